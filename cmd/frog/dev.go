@@ -0,0 +1,36 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+
+	"github.com/pondworks-lib/frog/dev"
+)
+
+func runDev(args []string) error {
+	fs := flag.NewFlagSet("dev", flag.ContinueOnError)
+	var cfg dev.Config
+	fs.DurationVar(&cfg.Interval, "interval", 0, "source polling interval (default 500ms)")
+	fs.StringVar(&cfg.StateFile, "state", "", "file carrying model state across restarts")
+	fs.Usage = func() {
+		fmt.Fprintln(os.Stderr, "usage: frog dev [-interval d] [-state file] [dir] [-- program args]")
+	}
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	rest := fs.Args()
+	if len(rest) > 0 && rest[0] != "--" {
+		cfg.Dir, rest = rest[0], rest[1:]
+	}
+	if len(rest) > 0 && rest[0] == "--" {
+		rest = rest[1:]
+	}
+	cfg.Args = rest
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	return dev.Run(ctx, cfg)
+}
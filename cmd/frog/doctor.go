@@ -0,0 +1,73 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/pondworks-lib/frog"
+)
+
+func runDoctor(args []string) error {
+	fs := flag.NewFlagSet("doctor", flag.ContinueOnError)
+	probe := fs.Bool("probe", true, "query the terminal instead of relying on environment heuristics")
+	timeout := fs.Duration("timeout", 500*time.Millisecond, "how long to wait for terminal replies")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	c := frog.Capabilities()
+	if *probe {
+		c = frog.ProbeCapabilities(*timeout)
+	}
+
+	source := "environment heuristics"
+	if c.Probed {
+		source = "terminal replies"
+	}
+	fmt.Printf("Terminal capabilities (from %s)\n\n", source)
+	tw := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintf(tw, "TERM\t%s\n", orNone(c.Term))
+	fmt.Fprintf(tw, "TERM_PROGRAM\t%s\n", orNone(os.Getenv("TERM_PROGRAM")))
+	fmt.Fprintf(tw, "tty\t%s\n", yesNo(c.TTY))
+	if c.TTY {
+		fmt.Fprintf(tw, "size\t%dx%d\n", c.Width, c.Height)
+	}
+	fmt.Fprintf(tw, "color\t%s\n", profileName(c.ColorProfile))
+	fmt.Fprintf(tw, "mouse (SGR)\t%s\n", yesNo(c.Mouse))
+	fmt.Fprintf(tw, "bracketed paste\t%s\n", yesNo(c.BracketedPaste))
+	fmt.Fprintf(tw, "kitty keyboard\t%s\n", yesNo(c.KittyKeyboard))
+	fmt.Fprintf(tw, "synchronized output\t%s\n", yesNo(c.SyncOutput))
+	fmt.Fprintf(tw, "sixel\t%s\n", yesNo(c.Sixel))
+	return tw.Flush()
+}
+
+func profileName(p frog.ColorProfile) string {
+	switch p {
+	case frog.ColorNone:
+		return "none"
+	case frog.ColorANSI16:
+		return "16 colors"
+	case frog.ColorANSI256:
+		return "256 colors"
+	case frog.ColorTrueColor:
+		return "truecolor (24-bit)"
+	}
+	return "auto"
+}
+
+func yesNo(b bool) string {
+	if b {
+		return "yes"
+	}
+	return "no"
+}
+
+func orNone(s string) string {
+	if s == "" {
+		return "(unset)"
+	}
+	return s
+}
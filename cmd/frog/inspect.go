@@ -0,0 +1,115 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/pondworks-lib/frog"
+)
+
+func runInspect(args []string) error {
+	fs := flag.NewFlagSet("inspect", flag.ContinueOnError)
+	sock := fs.String("sock", "", "inspector socket (default: the only frog-*.sock in the temporary directory)")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	what := fs.Arg(0)
+	switch what {
+	case "", frog.InspectModel, frog.InspectMessages, frog.InspectStats:
+	default:
+		return errors.New("usage: frog inspect [-sock path] [model|messages|stats]")
+	}
+	path := *sock
+	if path == "" {
+		var err error
+		if path, err = findInspector(); err != nil {
+			return err
+		}
+	}
+
+	conn, err := net.DialTimeout("unix", path, 2*time.Second)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	req, _ := json.Marshal(map[string]string{"type": what})
+	if _, err := conn.Write(append(req, '\n')); err != nil {
+		return err
+	}
+	r := bufio.NewScanner(conn)
+	r.Buffer(nil, 1<<26)
+	if !r.Scan() {
+		if r.Err() != nil {
+			return r.Err()
+		}
+		return errors.New("inspector closed the connection")
+	}
+	var rep frog.InspectReply
+	if err := json.Unmarshal(r.Bytes(), &rep); err != nil {
+		return err
+	}
+
+	if rep.Stats != nil {
+		printStats(*rep.Stats)
+	}
+	if rep.Messages != nil {
+		if what == "" {
+			fmt.Println("\nRecent messages")
+		}
+		for _, m := range rep.Messages {
+			fmt.Printf("%s  %-22s %s\n", m.Time.Format("15:04:05.000"), m.Type, m.Text)
+		}
+	}
+	if rep.Model != nil {
+		if what == "" {
+			fmt.Println("\nModel")
+		}
+		var out strings.Builder
+		enc := json.NewEncoder(&out)
+		enc.SetIndent("", "  ")
+		var v any
+		if err := json.Unmarshal(rep.Model, &v); err == nil && enc.Encode(v) == nil {
+			fmt.Print(out.String())
+		} else {
+			fmt.Println(string(rep.Model))
+		}
+	}
+	if rep.Error != "" {
+		return errors.New(rep.Error)
+	}
+	return nil
+}
+
+// findInspector returns the only inspector socket in the temporary
+// directory.
+func findInspector() (string, error) {
+	socks, _ := filepath.Glob(filepath.Join(os.TempDir(), "frog-*.sock"))
+	switch len(socks) {
+	case 0:
+		return "", fmt.Errorf("no inspector sockets in %s; run the program with FROG_INSPECT=1", os.TempDir())
+	case 1:
+		return socks[0], nil
+	}
+	return "", fmt.Errorf("several inspector sockets, pick one with -sock:\n  %s", strings.Join(socks, "\n  "))
+}
+
+func printStats(s frog.InspectedStats) {
+	tw := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintf(tw, "size\t%dx%d\n", s.Width, s.Height)
+	fmt.Fprintf(tw, "frames\t%d\n", s.Frames)
+	fmt.Fprintf(tw, "fps\t%.1f\n", s.FPS)
+	fmt.Fprintf(tw, "last frame\t%s (%d bytes)\n", s.LastFrame.Format("15:04:05.000"), s.LastBytes)
+	fmt.Fprintf(tw, "update\t%s\n", s.UpdateTime)
+	fmt.Fprintf(tw, "view\t%s\n", s.ViewTime)
+	fmt.Fprintf(tw, "queue\t%d/%d\n", s.Queue, s.QueueCap)
+	tw.Flush()
+}
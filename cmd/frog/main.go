@@ -0,0 +1,62 @@
+// Command frog is the Frog developer tool.
+//
+//	frog new <template> <dir>   generate a starter project
+//	frog dev [dir]              rebuild and restart on source changes
+//	frog doctor                 report terminal capabilities
+//	frog script <file>...       run end-to-end test scripts against a program
+//	frog preview [component]    preview a component standalone
+//	frog inspect [what]         query a running program's inspector
+package main
+
+import (
+	"fmt"
+	"os"
+)
+
+type command struct {
+	name    string
+	summary string
+	run     func(args []string) error
+}
+
+var commands []command
+
+func init() {
+	commands = []command{
+		{"new", "generate a starter project from a template", runNew},
+		{"dev", "run a program, rebuilding and restarting it on changes", runDev},
+		{"doctor", "report what the current terminal supports", runDoctor},
+		{"script", "run end-to-end test scripts over a program's control endpoint", runScript},
+		{"preview", "mount a component standalone with resizing, themes and a message log", runPreview},
+		{"inspect", "show a running program's model state, recent messages and render stats", runInspect},
+	}
+}
+
+func main() {
+	if len(os.Args) < 2 {
+		usage()
+		os.Exit(2)
+	}
+	name := os.Args[1]
+	for _, c := range commands {
+		if c.name == name {
+			if err := c.run(os.Args[2:]); err != nil {
+				fmt.Fprintf(os.Stderr, "frog %s: %v\n", name, err)
+				os.Exit(1)
+			}
+			return
+		}
+	}
+	if name != "help" && name != "-h" && name != "--help" {
+		fmt.Fprintf(os.Stderr, "frog: unknown command %q\n\n", name)
+	}
+	usage()
+	os.Exit(2)
+}
+
+func usage() {
+	fmt.Fprintln(os.Stderr, "usage: frog <command> [arguments]\n\ncommands:")
+	for _, c := range commands {
+		fmt.Fprintf(os.Stderr, "  %-8s %s\n", c.name, c.summary)
+	}
+}
@@ -0,0 +1,117 @@
+package main
+
+import (
+	"embed"
+	"errors"
+	"flag"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"text/template"
+)
+
+//go:embed templates
+var templatesFS embed.FS
+
+// templateData is passed to every template file.
+type templateData struct {
+	Name   string // project name (directory base name)
+	Module string // Go module path
+}
+
+func runNew(args []string) error {
+	fs := flag.NewFlagSet("new", flag.ContinueOnError)
+	module := fs.String("module", "", "module path for go.mod (default: project name)")
+	fs.Usage = func() {
+		fmt.Fprintf(os.Stderr, "usage: frog new [-module path] <template> <dir>\n\ntemplates: %s\n",
+			strings.Join(templateNames(), ", "))
+	}
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() != 2 {
+		fs.Usage()
+		return errors.New("expected a template and a directory")
+	}
+	tmpl, dir := fs.Arg(0), fs.Arg(1)
+	if !hasTemplate(tmpl) {
+		return fmt.Errorf("unknown template %q (available: %s)", tmpl, strings.Join(templateNames(), ", "))
+	}
+
+	data := templateData{Name: filepath.Base(dir), Module: *module}
+	if data.Module == "" {
+		data.Module = data.Name
+	}
+	if entries, err := os.ReadDir(dir); err == nil && len(entries) > 0 {
+		return fmt.Errorf("%s is not empty", dir)
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+
+	for _, src := range []string{"common", tmpl} {
+		if err := render(path.Join("templates", src), dir, data); err != nil {
+			return err
+		}
+	}
+	gomod := fmt.Sprintf("module %s\n\ngo 1.24\n", data.Module)
+	if err := os.WriteFile(filepath.Join(dir, "go.mod"), []byte(gomod), 0o644); err != nil {
+		return err
+	}
+
+	fmt.Printf("Created %s from the %q template.\n\n  cd %s\n  go get github.com/pondworks-lib/frog@latest\n  go run .\n",
+		dir, tmpl, dir)
+	return nil
+}
+
+// render executes every .tmpl file under root into dir, dropping the suffix.
+func render(root, dir string, data templateData) error {
+	return fs.WalkDir(templatesFS, root, func(p string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() || !strings.HasSuffix(p, ".tmpl") {
+			return err
+		}
+		src, err := templatesFS.ReadFile(p)
+		if err != nil {
+			return err
+		}
+		t, err := template.New(p).Parse(string(src))
+		if err != nil {
+			return err
+		}
+		rel := strings.TrimSuffix(strings.TrimPrefix(p, root+"/"), ".tmpl")
+		f, err := os.Create(filepath.Join(dir, filepath.FromSlash(rel)))
+		if err != nil {
+			return err
+		}
+		if err := t.Execute(f, data); err != nil {
+			f.Close()
+			return err
+		}
+		return f.Close()
+	})
+}
+
+func templateNames() []string {
+	entries, _ := templatesFS.ReadDir("templates")
+	var names []string
+	for _, e := range entries {
+		if e.IsDir() && e.Name() != "common" {
+			names = append(names, e.Name())
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+func hasTemplate(name string) bool {
+	for _, n := range templateNames() {
+		if n == name {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,100 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"strings"
+
+	"github.com/pondworks-lib/frog"
+	"github.com/pondworks-lib/frog/components/datepicker"
+	"github.com/pondworks-lib/frog/components/diff"
+	"github.com/pondworks-lib/frog/components/numberinput"
+	"github.com/pondworks-lib/frog/components/palette"
+	"github.com/pondworks-lib/frog/components/splitpane"
+	"github.com/pondworks-lib/frog/components/table"
+	"github.com/pondworks-lib/frog/components/viewport"
+	"github.com/pondworks-lib/frog/frogx/preview"
+)
+
+// builtinStories previews the components shipped with frog.
+func builtinStories() []preview.Story {
+	return []preview.Story{
+		{Name: "viewport", New: func(t preview.Theme) frog.Model {
+			return viewport.New(40, 10, viewport.WithScrollbar()).SetContent(sampleText(100))
+		}},
+		{Name: "splitpane", New: func(t preview.Theme) frog.Model {
+			left := viewport.New(20, 10).SetContent(sampleText(30))
+			right := viewport.New(20, 10).SetContent(sampleText(60))
+			return splitpane.New(left, right, splitpane.WithDivider("│", frog.NewStyle().Fg(t.Accent)))
+		}},
+		{Name: "diff", New: func(t preview.Theme) frog.Model {
+			m, _ := diff.New(60, 10).SetText(sampleDiff)
+			return m
+		}},
+		{Name: "numberinput", New: func(t preview.Theme) frog.Model {
+			return numberinput.New(5, numberinput.WithRange(0, 10))
+		}},
+		{Name: "palette", New: func(t preview.Theme) frog.Model {
+			var actions []palette.Action
+			for _, name := range []string{"Open File", "Save File", "Toggle Sidebar", "Go to Line", "Quit"} {
+				actions = append(actions, palette.Action{Name: name})
+			}
+			return palette.New(viewport.New(40, 10).SetContent(sampleText(30)), actions)
+		}},
+		{Name: "datepicker", New: func(t preview.Theme) frog.Model {
+			return datepicker.New(datepicker.WithStyles(
+				frog.NewStyle().Bg(t.Accent).Fg(t.Bg), frog.NewStyle().Underlined(), frog.NewStyle().Fainted()))
+		}},
+		{Name: "table", New: func(t preview.Theme) frog.Model {
+			cols := []table.Column{{Title: "City"}, {Title: "Country"}, {Title: "Population", Align: table.AlignRight}}
+			rows := []table.Row{
+				{"Tokyo", "Japan", "37400068"}, {"Delhi", "India", "28514000"},
+				{"Shanghai", "China", "25582000"}, {"São Paulo", "Brazil", "21650000"},
+				{"Mexico City", "Mexico", "21581000"}, {"Cairo", "Egypt", "20076000"},
+				{"Mumbai", "India", "19980000"}, {"Beijing", "China", "19618000"},
+			}
+			return table.New(cols, rows, 40, 10)
+		}},
+	}
+}
+
+const sampleDiff = `--- a/fox.txt
++++ b/fox.txt
+@@ -1,4 +1,4 @@
+ the quick brown fox
+-jumps over the lazy dog
++leaps over the sleepy dog
+ and runs away
+-into the woods
++into the forest
+`
+
+func sampleText(lines int) string {
+	rows := make([]string, lines)
+	for i := range rows {
+		rows[i] = fmt.Sprintf("%3d  the quick brown fox jumps over the lazy dog", i+1)
+	}
+	return strings.Join(rows, "\n")
+}
+
+func runPreview(args []string) error {
+	fs := flag.NewFlagSet("preview", flag.ContinueOnError)
+	width := fs.Int("w", 40, "initial component width")
+	height := fs.Int("h", 10, "initial component height")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	stories := builtinStories()
+	opts := []preview.Option{preview.WithSize(*width, *height)}
+	if name := fs.Arg(0); name != "" {
+		found := false
+		for _, s := range stories {
+			found = found || s.Name == name
+		}
+		if !found {
+			return fmt.Errorf("unknown component %q", name)
+		}
+		opts = append(opts, preview.WithStory(name))
+	}
+	return frog.Run(preview.New(stories, opts...), frog.WithAltScreen(), frog.WithMouse(), frog.WithHelp())
+}
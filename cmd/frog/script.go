@@ -0,0 +1,45 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/pondworks-lib/frog/script"
+)
+
+func runScript(args []string) error {
+	fs := flag.NewFlagSet("script", flag.ContinueOnError)
+	network := fs.String("net", "tcp", "network of the control endpoint (tcp or unix)")
+	addr := fs.String("addr", "127.0.0.1:7777", "address the program serves ServeControl on")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() == 0 {
+		return errors.New("usage: frog script [-net tcp|unix] [-addr address] file...")
+	}
+
+	c, err := script.Dial(*network, *addr)
+	if err != nil {
+		return err
+	}
+	defer c.Close()
+	for _, name := range fs.Args() {
+		f, err := os.Open(name)
+		if err != nil {
+			return err
+		}
+		s, err := script.Parse(name, f)
+		f.Close()
+		if err != nil {
+			return err
+		}
+		if err := s.Run(context.Background(), c); err != nil {
+			return err
+		}
+		fmt.Printf("ok  %s (%d steps)\n", name, len(s.Steps))
+	}
+	return nil
+}
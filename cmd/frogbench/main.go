@@ -0,0 +1,294 @@
+// Command frogbench renders synthetic workloads through Frog's renderer,
+// style, layout and input code and reports throughput, so performance
+// changes are measurable. The same workloads run as Go benchmarks in
+// core/bench_test.go; frogbench adds views of any size and baselines.
+//
+//	go run ./cmd/frogbench                      # run all workloads
+//	go run ./cmd/frogbench -run render -lines 20000
+//	go run ./cmd/frogbench -save base.json      # record a baseline
+//	go run ./cmd/frogbench -compare base.json   # fail on regressions
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+	"text/tabwriter"
+	"time"
+
+	"github.com/pondworks-lib/frog"
+)
+
+type config struct {
+	lines int
+	width int
+}
+
+type workload struct {
+	name string
+	fn   func(cfg config) func(b *testing.B)
+}
+
+// Result is one workload measurement, as saved by -save.
+type Result struct {
+	Name          string  `json:"name"`
+	NsPerOp       int64   `json:"ns_per_op"`
+	AllocsPerOp   int64   `json:"allocs_per_op"`
+	BytesPerOp    int64   `json:"bytes_per_op"`
+	WrittenPerOp  float64 `json:"written_per_op,omitempty"`
+	FramesPerSec  float64 `json:"frames_per_sec,omitempty"`
+	MsgsPerSecond float64 `json:"msgs_per_sec,omitempty"`
+}
+
+var workloads = []workload{
+	{"render/full", benchRenderFull},
+	{"render/diff", benchRenderDiff},
+	{"render/color", benchRenderColor},
+	{"style/render", benchStyleRender},
+	{"style/styled", benchStyled},
+	{"layout/center", benchLayoutCenter},
+	{"input/decode", benchInputDecode},
+}
+
+func main() {
+	var (
+		cfg        config
+		run        string
+		save       string
+		compare    string
+		maxRegress float64
+	)
+	flag.IntVar(&cfg.lines, "lines", 10000, "lines per synthetic view")
+	flag.IntVar(&cfg.width, "width", 120, "columns per synthetic line")
+	flag.StringVar(&run, "run", "", "only run workloads whose name contains this substring")
+	flag.StringVar(&save, "save", "", "write results as JSON to this file")
+	flag.StringVar(&compare, "compare", "", "compare against a JSON baseline written by -save")
+	flag.Float64Var(&maxRegress, "max-regress", 10, "allowed ns/op regression in percent when comparing")
+	flag.Parse()
+
+	var results []Result
+	tw := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, "workload\tns/op\tallocs/op\tB/op\twritten/op\tframes/s")
+	for _, w := range workloads {
+		if run != "" && !strings.Contains(w.name, run) {
+			continue
+		}
+		br := testing.Benchmark(w.fn(cfg))
+		res := Result{
+			Name:         w.name,
+			NsPerOp:      br.NsPerOp(),
+			AllocsPerOp:  br.AllocsPerOp(),
+			BytesPerOp:   br.AllocedBytesPerOp(),
+			WrittenPerOp: br.Extra["written/op"],
+		}
+		if res.NsPerOp > 0 && strings.HasPrefix(w.name, "render/") {
+			res.FramesPerSec = float64(time.Second) / float64(res.NsPerOp)
+		}
+		if v, ok := br.Extra["msgs/op"]; ok && res.NsPerOp > 0 {
+			res.MsgsPerSecond = v * float64(time.Second) / float64(res.NsPerOp)
+		}
+		results = append(results, res)
+		fmt.Fprintf(tw, "%s\t%d\t%d\t%d\t%.0f\t%.1f\n",
+			res.Name, res.NsPerOp, res.AllocsPerOp, res.BytesPerOp, res.WrittenPerOp, res.FramesPerSec)
+	}
+	tw.Flush()
+
+	if save != "" {
+		data, _ := json.MarshalIndent(results, "", "  ")
+		if err := os.WriteFile(save, data, 0o644); err != nil {
+			fmt.Fprintln(os.Stderr, "frogbench:", err)
+			os.Exit(1)
+		}
+	}
+	if compare != "" {
+		if !compareBaseline(compare, results, maxRegress) {
+			os.Exit(1)
+		}
+	}
+}
+
+// compareBaseline prints per-workload deltas and reports whether every
+// workload stayed within maxRegress percent of the baseline.
+func compareBaseline(path string, results []Result, maxRegress float64) bool {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "frogbench:", err)
+		return false
+	}
+	var base []Result
+	if err := json.Unmarshal(data, &base); err != nil {
+		fmt.Fprintln(os.Stderr, "frogbench: baseline:", err)
+		return false
+	}
+	byName := map[string]Result{}
+	for _, r := range base {
+		byName[r.Name] = r
+	}
+
+	ok := true
+	fmt.Println()
+	tw := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, "workload\tbase ns/op\tns/op\tdelta")
+	for _, r := range results {
+		b, found := byName[r.Name]
+		if !found || b.NsPerOp == 0 {
+			continue
+		}
+		delta := 100 * float64(r.NsPerOp-b.NsPerOp) / float64(b.NsPerOp)
+		mark := ""
+		if delta > maxRegress {
+			mark = "  REGRESSION"
+			ok = false
+		}
+		fmt.Fprintf(tw, "%s\t%d\t%d\t%+.1f%%%s\n", r.Name, b.NsPerOp, r.NsPerOp, delta, mark)
+	}
+	tw.Flush()
+	return ok
+}
+
+// ---- Workloads
+
+// countingWriter discards output but counts bytes, like a terminal would receive.
+type countingWriter struct{ n int64 }
+
+func (w *countingWriter) Write(p []byte) (int, error) {
+	w.n += int64(len(p))
+	return len(p), nil
+}
+
+func plainView(cfg config, frame int) string {
+	b := frog.NewViewBuilder()
+	defer b.Release()
+	line := strings.Repeat("x", cfg.width-12)
+	for i := 0; i < cfg.lines; i++ {
+		b.Line(fmt.Sprintf("%6d %4d ", i, frame), line)
+	}
+	return b.String()
+}
+
+func colorView(cfg config, frame int) string {
+	styles := []frog.Style{
+		frog.NewStyle().Fg(frog.ColorRed).Bolded(),
+		frog.NewStyle().Fg(frog.RGB(40, 200, 120)).Bg(frog.ANSI256(236)),
+		frog.NewStyle().Fg(frog.ANSI256(208)).Underlined(),
+	}
+	b := frog.NewViewBuilder()
+	defer b.Release()
+	for i := 0; i < cfg.lines; i++ {
+		b.Line()
+		for c := 0; c+10 <= cfg.width; c += 10 {
+			b.WriteStyled(styles[(i+c+frame)%len(styles)], "cell-text ")
+		}
+	}
+	return b.String()
+}
+
+func benchRenderFull(cfg config) func(b *testing.B) {
+	return func(b *testing.B) {
+		frames := [2]string{plainView(cfg, 0), plainView(cfg, 1)}
+		w := &countingWriter{}
+		r := frog.NewRenderer(w, frog.WithDiff(false), frog.WithColorProfile(frog.ColorTrueColor))
+		b.ReportAllocs()
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			r.Render(frames[i%2])
+		}
+		b.ReportMetric(float64(w.n)/float64(b.N), "written/op")
+	}
+}
+
+func benchRenderDiff(cfg config) func(b *testing.B) {
+	return func(b *testing.B) {
+		// Frames differ in a single line, the common case for dashboards.
+		base := strings.Split(plainView(cfg, 0), "\n")
+		frames := make([]string, 8)
+		for f := range frames {
+			lines := append([]string(nil), base...)
+			lines[(f*cfg.lines)/len(frames)] = fmt.Sprintf("changed line in frame %d", f)
+			frames[f] = strings.Join(lines, "\n")
+		}
+		w := &countingWriter{}
+		r := frog.NewRenderer(w, frog.WithColorProfile(frog.ColorTrueColor))
+		r.Render(frames[0])
+		w.n = 0
+		b.ReportAllocs()
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			r.Render(frames[(i+1)%len(frames)])
+		}
+		b.ReportMetric(float64(w.n)/float64(b.N), "written/op")
+	}
+}
+
+func benchRenderColor(cfg config) func(b *testing.B) {
+	return func(b *testing.B) {
+		frames := [2]string{colorView(cfg, 0), colorView(cfg, 1)}
+		w := &countingWriter{}
+		r := frog.NewRenderer(w, frog.WithColorProfile(frog.ColorTrueColor))
+		b.ReportAllocs()
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			r.Render(frames[i%2])
+		}
+		b.ReportMetric(float64(w.n)/float64(b.N), "written/op")
+	}
+}
+
+func benchStyleRender(config) func(b *testing.B) {
+	return func(b *testing.B) {
+		st := frog.NewStyle().Fg(frog.RGB(200, 100, 50)).Bg(frog.ANSI256(17)).Bolded().Underlined()
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			_ = st.Render("table cell")
+		}
+	}
+}
+
+func benchStyled(config) func(b *testing.B) {
+	return func(b *testing.B) {
+		prefix := frog.NewStyle().Fg(frog.RGB(200, 100, 50)).Bg(frog.ANSI256(17)).Bolded().Prefix()
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			_ = frog.Styled(prefix, "table cell")
+		}
+	}
+}
+
+func benchLayoutCenter(cfg config) func(b *testing.B) {
+	return func(b *testing.B) {
+		block := colorView(config{lines: 40, width: 60}, 0)
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			_ = frog.Center(block, cfg.width, 50)
+		}
+	}
+}
+
+func benchInputDecode(config) func(b *testing.B) {
+	return func(b *testing.B) {
+		// Mix of runes, UTF-8, arrows, SGR mouse and a bracketed paste.
+		chunk := "hello wörld\x1b[A\x1b[B\x1b[3~\x1b[<0;10;5M\x1b[<0;10;5m" +
+			"\x1b[200~pasted text\x1b[201~"
+		const repeat = 256
+		input := strings.Repeat(chunk, repeat)
+		b.ReportAllocs()
+		b.SetBytes(int64(len(input)))
+		var msgs int
+		for i := 0; i < b.N; i++ {
+			for buf := []byte(input); len(buf) > 0; {
+				msg, n := frog.ParseSequence(buf)
+				if n == 0 {
+					break
+				}
+				if msg != nil {
+					msgs++
+				}
+				buf = buf[n:]
+			}
+		}
+		b.ReportMetric(float64(msgs)/float64(b.N), "msgs/op")
+	}
+}
@@ -0,0 +1,281 @@
+// Package datepicker provides a month calendar for picking a date with the
+// keyboard, optionally limited to a range of dates.
+package datepicker
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/pondworks-lib/frog"
+)
+
+// SelectedDateMsg is emitted when the user confirms a date.
+type SelectedDateMsg struct{ Date time.Time }
+
+// KeyMap names the keys the picker handles besides the arrow keys (move by
+// day and week) and PgUp/PgDn (move by month). Keys are written as
+// KeyMsg.String.
+type KeyMap struct {
+	PrevMonth string
+	NextMonth string
+	PrevYear  string
+	NextYear  string
+	Today     string
+	Select    string
+}
+
+// DefaultKeyMap is the key map used by New.
+var DefaultKeyMap = KeyMap{
+	PrevMonth: "[",
+	NextMonth: "]",
+	PrevYear:  "{",
+	NextYear:  "}",
+	Today:     "t",
+	Select:    "\r",
+}
+
+// Bindings describes the picker's keys for a help overlay.
+func (k KeyMap) Bindings() []frog.Binding {
+	sel := k.Select
+	if sel == "\r" {
+		sel = "enter"
+	}
+	return []frog.Binding{
+		{Keys: "←/→", Help: "previous/next day"},
+		{Keys: "↑/↓", Help: "previous/next week"},
+		{Keys: "pgup/pgdn " + k.PrevMonth + "/" + k.NextMonth, Help: "previous/next month"},
+		{Keys: k.PrevYear + "/" + k.NextYear, Help: "previous/next year"},
+		{Keys: k.Today, Help: "today"},
+		{Keys: sel, Help: "select date"},
+	}
+}
+
+// Model is a date picker.
+type Model struct {
+	cursor    time.Time // highlighted day, at midnight
+	today     time.Time
+	min, max  time.Time // zero for no limit
+	weekStart time.Weekday
+	keys      KeyMap
+
+	selectedStyle frog.Style
+	todayStyle    frog.Style
+	disabledStyle frog.Style
+	headerStyle   frog.Style
+}
+
+// Option configures a Model.
+type Option func(*Model)
+
+// WithDate sets the initially highlighted date (default today).
+func WithDate(t time.Time) Option { return func(m *Model) { m.cursor = day(t) } }
+
+// WithRange limits selection to min..max inclusive; a zero time leaves that
+// end open.
+func WithRange(min, max time.Time) Option {
+	return func(m *Model) {
+		if !min.IsZero() {
+			m.min = day(min)
+		}
+		if !max.IsZero() {
+			m.max = day(max)
+		}
+	}
+}
+
+// WithWeekStart sets the first column of the calendar (default Monday).
+func WithWeekStart(d time.Weekday) Option { return func(m *Model) { m.weekStart = d } }
+
+// WithLocale sets the week start customary for a BCP 47 locale such as
+// "en-US" or "de-DE". See WeekStart.
+func WithLocale(locale string) Option { return func(m *Model) { m.weekStart = WeekStart(locale) } }
+
+// WithKeyMap replaces the default key map.
+func WithKeyMap(k KeyMap) Option { return func(m *Model) { m.keys = k } }
+
+// WithStyles sets the styles for the highlighted day, today and days
+// outside the allowed range.
+func WithStyles(selected, today, disabled frog.Style) Option {
+	return func(m *Model) { m.selectedStyle, m.todayStyle, m.disabledStyle = selected, today, disabled }
+}
+
+// New creates a date picker.
+func New(opts ...Option) Model {
+	now := day(time.Now())
+	m := Model{
+		cursor:        now,
+		today:         now,
+		weekStart:     time.Monday,
+		keys:          DefaultKeyMap,
+		selectedStyle: frog.NewStyle().Reversed(),
+		todayStyle:    frog.NewStyle().Underlined(),
+		disabledStyle: frog.NewStyle().Fainted(),
+		headerStyle:   frog.NewStyle().Bolded(),
+	}
+	for _, o := range opts {
+		o(&m)
+	}
+	m.cursor = m.clamp(m.cursor)
+	return m
+}
+
+// sundayLocales are regions where weeks customarily start on Sunday, and
+// saturdayLocales those where they start on Saturday (CLDR firstDay).
+var (
+	sundayLocales = map[string]bool{
+		"US": true, "CA": true, "MX": true, "BR": true, "JP": true, "KR": true,
+		"CN": true, "TW": true, "HK": true, "IN": true, "IL": true, "PH": true,
+		"ZA": true, "AU": true, "SA": true, "PE": true, "CO": true, "VE": true,
+	}
+	saturdayLocales = map[string]bool{
+		"AE": true, "AF": true, "BH": true, "DZ": true, "EG": true, "IQ": true,
+		"IR": true, "JO": true, "KW": true, "LY": true, "OM": true, "QA": true,
+		"SD": true, "SY": true,
+	}
+)
+
+// WeekStart returns the customary first day of the week for a BCP 47
+// locale ("en-US", "fr_FR", "ar-EG"), defaulting to Monday (ISO 8601).
+// A bare language uses its most common region ("en" → US, "ja" → JP).
+func WeekStart(locale string) time.Weekday {
+	locale = strings.ReplaceAll(locale, "_", "-")
+	if i := strings.IndexAny(locale, ".@"); i >= 0 {
+		locale = locale[:i] // POSIX forms such as en_US.UTF-8
+	}
+	lang, region, _ := strings.Cut(locale, "-")
+	if region == "" {
+		region = map[string]string{
+			"en": "US", "ja": "JP", "ko": "KR", "zh": "CN", "he": "IL",
+			"pt": "BR", "hi": "IN", "ar": "EG", "fa": "IR",
+		}[strings.ToLower(lang)]
+	}
+	region = strings.ToUpper(region)
+	switch {
+	case sundayLocales[region]:
+		return time.Sunday
+	case saturdayLocales[region]:
+		return time.Saturday
+	}
+	return time.Monday
+}
+
+// Date returns the highlighted date.
+func (m Model) Date() time.Time { return m.cursor }
+
+// SetDate highlights t, clamped to the allowed range.
+func (m Model) SetDate(t time.Time) Model {
+	m.cursor = m.clamp(day(t))
+	return m
+}
+
+// Init implements frog.Model.
+func (m Model) Init() frog.Cmd { return nil }
+
+// KeyBindings implements frog.KeyHelper.
+func (m Model) KeyBindings() []frog.Binding { return m.keys.Bindings() }
+
+// Update moves the highlight and emits SelectedDateMsg on Select.
+func (m Model) Update(msg frog.Msg) (frog.Model, frog.Cmd) {
+	k, ok := msg.(frog.KeyMsg)
+	if !ok {
+		return m, nil
+	}
+	c := m.cursor
+	switch k.Type {
+	case frog.KeyLeft:
+		c = c.AddDate(0, 0, -1)
+	case frog.KeyRight:
+		c = c.AddDate(0, 0, 1)
+	case frog.KeyUp:
+		c = c.AddDate(0, 0, -7)
+	case frog.KeyDown:
+		c = c.AddDate(0, 0, 7)
+	case frog.KeyPgUp:
+		c = addMonths(c, -1)
+	case frog.KeyPgDn:
+		c = addMonths(c, 1)
+	default:
+		switch k.String {
+		case m.keys.PrevMonth:
+			c = addMonths(c, -1)
+		case m.keys.NextMonth:
+			c = addMonths(c, 1)
+		case m.keys.PrevYear:
+			c = addMonths(c, -12)
+		case m.keys.NextYear:
+			c = addMonths(c, 12)
+		case m.keys.Today:
+			c = m.today
+		case m.keys.Select:
+			date := m.cursor
+			return m, func() frog.Msg { return SelectedDateMsg{Date: date} }
+		}
+	}
+	m.cursor = m.clamp(c)
+	return m, nil
+}
+
+// View renders the month containing the highlighted date.
+func (m Model) View() string {
+	first := time.Date(m.cursor.Year(), m.cursor.Month(), 1, 0, 0, 0, 0, m.cursor.Location())
+	title := fmt.Sprintf("%s %d", m.cursor.Month(), m.cursor.Year())
+	pad := (20 - len(title)) / 2
+	rows := []string{m.headerStyle.Render(strings.Repeat(" ", max(pad, 0)) + title)}
+
+	names := make([]string, 7)
+	for i := range names {
+		names[i] = ((m.weekStart + time.Weekday(i)) % 7).String()[:2]
+	}
+	rows = append(rows, strings.Join(names, " "))
+
+	lead := (int(first.Weekday()) - int(m.weekStart) + 7) % 7
+	cells := make([]string, 0, 42)
+	for i := 0; i < lead; i++ {
+		cells = append(cells, "  ")
+	}
+	for d := first; d.Month() == first.Month(); d = d.AddDate(0, 0, 1) {
+		cell := fmt.Sprintf("%2d", d.Day())
+		switch {
+		case d.Equal(m.cursor):
+			cell = m.selectedStyle.Render(cell)
+		case !m.allowed(d):
+			cell = m.disabledStyle.Render(cell)
+		case d.Equal(m.today):
+			cell = m.todayStyle.Render(cell)
+		}
+		cells = append(cells, cell)
+	}
+	for i := 0; i < len(cells); i += 7 {
+		rows = append(rows, strings.Join(cells[i:min(i+7, len(cells))], " "))
+	}
+	return strings.Join(rows, "\n")
+}
+
+func (m Model) allowed(d time.Time) bool {
+	return (m.min.IsZero() || !d.Before(m.min)) && (m.max.IsZero() || !d.After(m.max))
+}
+
+func (m Model) clamp(d time.Time) time.Time {
+	if !m.min.IsZero() && d.Before(m.min) {
+		return m.min
+	}
+	if !m.max.IsZero() && d.After(m.max) {
+		return m.max
+	}
+	return d
+}
+
+// day truncates t to midnight in its location.
+func day(t time.Time) time.Time {
+	y, mo, d := t.Date()
+	return time.Date(y, mo, d, 0, 0, 0, 0, t.Location())
+}
+
+// addMonths moves n months, keeping the day of month where it exists and
+// using the month's last day otherwise (Jan 31 + 1 month = Feb 28/29).
+func addMonths(t time.Time, n int) time.Time {
+	first := time.Date(t.Year(), t.Month()+time.Month(n), 1, 0, 0, 0, 0, t.Location())
+	last := first.AddDate(0, 1, -1).Day()
+	return first.AddDate(0, 0, min(t.Day(), last)-1)
+}
@@ -0,0 +1,384 @@
+// Package diff renders unified diffs, either unified or side by side, with
+// the changed part of modified lines emphasised, optional syntax colouring
+// and viewport scrolling.
+package diff
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/pondworks-lib/frog"
+	"github.com/pondworks-lib/frog/components/viewport"
+	"github.com/pondworks-lib/frog/core/text"
+)
+
+// Layout selects how a diff is drawn.
+type Layout int
+
+const (
+	Unified    Layout = iota // removed lines above added lines, one column
+	SideBySide               // old file on the left, new file on the right
+)
+
+// Highlighter colours one line of source code from the file at path. It is
+// applied to context lines and to added and removed lines that have no
+// intraline emphasis.
+type Highlighter func(path, line string) string
+
+// KeyMap names the keys the diff view handles besides the viewport's. Keys
+// are written as KeyMsg.String.
+type KeyMap struct {
+	Layout   string // switch between unified and side by side
+	NextHunk string
+	PrevHunk string
+}
+
+// DefaultKeyMap is the key map used by New.
+var DefaultKeyMap = KeyMap{Layout: "s", NextHunk: "n", PrevHunk: "p"}
+
+// Bindings describes the keys for a help overlay.
+func (k KeyMap) Bindings() []frog.Binding {
+	return []frog.Binding{
+		{Keys: k.NextHunk + "/" + k.PrevHunk, Help: "next/previous hunk"},
+		{Keys: k.Layout, Help: "unified/side by side"},
+	}
+}
+
+// Model is a scrollable diff view.
+type Model struct {
+	files         []File
+	layout        Layout
+	width, height int
+	vp            viewport.Model
+	hunkRows      []int // first rendered row of each hunk
+	keys          KeyMap
+	highlight     Highlighter
+	tabWidth      int
+
+	addStyle, delStyle   frog.Style
+	addEmph, delEmph     frog.Style
+	gutterStyle          frog.Style
+	fileStyle, hunkStyle frog.Style
+}
+
+// Option configures a Model.
+type Option func(*Model)
+
+// WithLayout sets the initial layout (default Unified).
+func WithLayout(l Layout) Option { return func(m *Model) { m.layout = l } }
+
+// WithKeyMap replaces the default key map.
+func WithKeyMap(k KeyMap) Option { return func(m *Model) { m.keys = k } }
+
+// WithHighlighter sets the syntax highlighter for source lines.
+func WithHighlighter(h Highlighter) Option { return func(m *Model) { m.highlight = h } }
+
+// WithTabWidth sets how many spaces a tab expands to (default 4).
+func WithTabWidth(n int) Option {
+	return func(m *Model) {
+		if n > 0 {
+			m.tabWidth = n
+		}
+	}
+}
+
+// WithStyles sets the styles for added lines, removed lines and the line
+// number gutter.
+func WithStyles(added, removed, gutter frog.Style) Option {
+	return func(m *Model) { m.addStyle, m.delStyle, m.gutterStyle = added, removed, gutter }
+}
+
+// WithEmphasis sets the styles for the changed part of modified lines
+// (default the added and removed colours reversed).
+func WithEmphasis(added, removed frog.Style) Option {
+	return func(m *Model) { m.addEmph, m.delEmph = added, removed }
+}
+
+// WithViewport passes options to the underlying viewport.
+func WithViewport(opts ...viewport.Option) Option {
+	return func(m *Model) { m.vp = viewport.New(m.width, m.height, opts...) }
+}
+
+// New creates an empty diff view of the given size.
+func New(width, height int, opts ...Option) Model {
+	m := Model{
+		width:       width,
+		height:      height,
+		keys:        DefaultKeyMap,
+		tabWidth:    4,
+		addStyle:    frog.NewStyle().Fg(frog.ColorGreen),
+		delStyle:    frog.NewStyle().Fg(frog.ColorRed),
+		addEmph:     frog.NewStyle().Fg(frog.ColorGreen).Reversed(),
+		delEmph:     frog.NewStyle().Fg(frog.ColorRed).Reversed(),
+		gutterStyle: frog.NewStyle().Fainted(),
+		fileStyle:   frog.NewStyle().Bolded(),
+		hunkStyle:   frog.NewStyle().Fg(frog.ColorCyan),
+	}
+	m.vp = viewport.New(width, height)
+	for _, o := range opts {
+		o(&m)
+	}
+	return m.render()
+}
+
+// SetFiles replaces the diff shown.
+func (m Model) SetFiles(files []File) Model {
+	m.files = files
+	return m.render()
+}
+
+// SetText parses unified diff text and shows it.
+func (m Model) SetText(s string) (Model, error) {
+	files, err := Parse(s)
+	if err != nil {
+		return m, err
+	}
+	return m.SetFiles(files), nil
+}
+
+// Files returns the diff shown.
+func (m Model) Files() []File { return m.files }
+
+// Layout returns the current layout.
+func (m Model) Layout() Layout { return m.layout }
+
+// SetLayout switches the layout.
+func (m Model) SetLayout(l Layout) Model {
+	m.layout = l
+	return m.render()
+}
+
+// SetSize resizes the view.
+func (m Model) SetSize(width, height int) Model {
+	m.width, m.height = width, height
+	m.vp = m.vp.SetSize(width, height)
+	return m.render()
+}
+
+// SetPosition records where the view is drawn (0-based screen coordinates)
+// so mouse events can be mapped to text.
+func (m Model) SetPosition(x, y int) Model {
+	m.vp = m.vp.SetPosition(x, y)
+	return m
+}
+
+// Init implements frog.Model.
+func (m Model) Init() frog.Cmd { return nil }
+
+// KeyBindings implements frog.KeyHelper.
+func (m Model) KeyBindings() []frog.Binding {
+	return append(m.keys.Bindings(), m.vp.KeyBindings()...)
+}
+
+// Update handles the layout and hunk keys and passes everything else to the
+// viewport.
+func (m Model) Update(msg frog.Msg) (frog.Model, frog.Cmd) {
+	switch msg := msg.(type) {
+	case frog.ResizeMsg:
+		return m.SetSize(msg.Width, msg.Height), nil
+	case frog.KeyMsg:
+		switch msg.String {
+		case m.keys.Layout:
+			return m.SetLayout(1 - m.layout), nil
+		case m.keys.NextHunk:
+			for _, r := range m.hunkRows {
+				if r > m.vp.YOffset() {
+					m.vp = m.vp.SetYOffset(r)
+					break
+				}
+			}
+			return m, nil
+		case m.keys.PrevHunk:
+			for i := len(m.hunkRows) - 1; i >= 0; i-- {
+				if r := m.hunkRows[i]; r < m.vp.YOffset() {
+					m.vp = m.vp.SetYOffset(r)
+					break
+				}
+			}
+			return m, nil
+		}
+	}
+	vp, cmd := m.vp.Update(msg)
+	m.vp = vp.(viewport.Model)
+	return m, cmd
+}
+
+// View renders the visible part of the diff.
+func (m Model) View() string { return m.vp.View() }
+
+// span is the changed part [lo, hi) of a modified line, in bytes.
+type span struct {
+	lo, hi int
+	ok     bool
+}
+
+// row is one rendered line of a side-by-side diff, holding the indices of
+// its old and new lines; either side may be missing (-1).
+type row struct {
+	old, new   int
+	oldS, newS span
+}
+
+// render lays out the diff and hands it to the viewport.
+func (m Model) render() Model {
+	var rows []string
+	m.hunkRows = m.hunkRows[:0:0]
+	for fi, f := range m.files {
+		if fi > 0 {
+			rows = append(rows, "")
+		}
+		path := f.Path()
+		rows = append(rows, m.fileStyle.Render(path))
+		for _, h := range f.Hunks {
+			m.hunkRows = append(m.hunkRows, len(rows))
+			head := fmt.Sprintf("@@ -%d,%d +%d,%d @@", h.OldStart, h.OldLines, h.NewStart, h.NewLines)
+			if h.Header != "" {
+				head += " " + h.Header
+			}
+			rows = append(rows, m.hunkStyle.Render(head))
+			lines := make([]Line, len(h.Lines))
+			for i, l := range h.Lines {
+				l.Text = strings.ReplaceAll(l.Text, "\t", strings.Repeat(" ", m.tabWidth))
+				lines[i] = l
+			}
+			if m.layout == SideBySide {
+				rows = append(rows, m.sideBySide(path, lines)...)
+			} else {
+				rows = append(rows, m.unified(path, lines)...)
+			}
+		}
+	}
+	m.vp = m.vp.SetContent(strings.Join(rows, "\n"))
+	return m
+}
+
+func (m Model) unified(path string, lines []Line) []string {
+	spans := make([]span, len(lines))
+	for _, r := range pair(lines) {
+		if r.old >= 0 && r.new >= 0 && r.old != r.new {
+			spans[r.old], spans[r.new] = r.oldS, r.newS
+		}
+	}
+	out := make([]string, len(lines))
+	for i, l := range lines {
+		gutter := m.gutterStyle.Render(fmt.Sprintf("%4s %4s ", lineNo(l.Old), lineNo(l.New)))
+		out[i] = gutter + m.line(path, l, spans[i])
+	}
+	return out
+}
+
+func (m Model) sideBySide(path string, lines []Line) []string {
+	left := max((m.width-1)/2, 1)
+	right := max(m.width-left-1, 1)
+	sep := m.gutterStyle.Render("│")
+	var out []string
+	for _, r := range pair(lines) {
+		out = append(out, m.cell(path, lines, r.old, r.oldS, left, true)+sep+m.cell(path, lines, r.new, r.newS, right, false))
+	}
+	return out
+}
+
+// cell renders one side of a side-by-side row, padded to w columns.
+func (m Model) cell(path string, lines []Line, i int, s span, w int, old bool) string {
+	var c string
+	if i >= 0 {
+		l := lines[i]
+		n := l.New
+		if old {
+			n = l.Old
+		}
+		c = m.gutterStyle.Render(fmt.Sprintf("%4s ", lineNo(n))) + m.line(path, l, s)
+	}
+	c = frog.Truncate(c, w)
+	return c + strings.Repeat(" ", max(w-frog.DisplayWidth(c), 0))
+}
+
+// line renders a line's sign and text.
+func (m Model) line(path string, l Line, s span) string {
+	base, emph, sign := frog.NewStyle(), frog.NewStyle(), " "
+	switch l.Kind {
+	case Added:
+		base, emph, sign = m.addStyle, m.addEmph, "+"
+	case Removed:
+		base, emph, sign = m.delStyle, m.delEmph, "-"
+	}
+	switch {
+	case s.ok:
+		return base.Render(sign+l.Text[:s.lo]) + emph.Render(l.Text[s.lo:s.hi]) + base.Render(l.Text[s.hi:])
+	case m.highlight != nil:
+		return base.Render(sign) + m.highlight(path, l.Text)
+	case l.Kind == Context:
+		return sign + l.Text
+	}
+	return base.Render(sign + l.Text)
+}
+
+// pair lines up a hunk for side-by-side display: context lines appear on
+// both sides, and each run of removed lines is matched with the run of
+// added lines that follows it, row by row.
+func pair(lines []Line) []row {
+	var rows []row
+	for i := 0; i < len(lines); {
+		if lines[i].Kind == Context {
+			rows = append(rows, row{old: i, new: i})
+			i++
+			continue
+		}
+		del := i
+		for i < len(lines) && lines[i].Kind == Removed {
+			i++
+		}
+		add := i
+		for i < len(lines) && lines[i].Kind == Added {
+			i++
+		}
+		nDel, nAdd := add-del, i-add
+		for j := 0; j < max(nDel, nAdd); j++ {
+			r := row{old: -1, new: -1}
+			if j < nDel {
+				r.old = del + j
+			}
+			if j < nAdd {
+				r.new = add + j
+			}
+			if r.old >= 0 && r.new >= 0 {
+				r.oldS, r.newS = intraline(lines[r.old].Text, lines[r.new].Text)
+			}
+			rows = append(rows, r)
+		}
+	}
+	return rows
+}
+
+// intraline finds the changed middle of two versions of a line by
+// trimming their common prefix and suffix, grapheme by grapheme. Lines
+// with nothing in common get no emphasis.
+func intraline(a, b string) (span, span) {
+	ga, gb := text.Graphemes(a), text.Graphemes(b)
+	p := 0
+	for p < len(ga) && p < len(gb) && ga[p] == gb[p] {
+		p++
+	}
+	s := 0
+	for s < len(ga)-p && s < len(gb)-p && ga[len(ga)-1-s] == gb[len(gb)-1-s] {
+		s++
+	}
+	if p+s == 0 {
+		return span{}, span{}
+	}
+	return makeSpan(ga, p, s), makeSpan(gb, p, s)
+}
+
+func makeSpan(g []string, prefix, suffix int) span {
+	lo := len(strings.Join(g[:prefix], ""))
+	hi := lo + len(strings.Join(g[prefix:len(g)-suffix], ""))
+	return span{lo: lo, hi: hi, ok: true}
+}
+
+func lineNo(n int) string {
+	if n == 0 {
+		return ""
+	}
+	return strconv.Itoa(n)
+}
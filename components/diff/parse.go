@@ -0,0 +1,162 @@
+package diff
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Kind classifies a diff line.
+type Kind int
+
+const (
+	Context Kind = iota
+	Added
+	Removed
+)
+
+// Line is one line of a hunk. Old and New are 1-based line numbers in the
+// old and new file; the one a line does not exist in is 0.
+type Line struct {
+	Kind     Kind
+	Text     string
+	Old, New int
+}
+
+// Hunk is a run of changes with its context.
+type Hunk struct {
+	OldStart, OldLines int
+	NewStart, NewLines int
+	Header             string // the text after the second "@@", usually a function name
+	Lines              []Line
+}
+
+// File is the diff of one file.
+type File struct {
+	OldPath, NewPath string // "/dev/null" for created or deleted files
+	Hunks            []Hunk
+}
+
+// Path returns the path to show for f: the new path unless the file was
+// deleted.
+func (f File) Path() string {
+	if f.NewPath == "" || f.NewPath == "/dev/null" {
+		return f.OldPath
+	}
+	return f.NewPath
+}
+
+// Parse reads unified diff text such as the output of diff -u or git diff.
+// Lines outside hunks other than the file headers (index, mode and similar
+// git extended headers) are ignored.
+func Parse(s string) ([]File, error) {
+	var (
+		files            []File
+		file             *File
+		hunk             *Hunk
+		oldNo, newNo     int
+		oldLeft, newLeft int
+	)
+	startFile := func() {
+		files = append(files, File{})
+		file = &files[len(files)-1]
+		hunk = nil
+	}
+	for i, l := range strings.Split(strings.TrimSuffix(s, "\n"), "\n") {
+		l = strings.TrimSuffix(l, "\r")
+		if hunk != nil && (oldLeft > 0 || newLeft > 0) {
+			if l == "" {
+				l = " " // some tools strip the space from empty context lines
+			}
+			line := Line{Text: l[1:]}
+			switch l[0] {
+			case ' ':
+				line.Kind, line.Old, line.New = Context, oldNo, newNo
+				oldNo, newNo, oldLeft, newLeft = oldNo+1, newNo+1, oldLeft-1, newLeft-1
+			case '-':
+				line.Kind, line.Old = Removed, oldNo
+				oldNo, oldLeft = oldNo+1, oldLeft-1
+			case '+':
+				line.Kind, line.New = Added, newNo
+				newNo, newLeft = newNo+1, newLeft-1
+			case '\\': // "\ No newline at end of file"
+				continue
+			default:
+				return nil, fmt.Errorf("diff: line %d: unexpected %q in hunk", i+1, l)
+			}
+			hunk.Lines = append(hunk.Lines, line)
+			continue
+		}
+		switch {
+		case strings.HasPrefix(l, "diff "):
+			startFile()
+			if a, b, ok := strings.Cut(strings.TrimPrefix(l, "diff --git "), " b/"); ok {
+				file.OldPath, file.NewPath = strings.TrimPrefix(a, "a/"), b
+			}
+		case strings.HasPrefix(l, "--- "):
+			if file == nil || len(file.Hunks) > 0 {
+				startFile()
+			}
+			file.OldPath = headerPath(l[4:], "a/")
+		case strings.HasPrefix(l, "+++ "):
+			if file == nil {
+				startFile()
+			}
+			file.NewPath = headerPath(l[4:], "b/")
+		case strings.HasPrefix(l, "@@ "):
+			if file == nil {
+				startFile()
+			}
+			var err error
+			var h Hunk
+			h.OldStart, h.OldLines, h.NewStart, h.NewLines, h.Header, err = parseHunkHeader(l)
+			if err != nil {
+				return nil, fmt.Errorf("diff: line %d: %w", i+1, err)
+			}
+			oldNo, oldLeft, newNo, newLeft = h.OldStart, h.OldLines, h.NewStart, h.NewLines
+			file.Hunks = append(file.Hunks, h)
+			hunk = &file.Hunks[len(file.Hunks)-1]
+		}
+	}
+	return files, nil
+}
+
+// headerPath extracts the path from a "---" or "+++" header, dropping the
+// git prefix and any timestamp diff -u appends after a tab.
+func headerPath(s, prefix string) string {
+	s, _, _ = strings.Cut(s, "\t")
+	if s == "/dev/null" {
+		return s
+	}
+	return strings.TrimPrefix(s, prefix)
+}
+
+// parseHunkHeader parses "@@ -l,s +l,s @@ header".
+func parseHunkHeader(l string) (oldStart, oldLen, newStart, newLen int, header string, err error) {
+	rest, ok := strings.CutPrefix(l, "@@ ")
+	ranges, header, ok2 := strings.Cut(rest, " @@")
+	old, nw, ok3 := strings.Cut(ranges, " ")
+	if !ok || !ok2 || !ok3 || !strings.HasPrefix(old, "-") || !strings.HasPrefix(nw, "+") {
+		return 0, 0, 0, 0, "", fmt.Errorf("bad hunk header %q", l)
+	}
+	if oldStart, oldLen, err = parseRange(old[1:]); err == nil {
+		newStart, newLen, err = parseRange(nw[1:])
+	}
+	if err != nil {
+		return 0, 0, 0, 0, "", fmt.Errorf("bad hunk header %q", l)
+	}
+	return oldStart, oldLen, newStart, newLen, strings.TrimPrefix(header, " "), nil
+}
+
+// parseRange parses "start,len" or "start" (length 1).
+func parseRange(s string) (start, n int, err error) {
+	a, b, ok := strings.Cut(s, ",")
+	if start, err = strconv.Atoi(a); err != nil {
+		return 0, 0, err
+	}
+	n = 1
+	if ok {
+		n, err = strconv.Atoi(b)
+	}
+	return start, n, err
+}
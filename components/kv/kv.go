@@ -0,0 +1,205 @@
+// Package kv renders aligned label/value pairs grouped under optional
+// section headers, wrapping long values to the available width — the
+// layout of "details" panes.
+package kv
+
+import (
+	"strings"
+
+	"github.com/pondworks-lib/frog"
+	"github.com/pondworks-lib/frog/core/text"
+)
+
+// Pair is one label and its value.
+type Pair struct {
+	Label, Value string
+}
+
+// Section is a group of pairs under a title. The first section may have an
+// empty title, which renders no header.
+type Section struct {
+	Title string
+	Pairs []Pair
+}
+
+// Model is a key/value details view.
+type Model struct {
+	sections   []Section
+	width      int
+	maxLabel   float64 // largest share of the width the label column may take
+	separator  string
+	labelStyle frog.Style
+	valueStyle frog.Style
+	titleStyle frog.Style
+}
+
+// Option configures a Model.
+type Option func(*Model)
+
+// WithLabelShare caps the label column at share (0..1) of the width; longer
+// labels wrap (default 0.4).
+func WithLabelShare(share float64) Option {
+	return func(m *Model) {
+		if share > 0 && share < 1 {
+			m.maxLabel = share
+		}
+	}
+}
+
+// WithSeparator sets the text between the label and value columns
+// (default "  ").
+func WithSeparator(s string) Option { return func(m *Model) { m.separator = s } }
+
+// WithStyles sets the label, value and section title styles.
+func WithStyles(label, value, title frog.Style) Option {
+	return func(m *Model) { m.labelStyle, m.valueStyle, m.titleStyle = label, value, title }
+}
+
+// New creates an empty view width columns wide.
+func New(width int, opts ...Option) Model {
+	m := Model{
+		width:      width,
+		maxLabel:   0.4,
+		separator:  "  ",
+		labelStyle: frog.NewStyle().Fainted(),
+		valueStyle: frog.NewStyle(),
+		titleStyle: frog.NewStyle().Bolded(),
+	}
+	for _, o := range opts {
+		o(&m)
+	}
+	return m
+}
+
+// Add appends a pair to the last section.
+func (m Model) Add(label, value string) Model {
+	if len(m.sections) == 0 {
+		m.sections = []Section{{}}
+	} else {
+		m.sections = append([]Section(nil), m.sections...)
+	}
+	last := &m.sections[len(m.sections)-1]
+	last.Pairs = append(last.Pairs[:len(last.Pairs):len(last.Pairs)], Pair{Label: label, Value: value})
+	return m
+}
+
+// Section starts a new section; following Adds go into it.
+func (m Model) Section(title string) Model {
+	m.sections = append(m.sections[:len(m.sections):len(m.sections)], Section{Title: title})
+	return m
+}
+
+// SetSections replaces the content.
+func (m Model) SetSections(sections ...Section) Model {
+	m.sections = sections
+	return m
+}
+
+// SetWidth sets the width to lay out for.
+func (m Model) SetWidth(w int) Model {
+	m.width = w
+	return m
+}
+
+// Init implements frog.Model.
+func (m Model) Init() frog.Cmd { return nil }
+
+// Update follows resizes.
+func (m Model) Update(msg frog.Msg) (frog.Model, frog.Cmd) {
+	if rs, ok := msg.(frog.ResizeMsg); ok {
+		m.width = rs.Width
+	}
+	return m, nil
+}
+
+// labelWidth balances the columns: labels get what the widest label needs,
+// up to the configured share of the width.
+func (m Model) labelWidth() int {
+	w := 0
+	for _, s := range m.sections {
+		for _, p := range s.Pairs {
+			w = max(w, frog.DisplayWidth(p.Label))
+		}
+	}
+	if m.width > 0 {
+		w = min(w, max(int(float64(m.width)*m.maxLabel), 1))
+	}
+	return w
+}
+
+// View renders the sections.
+func (m Model) View() string {
+	lw := m.labelWidth()
+	vw := 0 // no wrapping while the width is unknown
+	if m.width > 0 {
+		vw = max(m.width-lw-frog.DisplayWidth(m.separator), 1)
+	}
+
+	var rows []string
+	for i, s := range m.sections {
+		if i > 0 {
+			rows = append(rows, "")
+		}
+		if s.Title != "" {
+			rows = append(rows, m.titleStyle.Render(s.Title))
+		}
+		for _, p := range s.Pairs {
+			labels := wrap(p.Label, lw)
+			values := wrap(p.Value, vw)
+			for j := 0; j < max(len(labels), len(values)); j++ {
+				var l, v string
+				if j < len(labels) {
+					l = labels[j]
+				}
+				if j < len(values) {
+					v = values[j]
+				}
+				pad := strings.Repeat(" ", max(lw-frog.DisplayWidth(l), 0))
+				rows = append(rows, m.labelStyle.Render(l)+pad+m.separator+m.valueStyle.Render(v))
+			}
+		}
+	}
+	return strings.Join(rows, "\n")
+}
+
+// wrap breaks s into lines of at most w columns, at spaces where possible.
+// Explicit newlines are kept; w <= 0 disables wrapping.
+func wrap(s string, w int) []string {
+	var out []string
+	for _, para := range strings.Split(s, "\n") {
+		if w <= 0 || text.Width(para) <= w {
+			out = append(out, para)
+			continue
+		}
+		line, lineW := "", 0
+		for _, word := range strings.Fields(para) {
+			ww := text.Width(word)
+			if lineW > 0 && lineW+1+ww <= w {
+				line += " " + word
+				lineW += 1 + ww
+				continue
+			}
+			if lineW > 0 {
+				out = append(out, line)
+			}
+			line, lineW = "", 0
+			for ww > w { // hard-break words wider than a line
+				cut, cw := 0, 0
+				for cut < len(word) {
+					next := text.NextGrapheme(word, cut)
+					gw := text.GraphemeWidth(word[cut:next])
+					if cw+gw > w && cw > 0 {
+						break
+					}
+					cut, cw = next, cw+gw
+				}
+				out = append(out, word[:cut])
+				word = word[cut:]
+				ww = text.Width(word)
+			}
+			line, lineW = word, ww
+		}
+		out = append(out, line)
+	}
+	return out
+}
@@ -0,0 +1,485 @@
+// Package logview provides a scrolling log tail that follows new lines
+// unless the user scrolls away, colours lines by level and filters them by
+// level, substring or regular expression. Memory is capped by keeping only
+// the most recent lines.
+package logview
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/pondworks-lib/frog"
+)
+
+// Level is the severity of a log line.
+type Level int
+
+const (
+	LevelNone Level = iota // no level recognised
+	LevelDebug
+	LevelInfo
+	LevelWarn
+	LevelError
+)
+
+var levelNames = [...]string{"none", "debug", "info", "warn", "error"}
+
+// String returns the level name, as shown in the status bar.
+func (l Level) String() string {
+	if l < 0 || int(l) >= len(levelNames) {
+		return "level(" + strconv.Itoa(int(l)) + ")"
+	}
+	return levelNames[l]
+}
+
+// levelWords maps the level names DetectLevel recognises, lower-cased.
+var levelWords = map[string]Level{
+	"trace": LevelDebug, "debug": LevelDebug, "dbg": LevelDebug,
+	"info": LevelInfo, "inf": LevelInfo, "notice": LevelInfo,
+	"warn": LevelWarn, "warning": LevelWarn, "wrn": LevelWarn,
+	"error": LevelError, "err": LevelError, "fatal": LevelError,
+	"panic": LevelError, "crit": LevelError, "critical": LevelError,
+}
+
+// DetectLevel finds a level name among the first few words of line, as
+// written by most loggers: "ERROR ...", "[warn] ...", "level=info ...",
+// "2024-01-02T15:04:05Z DBG ...".
+func DetectLevel(line string) Level {
+	words := strings.FieldsFunc(line, func(r rune) bool {
+		return !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z')
+	})
+	for i, w := range words {
+		if i == 6 {
+			break
+		}
+		if l, ok := levelWords[strings.ToLower(w)]; ok {
+			return l
+		}
+	}
+	return LevelNone
+}
+
+// KeyMap names the keys the log view handles besides the arrow, page and
+// Home/End keys. Keys are written as KeyMsg.String.
+type KeyMap struct {
+	Follow string // jump to the end and follow new lines
+	Filter string // start typing a filter; Enter keeps it, Esc clears it
+	Level  string // cycle the minimum level shown
+}
+
+// DefaultKeyMap is the key map used by New.
+var DefaultKeyMap = KeyMap{Follow: "F", Filter: "/", Level: "L"}
+
+// Bindings describes the log view's keys for a help overlay.
+func (k KeyMap) Bindings() []frog.Binding {
+	return []frog.Binding{
+		{Keys: "↑/↓ pgup/pgdn", Help: "scroll"},
+		{Keys: "home/end", Help: "go to top/bottom"},
+		{Keys: k.Follow, Help: "follow new lines"},
+		{Keys: k.Filter, Help: "filter"},
+		{Keys: k.Level, Help: "minimum level"},
+	}
+}
+
+// Model is a log view. Copies of a Model share its line buffer.
+type Model struct {
+	lines         *ring
+	visible       []int // sequence numbers of lines passing the filters
+	offset        int   // index into visible of the first row shown
+	follow        bool
+	width, height int
+	x, y          int // 0-based screen position, for mouse mapping
+
+	minLevel  Level
+	substr    string
+	re        *regexp.Regexp
+	editing   bool // the filter is being typed
+	detect    func(string) Level
+	keys      KeyMap
+	wheelStep int
+
+	levelStyles  [LevelError + 1]frog.Style
+	filterStyle  frog.Style
+	hiddenStyle  frog.Style
+	matchedStyle frog.Style
+}
+
+// Option configures a Model.
+type Option func(*Model)
+
+// WithCapacity sets how many lines are kept (default 10000). Older lines
+// are dropped.
+func WithCapacity(n int) Option {
+	return func(m *Model) {
+		if n > 0 {
+			m.lines = newRing(n)
+		}
+	}
+}
+
+// WithLevelFunc replaces DetectLevel. Lines for which fn returns LevelNone
+// take the level of the line before them, so stack traces and other
+// continuation lines stay with their message.
+func WithLevelFunc(fn func(string) Level) Option { return func(m *Model) { m.detect = fn } }
+
+// WithLevelStyles sets the styles for debug, info, warning and error lines.
+func WithLevelStyles(debug, info, warn, err frog.Style) Option {
+	return func(m *Model) {
+		m.levelStyles[LevelDebug], m.levelStyles[LevelInfo] = debug, info
+		m.levelStyles[LevelWarn], m.levelStyles[LevelError] = warn, err
+	}
+}
+
+// WithMatchStyle sets the style for filter matches within lines (default
+// reversed).
+func WithMatchStyle(s frog.Style) Option { return func(m *Model) { m.matchedStyle = s } }
+
+// WithKeyMap replaces the default key map.
+func WithKeyMap(k KeyMap) Option { return func(m *Model) { m.keys = k } }
+
+// WithWheelStep sets how many lines one wheel notch scrolls (default 3).
+func WithWheelStep(n int) Option {
+	return func(m *Model) {
+		if n > 0 {
+			m.wheelStep = n
+		}
+	}
+}
+
+// New creates an empty log view of the given size, following new lines.
+func New(width, height int, opts ...Option) Model {
+	m := Model{
+		lines:        newRing(10000),
+		follow:       true,
+		width:        width,
+		height:       height,
+		detect:       DetectLevel,
+		keys:         DefaultKeyMap,
+		wheelStep:    3,
+		filterStyle:  frog.NewStyle().Reversed(),
+		hiddenStyle:  frog.NewStyle().Fainted(),
+		matchedStyle: frog.NewStyle().Reversed(),
+	}
+	m.levelStyles[LevelDebug] = frog.NewStyle().Fainted()
+	m.levelStyles[LevelWarn] = frog.NewStyle().Fg(frog.ColorYellow)
+	m.levelStyles[LevelError] = frog.NewStyle().Fg(frog.ColorRed)
+	for _, o := range opts {
+		o(&m)
+	}
+	return m
+}
+
+// Append adds lines at the end, following them if the view is following.
+// Text containing newlines is split into several lines.
+func (m Model) Append(lines ...string) Model {
+	prev := LevelNone
+	if e, ok := m.lines.last(); ok {
+		prev = e.level
+	}
+	for _, text := range lines {
+		for _, l := range strings.Split(strings.TrimSuffix(text, "\n"), "\n") {
+			l = strings.TrimSuffix(l, "\r")
+			lvl := m.detect(l)
+			if lvl == LevelNone {
+				lvl = prev
+			}
+			prev = lvl
+			seq := m.lines.total
+			m.lines.push(entry{text: l, level: lvl})
+			if m.matches(m.lines.at(seq)) {
+				m.visible = append(m.visible, seq)
+			}
+		}
+	}
+	m = m.dropEvicted()
+	if m.follow {
+		m.offset = m.maxOffset()
+	}
+	return m
+}
+
+// dropEvicted removes lines that fell out of the buffer from the visible
+// list, keeping the rows on screen in place.
+func (m Model) dropEvicted() Model {
+	first := m.lines.first()
+	n := 0
+	for n < len(m.visible) && m.visible[n] < first {
+		n++
+	}
+	if n > 0 {
+		m.visible = append(m.visible[:0:0], m.visible[n:]...)
+		m.offset = max(m.offset-n, 0)
+	}
+	return m
+}
+
+// Clear drops all lines.
+func (m Model) Clear() Model {
+	m.lines.reset()
+	m.visible, m.offset = nil, 0
+	return m
+}
+
+// Len reports how many lines are held, before filtering.
+func (m Model) Len() int { return len(m.lines.buf) }
+
+// Following reports whether the view sticks to the newest line.
+func (m Model) Following() bool { return m.follow }
+
+// SetFollow turns following on (jumping to the end) or off.
+func (m Model) SetFollow(on bool) Model {
+	m.follow = on
+	if on {
+		m.offset = m.maxOffset()
+	}
+	return m
+}
+
+// SetMinLevel hides lines below level. Lines with no level are always
+// shown.
+func (m Model) SetMinLevel(level Level) Model {
+	m.minLevel = level
+	return m.refilter()
+}
+
+// SetFilter shows only lines containing s; "" shows all lines. It replaces
+// a regular expression filter.
+func (m Model) SetFilter(s string) Model {
+	m.substr, m.re = s, nil
+	return m.refilter()
+}
+
+// SetFilterRegexp shows only lines matching re; nil shows all lines. It
+// replaces a substring filter.
+func (m Model) SetFilterRegexp(re *regexp.Regexp) Model {
+	m.substr, m.re = "", re
+	return m.refilter()
+}
+
+// SetSize resizes the view.
+func (m Model) SetSize(width, height int) Model {
+	m.width, m.height = width, height
+	if m.follow {
+		m.offset = m.maxOffset()
+	}
+	m.offset = min(m.offset, m.maxOffset())
+	return m
+}
+
+// SetPosition records where the view is drawn (0-based screen coordinates)
+// so mouse events are only taken over it.
+func (m Model) SetPosition(x, y int) Model {
+	m.x, m.y = x, y
+	return m
+}
+
+func (m Model) matches(e entry) bool {
+	if e.level != LevelNone && e.level < m.minLevel {
+		return false
+	}
+	if m.re != nil {
+		return m.re.MatchString(e.text)
+	}
+	return strings.Contains(e.text, m.substr)
+}
+
+// refilter rebuilds the visible list, keeping the line at the top of the
+// view on screen when not following.
+func (m Model) refilter() Model {
+	top := -1
+	if m.offset < len(m.visible) {
+		top = m.visible[m.offset]
+	}
+	m.visible = m.visible[:0:0]
+	m.offset = 0
+	for seq := m.lines.first(); seq < m.lines.total; seq++ {
+		if m.matches(m.lines.at(seq)) {
+			if seq <= top {
+				m.offset = len(m.visible)
+			}
+			m.visible = append(m.visible, seq)
+		}
+	}
+	if m.follow {
+		m.offset = m.maxOffset()
+	}
+	m.offset = min(m.offset, m.maxOffset())
+	return m
+}
+
+// rows is the height left for lines; the status bar takes the last row
+// while a filter is typed or set.
+func (m Model) rows() int {
+	if m.editing || m.substr != "" || m.re != nil || m.minLevel > LevelNone {
+		return max(m.height-1, 0)
+	}
+	return m.height
+}
+
+func (m Model) maxOffset() int { return max(len(m.visible)-m.rows(), 0) }
+
+// scroll moves the view by n rows; reaching the end resumes following.
+func (m Model) scroll(n int) Model {
+	m.offset = max(min(m.offset+n, m.maxOffset()), 0)
+	m.follow = m.offset == m.maxOffset()
+	return m
+}
+
+// Init implements frog.Model.
+func (m Model) Init() frog.Cmd { return nil }
+
+// KeyBindings implements frog.KeyHelper.
+func (m Model) KeyBindings() []frog.Binding { return m.keys.Bindings() }
+
+// Update handles new lines, scrolling and filtering.
+func (m Model) Update(msg frog.Msg) (frog.Model, frog.Cmd) {
+	switch msg := msg.(type) {
+	case LinesMsg:
+		m = m.Append(msg.Lines...)
+		if msg.src != nil {
+			return m, msg.src.next
+		}
+	case frog.ResizeMsg:
+		return m.SetSize(msg.Width, msg.Height), nil
+	case frog.KeyMsg:
+		if m.editing {
+			return m.editFilter(msg), nil
+		}
+		return m.key(msg), nil
+	case frog.MouseMsg:
+		row, col := msg.Y-1-m.y, msg.X-1-m.x
+		if msg.Action != frog.MouseWheel || row < 0 || row >= m.height || col < 0 || col >= m.width {
+			return m, nil
+		}
+		if msg.Button == frog.MouseWheelUp {
+			return m.scroll(-m.wheelStep), nil
+		}
+		return m.scroll(m.wheelStep), nil
+	}
+	return m, nil
+}
+
+func (m Model) key(k frog.KeyMsg) Model {
+	switch k.String {
+	case m.keys.Follow:
+		return m.SetFollow(true)
+	case m.keys.Filter:
+		m.editing = true
+		return m.SetFilter(m.substr)
+	case m.keys.Level:
+		return m.SetMinLevel((m.minLevel + 1) % (LevelError + 1))
+	}
+	switch k.Type {
+	case frog.KeyUp:
+		return m.scroll(-1)
+	case frog.KeyDown:
+		return m.scroll(1)
+	case frog.KeyPgUp:
+		return m.scroll(-m.rows())
+	case frog.KeyPgDn:
+		return m.scroll(m.rows())
+	case frog.KeyHome:
+		return m.scroll(-len(m.visible))
+	case frog.KeyEnd:
+		return m.SetFollow(true)
+	}
+	return m
+}
+
+// editFilter handles keys while the filter is typed. The filter applies as
+// it is typed.
+func (m Model) editFilter(k frog.KeyMsg) Model {
+	switch k.Type {
+	case frog.KeyEnter:
+		m.editing = false
+		return m
+	case frog.KeyEsc:
+		m.editing = false
+		return m.SetFilter("")
+	case frog.KeyBackspace:
+		if m.substr == "" {
+			m.editing = false
+			return m.SetFilter("")
+		}
+		_, size := utf8.DecodeLastRuneInString(m.substr)
+		return m.SetFilter(m.substr[:len(m.substr)-size])
+	case frog.KeyRune:
+		if !k.Alt && !k.Ctrl {
+			return m.SetFilter(m.substr + string(k.Rune))
+		}
+	}
+	return m
+}
+
+// View renders the visible lines, and the filters on the last row when
+// any are set.
+func (m Model) View() string {
+	rows := make([]string, 0, m.height)
+	for i := 0; i < m.rows(); i++ {
+		n := m.offset + i
+		if n >= len(m.visible) {
+			rows = append(rows, strings.Repeat(" ", m.width))
+			continue
+		}
+		e := m.lines.at(m.visible[n])
+		line := m.render(e)
+		line = frog.Truncate(line, m.width)
+		rows = append(rows, line+strings.Repeat(" ", max(m.width-frog.DisplayWidth(line), 0)))
+	}
+	if m.rows() < m.height {
+		rows = append(rows, m.filterBar())
+	}
+	return strings.Join(rows, "\n")
+}
+
+// render colours a line by level and marks filter matches.
+func (m Model) render(e entry) string {
+	st := m.levelStyles[e.level]
+	var spans [][]int
+	switch {
+	case m.re != nil:
+		spans = m.re.FindAllStringIndex(e.text, -1)
+	case m.substr != "":
+		for i := 0; ; {
+			j := strings.Index(e.text[i:], m.substr)
+			if j < 0 {
+				break
+			}
+			spans = append(spans, []int{i + j, i + j + len(m.substr)})
+			i += j + len(m.substr)
+		}
+	}
+	var b strings.Builder
+	prev := 0
+	for _, s := range spans {
+		if s[0] == s[1] {
+			continue
+		}
+		b.WriteString(st.Render(e.text[prev:s[0]]))
+		b.WriteString(m.matchedStyle.Render(e.text[s[0]:s[1]]))
+		prev = s[1]
+	}
+	b.WriteString(st.Render(e.text[prev:]))
+	return b.String()
+}
+
+func (m Model) filterBar() string {
+	label := ""
+	switch {
+	case m.re != nil:
+		label = "/" + m.re.String() + "/"
+	case m.editing || m.substr != "":
+		label = "/" + m.substr
+	}
+	status := ""
+	if m.minLevel > LevelNone {
+		status += "  " + m.minLevel.String() + "+"
+	}
+	if !m.follow {
+		status += "  (paused)"
+	}
+	bar := frog.Truncate(label+m.hiddenStyle.Render(status), m.width)
+	return m.filterStyle.Render(bar + strings.Repeat(" ", max(m.width-frog.DisplayWidth(bar), 0)))
+}
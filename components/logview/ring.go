@@ -0,0 +1,52 @@
+package logview
+
+// entry is one stored log line.
+type entry struct {
+	text  string
+	level Level
+}
+
+// ring holds the most recent lines. Lines are addressed by sequence number,
+// counting every line ever appended, so indices into it stay valid while old
+// lines are evicted.
+type ring struct {
+	buf   []entry
+	total int // lines ever appended; the next sequence number
+}
+
+func newRing(capacity int) *ring { return &ring{buf: make([]entry, 0, capacity)} }
+
+// first returns the sequence number of the oldest line still held.
+func (r *ring) first() int { return r.total - len(r.buf) }
+
+// push appends e, evicting the oldest line when full.
+func (r *ring) push(e entry) {
+	if len(r.buf) < cap(r.buf) {
+		r.buf = append(r.buf, e)
+	} else {
+		r.buf[r.total%cap(r.buf)] = e
+	}
+	r.total++
+}
+
+// at returns line seq, which must be in [first, total).
+func (r *ring) at(seq int) entry {
+	if len(r.buf) < cap(r.buf) {
+		return r.buf[seq]
+	}
+	return r.buf[seq%cap(r.buf)]
+}
+
+// last returns the newest line, if any.
+func (r *ring) last() (entry, bool) {
+	if r.total == 0 {
+		return entry{}, false
+	}
+	return r.at(r.total - 1), true
+}
+
+func (r *ring) reset() {
+	clear(r.buf)
+	r.buf = r.buf[:0]
+	r.total = 0
+}
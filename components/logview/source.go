@@ -0,0 +1,69 @@
+package logview
+
+import (
+	"bufio"
+	"io"
+
+	"github.com/pondworks-lib/frog"
+)
+
+// LinesMsg adds lines to a log view. Send it with Session.Send to feed a
+// view from elsewhere; Tail produces it for a reader.
+type LinesMsg struct {
+	Lines []string
+
+	src *source // set for lines read by Tail; Update asks for more
+}
+
+// TailDoneMsg reports that a reader passed to Tail is exhausted. Err is nil
+// at a clean end of input.
+type TailDoneMsg struct{ Err error }
+
+// maxBatch bounds how many buffered lines one LinesMsg carries, so a fast
+// writer cannot stall rendering.
+const maxBatch = 512
+
+// source reads lines from a reader on a background goroutine.
+type source struct {
+	lines chan string
+	err   error // valid once lines is closed
+}
+
+// Tail returns a command that reads r line by line and delivers the lines
+// to the view as LinesMsg. The view's Update keeps reading until r is
+// exhausted, then emits TailDoneMsg. Lines longer than 1 MiB end the tail
+// with bufio.ErrTooLong.
+func Tail(r io.Reader) frog.Cmd {
+	s := &source{lines: make(chan string, maxBatch)}
+	go func() {
+		defer close(s.lines)
+		sc := bufio.NewScanner(r)
+		sc.Buffer(nil, 1<<20)
+		for sc.Scan() {
+			s.lines <- sc.Text()
+		}
+		s.err = sc.Err()
+	}()
+	return s.next
+}
+
+// next blocks for a line, then takes whatever else is already buffered.
+func (s *source) next() frog.Msg {
+	l, ok := <-s.lines
+	if !ok {
+		return TailDoneMsg{Err: s.err}
+	}
+	batch := []string{l}
+	for len(batch) < maxBatch {
+		select {
+		case l, ok := <-s.lines:
+			if !ok {
+				return LinesMsg{Lines: batch, src: s}
+			}
+			batch = append(batch, l)
+		default:
+			return LinesMsg{Lines: batch, src: s}
+		}
+	}
+	return LinesMsg{Lines: batch, src: s}
+}
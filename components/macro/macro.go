@@ -0,0 +1,258 @@
+// Package macro records key presses and plays them back, for editor-like
+// applications: press the Record key, type, press it again, then press
+// Replay to repeat what was typed. Macros can be named and saved to a file
+// so they survive restarts.
+package macro
+
+import (
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"github.com/pondworks-lib/frog"
+)
+
+// KeyMap names the macro keys by their frog.KeyMsg.Canonical names.
+type KeyMap struct {
+	Record string // start and stop recording
+	Replay string // play the last recorded macro
+}
+
+// DefaultKeyMap is the key map used by New.
+var DefaultKeyMap = KeyMap{Record: "f3", Replay: "f4"}
+
+// Bindings describes the macro keys for a help overlay.
+func (k KeyMap) Bindings() []frog.Binding {
+	return []frog.Binding{
+		{Keys: k.Record, Help: "start/stop recording a macro"},
+		{Keys: k.Replay, Help: "play the last macro"},
+	}
+}
+
+// PlayMsg plays the named macro; see Play.
+type PlayMsg struct{ Name string }
+
+// Play returns a command that plays the named macro, for binding saved
+// macros to keys or palette actions.
+func Play(name string) frog.Cmd {
+	return func() frog.Msg { return PlayMsg{Name: name} }
+}
+
+// Model wraps an application model with macro recording. Messages go to
+// Child; while recording, the key presses among them are kept as well.
+// Playing a macro sends its keys to Child one after another within a
+// single update, and runs the commands Child returns in the same order.
+type Model struct {
+	Child frog.Model
+
+	recording bool
+	rec       []frog.KeyMsg
+	last      []frog.KeyMsg
+	named     map[string][]frog.KeyMsg
+	maxLen    int
+	keys      KeyMap
+}
+
+// Option configures a Model.
+type Option func(*Model)
+
+// WithKeyMap replaces the default key map.
+func WithKeyMap(k KeyMap) Option { return func(m *Model) { m.keys = k } }
+
+// WithMacros provides named macros, typically from Load.
+func WithMacros(macros map[string][]frog.KeyMsg) Option {
+	return func(m *Model) {
+		for name, keys := range macros {
+			m.named[name] = keys
+		}
+	}
+}
+
+// WithMaxLength bounds how many keys a recording keeps (default 10000);
+// recording stops when it is reached.
+func WithMaxLength(n int) Option {
+	return func(m *Model) {
+		if n > 0 {
+			m.maxLen = n
+		}
+	}
+}
+
+// New wraps child with macro recording.
+func New(child frog.Model, opts ...Option) Model {
+	m := Model{Child: child, named: map[string][]frog.KeyMsg{}, maxLen: 10000, keys: DefaultKeyMap}
+	for _, o := range opts {
+		o(&m)
+	}
+	return m
+}
+
+// Recording reports whether key presses are being recorded, for showing
+// an indicator.
+func (m Model) Recording() bool { return m.recording }
+
+// Last returns the last recorded macro.
+func (m Model) Last() []frog.KeyMsg { return append([]frog.KeyMsg(nil), m.last...) }
+
+// SaveAs keeps the last recorded macro as name, replacing any macro of that
+// name.
+func (m Model) SaveAs(name string) Model {
+	m.named = clone(m.named)
+	m.named[name] = m.Last()
+	return m
+}
+
+// Delete removes the named macro.
+func (m Model) Delete(name string) Model {
+	m.named = clone(m.named)
+	delete(m.named, name)
+	return m
+}
+
+// Macros returns the named macros, for Save.
+func (m Model) Macros() map[string][]frog.KeyMsg { return clone(m.named) }
+
+func clone(macros map[string][]frog.KeyMsg) map[string][]frog.KeyMsg {
+	out := make(map[string][]frog.KeyMsg, len(macros))
+	for name, keys := range macros {
+		out[name] = keys
+	}
+	return out
+}
+
+// Init initializes the child.
+func (m Model) Init() frog.Cmd { return m.Child.Init() }
+
+// KeyBindings lists the child's keys, if it describes them, and the macro
+// keys.
+func (m Model) KeyBindings() []frog.Binding {
+	var b []frog.Binding
+	if kh, ok := m.Child.(frog.KeyHelper); ok {
+		b = kh.KeyBindings()
+	}
+	return append(b, m.keys.Bindings()...)
+}
+
+// Update handles the macro keys and PlayMsg and passes everything else to
+// Child. Macros are not played while recording.
+func (m Model) Update(msg frog.Msg) (frog.Model, frog.Cmd) {
+	switch msg := msg.(type) {
+	case frog.KeyMsg:
+		switch msg.Canonical() {
+		case m.keys.Record:
+			if m.recording {
+				return m.stop(), nil
+			}
+			m.recording, m.rec = true, nil
+			return m, nil
+		case m.keys.Replay:
+			if !m.recording {
+				return m.play(m.last)
+			}
+			return m, nil
+		}
+		if m.recording {
+			m.rec = append(m.rec, msg)
+			if len(m.rec) >= m.maxLen {
+				m = m.stop()
+			}
+		}
+	case PlayMsg:
+		if !m.recording {
+			return m.play(m.named[msg.Name])
+		}
+		return m, nil
+	}
+	var cmd frog.Cmd
+	m.Child, cmd = m.Child.Update(msg)
+	return m, cmd
+}
+
+// stop ends recording, keeping the recording as the last macro unless it
+// is empty.
+func (m Model) stop() Model {
+	m.recording = false
+	if len(m.rec) > 0 {
+		m.last = m.rec
+	}
+	m.rec = nil
+	return m
+}
+
+func (m Model) play(keys []frog.KeyMsg) (frog.Model, frog.Cmd) {
+	var cmds []frog.Cmd
+	for _, k := range keys {
+		var cmd frog.Cmd
+		m.Child, cmd = m.Child.Update(k)
+		if cmd != nil {
+			cmds = append(cmds, cmd)
+		}
+	}
+	return m, frog.Sequence(cmds...)
+}
+
+// View renders the child.
+func (m Model) View() string { return m.Child.View() }
+
+// savedKey is a key in a macro file: its canonical name, and the raw input
+// so keys that Child compares by String replay exactly.
+type savedKey struct {
+	Key string `json:"key"`
+	Seq string `json:"seq,omitempty"`
+}
+
+// Load reads named macros saved by Save. A missing file holds no macros.
+func Load(path string) (map[string][]frog.KeyMsg, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return map[string][]frog.KeyMsg{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var saved map[string][]savedKey
+	if err := json.Unmarshal(data, &saved); err != nil {
+		return nil, err
+	}
+	macros := make(map[string][]frog.KeyMsg, len(saved))
+	for name, keys := range saved {
+		msgs := make([]frog.KeyMsg, 0, len(keys))
+		for _, s := range keys {
+			k, err := frog.ParseKey(s.Key)
+			if err != nil {
+				return nil, err
+			}
+			if s.Seq != "" {
+				k.String = s.Seq
+			}
+			msgs = append(msgs, k)
+		}
+		macros[name] = msgs
+	}
+	return macros, nil
+}
+
+// Save writes named macros to path as JSON, creating its directory. Keys
+// frog cannot name (see frog.KeyMsg.Canonical) are left out.
+func Save(path string, macros map[string][]frog.KeyMsg) error {
+	saved := make(map[string][]savedKey, len(macros))
+	for name, keys := range macros {
+		s := make([]savedKey, 0, len(keys))
+		for _, k := range keys {
+			if c := k.Canonical(); c != "" {
+				s = append(s, savedKey{Key: c, Seq: k.String})
+			}
+		}
+		saved[name] = s
+	}
+	data, err := json.MarshalIndent(saved, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0o644)
+}
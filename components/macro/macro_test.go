@@ -0,0 +1,88 @@
+package macro
+
+import (
+	"io"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pondworks-lib/frog"
+)
+
+type fakeTerminal struct{}
+
+func (fakeTerminal) IsTerminal() bool                   { return true }
+func (fakeTerminal) MakeRaw() (func() error, error)     { return func() error { return nil }, nil }
+func (fakeTerminal) Size() (width, height int, _ error) { return 80, 24, nil }
+
+type echoMsg rune
+
+// echoChild answers each rune key with a command echoing it, reports each
+// echo on got, and quits after want echoes.
+type echoChild struct {
+	got  chan<- rune
+	n    *int
+	want int
+}
+
+func (c echoChild) Init() frog.Cmd { return nil }
+func (c echoChild) Update(msg frog.Msg) (frog.Model, frog.Cmd) {
+	switch msg := msg.(type) {
+	case frog.KeyMsg:
+		if msg.Type == frog.KeyRune {
+			return c, func() frog.Msg { return echoMsg(msg.Rune) }
+		}
+	case echoMsg:
+		c.got <- rune(msg)
+		if *c.n++; *c.n == c.want {
+			return c, frog.Quit()
+		}
+	}
+	return c, nil
+}
+func (c echoChild) View() string { return "" }
+
+func TestReplayDeliversEveryCommand(t *testing.T) {
+	got := make(chan rune, 6)
+	m := New(echoChild{got: got, n: new(int), want: 6})
+	// Record "abc" between two F3s, then replay it with F4.
+	pr, pw := io.Pipe()
+	defer pw.Close()
+	in := io.MultiReader(strings.NewReader("\x1bORabc\x1bOR"), pr)
+	app := frog.NewApp(m, frog.WithTerminal(fakeTerminal{}), frog.WithIn(in), frog.WithOut(io.Discard), frog.WithoutSignalHandler())
+	done := make(chan error, 1)
+	go func() { done <- app.Run() }()
+
+	// The recorded keys' commands run concurrently, so their echoes come
+	// in any order; the replayed ones run in sequence.
+	read := func(n int) []rune {
+		var rs []rune
+		for range n {
+			select {
+			case r := <-got:
+				rs = append(rs, r)
+			case <-time.After(5 * time.Second):
+				t.Fatalf("echoed %q, want %d runes", string(rs), n)
+			}
+		}
+		return rs
+	}
+	recorded := read(3)
+	slices.Sort(recorded)
+	if string(recorded) != "abc" {
+		t.Fatalf("recorded echoes %q, want a, b and c", string(recorded))
+	}
+	go pw.Write([]byte("\x1bOS"))
+	if replayed := read(3); string(replayed) != "abc" {
+		t.Fatalf("replayed echoes %q, want abc", string(replayed))
+	}
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Fatal(err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("session did not quit")
+	}
+}
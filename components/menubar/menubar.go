@@ -0,0 +1,362 @@
+// Package menubar provides a top menu row with dropdown menus over an
+// application model, driven by the keyboard (F10, Alt+letter, arrows) or
+// the mouse. Choosing an item runs its command.
+package menubar
+
+import (
+	"strings"
+	"unicode"
+
+	"github.com/pondworks-lib/frog"
+)
+
+// Item is an entry of a menu.
+type Item struct {
+	Label     string
+	Keys      string   // the item's own key binding, shown as a hint; optional
+	Action    frog.Cmd // run when the item is chosen; may be nil
+	Disabled  bool
+	Separator bool // a divider line; the other fields are ignored
+}
+
+// Menu is a title in the bar and its dropdown.
+type Menu struct {
+	Title string
+	Items []Item
+}
+
+// KeyMap names the keys the menu bar handles besides the arrow keys and
+// Enter. Keys are written as KeyMsg.String. Alt plus the first letter of a
+// title opens that menu.
+type KeyMap struct {
+	Activate string // focus the bar and open the first menu
+	Close    string
+}
+
+// DefaultKeyMap is the key map used by New. Activate is F10 as most
+// terminals send it.
+var DefaultKeyMap = KeyMap{Activate: "\x1b[21~", Close: "\x1b"}
+
+// Bindings describes the menu bar's keys for a help overlay.
+func (k KeyMap) Bindings() []frog.Binding {
+	return []frog.Binding{
+		{Keys: keyLabel(k.Activate), Help: "menu"},
+		{Keys: "alt+letter", Help: "open menu"},
+	}
+}
+
+func keyLabel(s string) string {
+	switch s {
+	case "\x1b[21~":
+		return "f10"
+	case "\x1b":
+		return "esc"
+	}
+	return s
+}
+
+// Model wraps an application model with a menu bar on its first row. The
+// child is given the remaining rows; mouse coordinates it receives are
+// shifted to match. While a menu is open, keys and mouse events go to the
+// menu bar.
+type Model struct {
+	Child frog.Model
+
+	menus         []Menu
+	active        bool // the bar has keyboard focus
+	open          bool // the dropdown of menu is shown
+	menu, item    int
+	width, height int
+	keys          KeyMap
+
+	barStyle      frog.Style
+	selectedStyle frog.Style
+	disabledStyle frog.Style
+}
+
+// Option configures a Model.
+type Option func(*Model)
+
+// WithKeyMap replaces the default key map.
+func WithKeyMap(k KeyMap) Option { return func(m *Model) { m.keys = k } }
+
+// WithStyles sets the styles for the bar and dropdowns, the highlighted
+// title or item, and disabled items.
+func WithStyles(bar, selected, disabled frog.Style) Option {
+	return func(m *Model) { m.barStyle, m.selectedStyle, m.disabledStyle = bar, selected, disabled }
+}
+
+// New wraps child with a menu bar of menus.
+func New(child frog.Model, menus []Menu, opts ...Option) Model {
+	m := Model{
+		Child:         child,
+		menus:         menus,
+		keys:          DefaultKeyMap,
+		barStyle:      frog.NewStyle().Reversed(),
+		selectedStyle: frog.NewStyle(),
+		disabledStyle: frog.NewStyle().Fainted().Reversed(),
+	}
+	for _, o := range opts {
+		o(&m)
+	}
+	return m
+}
+
+// SetMenus replaces the menus.
+func (m Model) SetMenus(menus []Menu) Model {
+	m.menus = menus
+	return m.Close()
+}
+
+// IsOpen reports whether the bar has focus.
+func (m Model) IsOpen() bool { return m.active }
+
+// Close drops focus from the bar and closes any dropdown.
+func (m Model) Close() Model {
+	m.active, m.open = false, false
+	return m
+}
+
+// Init initializes the child.
+func (m Model) Init() frog.Cmd { return m.Child.Init() }
+
+// KeyBindings lists the child's keys, if it describes them, and the menu
+// bar's own.
+func (m Model) KeyBindings() []frog.Binding {
+	var b []frog.Binding
+	if kh, ok := m.Child.(frog.KeyHelper); ok {
+		b = kh.KeyBindings()
+	}
+	return append(b, m.keys.Bindings()...)
+}
+
+// Update handles the menu keys and mouse events on the bar and dropdowns,
+// and passes everything else to the child.
+func (m Model) Update(msg frog.Msg) (frog.Model, frog.Cmd) {
+	switch msg := msg.(type) {
+	case frog.ResizeMsg:
+		m.width, m.height = msg.Width, msg.Height
+		var cmd frog.Cmd
+		m.Child, cmd = m.Child.Update(frog.ResizeMsg{Width: msg.Width, Height: max(msg.Height-1, 0)})
+		return m, cmd
+	case frog.KeyMsg:
+		if m.active {
+			return m.key(msg)
+		}
+		if msg.String == m.keys.Activate {
+			return m.openMenu(0), nil
+		}
+		if i, ok := m.accelerator(msg); ok {
+			return m.openMenu(i), nil
+		}
+	case frog.MouseMsg:
+		return m.mouse(msg)
+	}
+	var cmd frog.Cmd
+	m.Child, cmd = m.Child.Update(msg)
+	return m, cmd
+}
+
+// accelerator finds the menu opened by Alt plus the first letter of its
+// title.
+func (m Model) accelerator(k frog.KeyMsg) (int, bool) {
+	if !k.Alt || k.Type != frog.KeyRune {
+		return 0, false
+	}
+	for i, mn := range m.menus {
+		for _, r := range mn.Title {
+			if unicode.ToLower(r) == unicode.ToLower(k.Rune) {
+				return i, true
+			}
+			break
+		}
+	}
+	return 0, false
+}
+
+func (m Model) openMenu(i int) Model {
+	if len(m.menus) == 0 {
+		return m
+	}
+	m.active, m.open, m.menu = true, true, i
+	m.item = m.step(-1, 1)
+	return m
+}
+
+func (m Model) key(k frog.KeyMsg) (frog.Model, frog.Cmd) {
+	if k.String == m.keys.Close || k.String == m.keys.Activate {
+		return m.Close(), nil
+	}
+	if i, ok := m.accelerator(k); ok {
+		return m.openMenu(i), nil
+	}
+	n := len(m.menus)
+	switch k.Type {
+	case frog.KeyLeft:
+		return m.switchMenu((m.menu + n - 1) % n), nil
+	case frog.KeyRight:
+		return m.switchMenu((m.menu + 1) % n), nil
+	case frog.KeyDown:
+		if !m.open {
+			return m.openMenu(m.menu), nil
+		}
+		m.item = m.step(m.item, 1)
+	case frog.KeyUp:
+		if m.open {
+			m.item = m.step(m.item, -1)
+		}
+	case frog.KeyEnter, frog.KeySpace:
+		if !m.open {
+			return m.openMenu(m.menu), nil
+		}
+		return m.choose(m.item)
+	}
+	return m, nil
+}
+
+// switchMenu moves to menu i, keeping the dropdown open if it was.
+func (m Model) switchMenu(i int) Model {
+	if m.open {
+		return m.openMenu(i)
+	}
+	m.menu = i
+	return m
+}
+
+// step returns the next selectable item from i in direction dir, wrapping
+// around; -1 if the menu has none.
+func (m Model) step(i, dir int) int {
+	items := m.menus[m.menu].Items
+	for range items {
+		i = (i + dir + len(items)) % len(items)
+		if it := items[i]; !it.Separator && !it.Disabled {
+			return i
+		}
+	}
+	return -1
+}
+
+// choose closes the bar and runs item i.
+func (m Model) choose(i int) (frog.Model, frog.Cmd) {
+	items := m.menus[m.menu].Items
+	if i < 0 || i >= len(items) || items[i].Separator || items[i].Disabled {
+		return m, nil
+	}
+	return m.Close(), items[i].Action
+}
+
+func (m Model) mouse(msg frog.MouseMsg) (frog.Model, frog.Cmd) {
+	col, row := msg.X-1, msg.Y-1
+	x, w, h := m.dropdown()
+	inDrop := m.open && col >= x && col < x+w && row >= 1 && row < 1+h
+
+	switch {
+	case row == 0 && msg.Action == frog.MousePress && msg.Button == frog.MouseLeft:
+		i := m.titleAt(col)
+		if i < 0 || (m.open && i == m.menu) {
+			return m.Close(), nil
+		}
+		return m.openMenu(i), nil
+	case inDrop && msg.Action == frog.MouseRelease:
+		return m.choose(row - 2) // on release, so press-drag-release works
+	case inDrop:
+		if i := row - 2; i >= 0 && i < len(m.menus[m.menu].Items) {
+			if it := m.menus[m.menu].Items[i]; !it.Separator && !it.Disabled {
+				m.item = i
+			}
+		}
+		return m, nil
+	case m.open && msg.Action == frog.MousePress:
+		return m.Close(), nil
+	case m.active || row == 0:
+		return m, nil
+	}
+	msg.Y--
+	var cmd frog.Cmd
+	m.Child, cmd = m.Child.Update(msg)
+	return m, cmd
+}
+
+// titleAt returns the menu whose title covers column col, or -1.
+func (m Model) titleAt(col int) int {
+	x := 0
+	for i, mn := range m.menus {
+		w := frog.DisplayWidth(mn.Title) + 2
+		if col >= x && col < x+w {
+			return i
+		}
+		x += w
+	}
+	return -1
+}
+
+// dropdown returns the column, width and height of the open dropdown.
+func (m Model) dropdown() (x, w, h int) {
+	if !m.open {
+		return 0, 0, 0
+	}
+	for _, mn := range m.menus[:m.menu] {
+		x += frog.DisplayWidth(mn.Title) + 2
+	}
+	items := m.menus[m.menu].Items
+	labelW, keysW := 0, 0
+	for _, it := range items {
+		labelW = max(labelW, frog.DisplayWidth(it.Label))
+		keysW = max(keysW, frog.DisplayWidth(it.Keys))
+	}
+	w = 2 + labelW + 2
+	if keysW > 0 {
+		w += 2 + keysW
+	}
+	return x, w, len(items) + 2
+}
+
+// View renders the bar above the child, with the open dropdown on top.
+func (m Model) View() string {
+	var bar strings.Builder
+	for i, mn := range m.menus {
+		title := " " + mn.Title + " "
+		if m.active && i == m.menu {
+			bar.WriteString(m.selectedStyle.Render(title))
+		} else {
+			bar.WriteString(m.barStyle.Render(title))
+		}
+	}
+	row := bar.String()
+	if pad := m.width - frog.DisplayWidth(row); pad > 0 {
+		row += m.barStyle.Render(strings.Repeat(" ", pad))
+	}
+	view := row + "\n" + m.Child.View()
+	if !m.open {
+		return view
+	}
+	x, _, _ := m.dropdown()
+	return frog.Overlay(view, m.dropdownView(), x, 1)
+}
+
+// dropdownView draws the open menu's items in a box.
+func (m Model) dropdownView() string {
+	_, w, _ := m.dropdown()
+	inner := w - 2
+	items := m.menus[m.menu].Items
+	rows := []string{m.barStyle.Render("┌" + strings.Repeat("─", inner) + "┐")}
+	for i, it := range items {
+		if it.Separator {
+			rows = append(rows, m.barStyle.Render("├"+strings.Repeat("─", inner)+"┤"))
+			continue
+		}
+		keys := it.Keys
+		gap := strings.Repeat(" ", max(inner-2-frog.DisplayWidth(it.Label)-frog.DisplayWidth(keys), 0))
+		text := " " + it.Label + gap + keys + " "
+		st := m.barStyle
+		switch {
+		case it.Disabled:
+			st = m.disabledStyle
+		case i == m.item:
+			st = m.selectedStyle
+		}
+		rows = append(rows, m.barStyle.Render("│")+st.Render(text)+m.barStyle.Render("│"))
+	}
+	rows = append(rows, m.barStyle.Render("└"+strings.Repeat("─", inner)+"┘"))
+	return strings.Join(rows, "\n")
+}
@@ -0,0 +1,221 @@
+// Package numberinput provides a numeric text field that can be typed into
+// or stepped with the arrow keys and the mouse wheel, with range checking
+// and inline validation errors.
+package numberinput
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/pondworks-lib/frog"
+)
+
+// Mode selects integer or floating-point input.
+type Mode int
+
+const (
+	Int Mode = iota
+	Float
+)
+
+// Model is a number input.
+type Model struct {
+	text      string
+	mode      Mode
+	min, max  float64
+	step      float64
+	precision int // decimals shown in Float mode after stepping
+	width     int
+	validate  func(float64) error
+	err       error
+
+	style      frog.Style
+	errorStyle frog.Style
+}
+
+// Option configures a Model.
+type Option func(*Model)
+
+// WithMode sets Int (default) or Float input.
+func WithMode(mode Mode) Option { return func(m *Model) { m.mode = mode } }
+
+// WithRange limits the value to min..max inclusive.
+func WithRange(min, max float64) Option { return func(m *Model) { m.min, m.max = min, max } }
+
+// WithStep sets how much one arrow press or wheel notch changes the value
+// (default 1).
+func WithStep(step float64) Option {
+	return func(m *Model) {
+		if step > 0 {
+			m.step = step
+		}
+	}
+}
+
+// WithPrecision sets the decimals shown after stepping in Float mode
+// (default 2).
+func WithPrecision(n int) Option {
+	return func(m *Model) {
+		if n >= 0 {
+			m.precision = n
+		}
+	}
+}
+
+// WithWidth sets the field width in columns (default 8).
+func WithWidth(n int) Option {
+	return func(m *Model) {
+		if n > 0 {
+			m.width = n
+		}
+	}
+}
+
+// WithValidate adds a check run on every valid number within range; its
+// error is shown under the field.
+func WithValidate(fn func(float64) error) Option { return func(m *Model) { m.validate = fn } }
+
+// WithStyles sets the field and error styles.
+func WithStyles(field, err frog.Style) Option {
+	return func(m *Model) { m.style, m.errorStyle = field, err }
+}
+
+// New creates a number input holding value.
+func New(value float64, opts ...Option) Model {
+	m := Model{
+		min:        math.Inf(-1),
+		max:        math.Inf(1),
+		step:       1,
+		precision:  2,
+		width:      8,
+		style:      frog.NewStyle().Underlined(),
+		errorStyle: frog.NewStyle().Fg(frog.ColorRed),
+	}
+	for _, o := range opts {
+		o(&m)
+	}
+	return m.SetValue(value)
+}
+
+// Value returns the entered number and whether it is valid.
+func (m Model) Value() (float64, bool) {
+	v, err := m.parse()
+	return v, err == nil
+}
+
+// Int returns the entered number rounded to an int.
+func (m Model) Int() int {
+	v, _ := m.parse()
+	return int(math.Round(v))
+}
+
+// Err returns the validation error shown under the field, if any.
+func (m Model) Err() error { return m.err }
+
+// SetValue replaces the text with v, clamped to the range.
+func (m Model) SetValue(v float64) Model {
+	v = math.Max(m.min, math.Min(m.max, v))
+	if m.mode == Int {
+		m.text = strconv.FormatInt(int64(math.Round(v)), 10)
+	} else {
+		m.text = strconv.FormatFloat(v, 'f', m.precision, 64)
+	}
+	m.err = m.check()
+	return m
+}
+
+// Init implements frog.Model.
+func (m Model) Init() frog.Cmd { return nil }
+
+// KeyBindings implements frog.KeyHelper.
+func (m Model) KeyBindings() []frog.Binding {
+	return []frog.Binding{{Keys: "↑/↓ wheel", Help: "increase/decrease"}}
+}
+
+// Update handles typing, stepping and the mouse wheel.
+func (m Model) Update(msg frog.Msg) (frog.Model, frog.Cmd) {
+	switch msg := msg.(type) {
+	case frog.KeyMsg:
+		switch msg.Type {
+		case frog.KeyUp:
+			return m.stepBy(1), nil
+		case frog.KeyDown:
+			return m.stepBy(-1), nil
+		case frog.KeyBackspace:
+			if m.text != "" {
+				m.text = m.text[:len(m.text)-1]
+			}
+		case frog.KeyRune:
+			if msg.Alt || msg.Ctrl || !strings.ContainsRune("0123456789.-+eE", msg.Rune) {
+				return m, nil
+			}
+			m.text += string(msg.Rune)
+		default:
+			return m, nil
+		}
+		m.err = m.check()
+	case frog.MouseMsg:
+		switch msg.Button {
+		case frog.MouseWheelUp:
+			return m.stepBy(1), nil
+		case frog.MouseWheelDown:
+			return m.stepBy(-1), nil
+		}
+	}
+	return m, nil
+}
+
+// stepBy moves the value n steps, starting from the nearest bound when the
+// text is not a number.
+func (m Model) stepBy(n int) Model {
+	v, err := strconv.ParseFloat(strings.TrimSpace(m.text), 64)
+	if err != nil {
+		v = math.Max(m.min, math.Min(m.max, 0))
+	}
+	return m.SetValue(v + float64(n)*m.step)
+}
+
+func (m Model) parse() (float64, error) {
+	s := strings.TrimSpace(m.text)
+	if s == "" {
+		return 0, errors.New("enter a number")
+	}
+	v, err := strconv.ParseFloat(s, 64)
+	if err != nil || math.IsNaN(v) || math.IsInf(v, 0) {
+		return 0, errors.New("not a number")
+	}
+	if m.mode == Int && v != math.Trunc(v) {
+		return v, errors.New("must be a whole number")
+	}
+	if v < m.min {
+		return v, fmt.Errorf("must be at least %s", m.format(m.min))
+	}
+	if v > m.max {
+		return v, fmt.Errorf("must be at most %s", m.format(m.max))
+	}
+	return v, nil
+}
+
+func (m Model) check() error {
+	v, err := m.parse()
+	if err == nil && m.validate != nil {
+		err = m.validate(v)
+	}
+	return err
+}
+
+func (m Model) format(v float64) string { return strconv.FormatFloat(v, 'g', -1, 64) }
+
+// View renders the field with spinner arrows, and the error on a second
+// line when the input is invalid.
+func (m Model) View() string {
+	text := frog.Truncate(m.text, m.width)
+	field := m.style.Render(strings.Repeat(" ", m.width-frog.DisplayWidth(text))+text) + " ▴▾"
+	if m.err == nil {
+		return field
+	}
+	return field + "\n" + m.errorStyle.Render("✗ "+m.err.Error())
+}
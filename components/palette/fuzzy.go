@@ -0,0 +1,45 @@
+package palette
+
+import (
+	"unicode"
+	"unicode/utf8"
+)
+
+// fuzzy matches query against s as a case-insensitive subsequence. It
+// returns the byte offsets of the matched runes and a score that favours
+// matches at the start of s, at word starts and in consecutive runs.
+func fuzzy(query, s string) (score int, pos []int, ok bool) {
+	if query == "" {
+		return 0, nil, true
+	}
+	qi := 0
+	q, qn := utf8.DecodeRuneInString(query)
+	prevMatched, prev := false, ' '
+	for i, r := range s {
+		if unicode.ToLower(r) == unicode.ToLower(q) {
+			pos = append(pos, i)
+			score++
+			switch {
+			case i == 0:
+				score += 8
+			case !isWordRune(prev) || unicode.IsUpper(r) && unicode.IsLower(prev):
+				score += 6
+			}
+			if prevMatched {
+				score += 4
+			}
+			prevMatched = true
+			qi += qn
+			if qi == len(query) {
+				return score - (utf8.RuneCountInString(s)-len(pos))/4, pos, true
+			}
+			q, qn = utf8.DecodeRuneInString(query[qi:])
+		} else {
+			prevMatched = false
+		}
+		prev = r
+	}
+	return 0, nil, false
+}
+
+func isWordRune(r rune) bool { return unicode.IsLetter(r) || unicode.IsDigit(r) }
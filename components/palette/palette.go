@@ -0,0 +1,334 @@
+// Package palette provides a command palette: an overlay, opened with a key
+// such as Ctrl+P, that fuzzy-searches the application's actions and runs the
+// one chosen.
+package palette
+
+import (
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/pondworks-lib/frog"
+)
+
+// Action is an entry of the palette.
+type Action struct {
+	Name string   // shown and searched
+	Keys string   // the action's own key binding, shown as a hint; optional
+	Run  frog.Cmd // run when the action is chosen; may be nil
+}
+
+// KeyMap names the keys the palette handles besides the arrow keys, Enter
+// and Backspace. Keys are written as KeyMsg.String, prefixed with "alt+"
+// or "ctrl+" for modified runes.
+type KeyMap struct {
+	Open  string
+	Close string
+	Next  string // move down the results, besides ↓
+	Prev  string // move up the results, besides ↑
+}
+
+// DefaultKeyMap is the key map used by New.
+var DefaultKeyMap = KeyMap{Open: "ctrl+p", Close: "\x1b", Next: "ctrl+n", Prev: "ctrl+p"}
+
+// Bindings describes the palette's keys for a help overlay.
+func (k KeyMap) Bindings() []frog.Binding {
+	return []frog.Binding{{Keys: k.Open, Help: "command palette"}}
+}
+
+// Model wraps an application model with a command palette. While the
+// palette is closed, messages go to Child; while it is open, key and mouse
+// messages go to the palette and everything else still reaches Child. The
+// palette assumes it fills the screen from the top-left corner.
+type Model struct {
+	Child frog.Model
+
+	actions       []Action
+	open          bool
+	query         string
+	results       []result
+	cursor        int // index into results
+	top           int // first result shown
+	width, height int
+	maxRows       int
+	keys          KeyMap
+
+	borderStyle   frog.Style
+	selectedStyle frog.Style
+	matchStyle    frog.Style
+	hintStyle     frog.Style
+}
+
+// result is an action matching the query.
+type result struct {
+	action int
+	score  int
+	pos    []int // byte offsets of matched runes in the name
+}
+
+// Option configures a Model.
+type Option func(*Model)
+
+// WithKeyMap replaces the default key map.
+func WithKeyMap(k KeyMap) Option { return func(m *Model) { m.keys = k } }
+
+// WithMaxRows sets how many results are shown at once (default 8).
+func WithMaxRows(n int) Option {
+	return func(m *Model) {
+		if n > 0 {
+			m.maxRows = n
+		}
+	}
+}
+
+// WithStyles sets the styles for the border, the selected result and the
+// matched characters of other results.
+func WithStyles(border, selected, match frog.Style) Option {
+	return func(m *Model) { m.borderStyle, m.selectedStyle, m.matchStyle = border, selected, match }
+}
+
+// New wraps child with a palette over actions.
+func New(child frog.Model, actions []Action, opts ...Option) Model {
+	m := Model{
+		Child:         child,
+		actions:       actions,
+		maxRows:       8,
+		keys:          DefaultKeyMap,
+		borderStyle:   frog.NewStyle(),
+		selectedStyle: frog.NewStyle().Reversed(),
+		matchStyle:    frog.NewStyle().Bolded().Underlined(),
+		hintStyle:     frog.NewStyle().Fainted(),
+	}
+	for _, o := range opts {
+		o(&m)
+	}
+	return m
+}
+
+// SetActions replaces the actions offered.
+func (m Model) SetActions(actions []Action) Model {
+	m.actions = actions
+	return m.search()
+}
+
+// IsOpen reports whether the palette is shown.
+func (m Model) IsOpen() bool { return m.open }
+
+// Open shows the palette with an empty query.
+func (m Model) Open() Model {
+	m.open, m.query = true, ""
+	return m.search()
+}
+
+// Close hides the palette.
+func (m Model) Close() Model {
+	m.open = false
+	return m
+}
+
+// Init initializes the child.
+func (m Model) Init() frog.Cmd { return m.Child.Init() }
+
+// KeyBindings lists the child's keys, if it describes them, and the
+// palette's own.
+func (m Model) KeyBindings() []frog.Binding {
+	var b []frog.Binding
+	if kh, ok := m.Child.(frog.KeyHelper); ok {
+		b = kh.KeyBindings()
+	}
+	return append(b, m.keys.Bindings()...)
+}
+
+// Update opens the palette on the Open key and routes messages as described
+// on Model.
+func (m Model) Update(msg frog.Msg) (frog.Model, frog.Cmd) {
+	switch msg := msg.(type) {
+	case frog.ResizeMsg:
+		m.width, m.height = msg.Width, msg.Height
+	case frog.KeyMsg:
+		if m.open {
+			return m.key(msg)
+		}
+		if keyName(msg) == m.keys.Open {
+			return m.Open(), nil
+		}
+	case frog.MouseMsg:
+		if m.open {
+			return m.mouse(msg)
+		}
+	}
+	var cmd frog.Cmd
+	m.Child, cmd = m.Child.Update(msg)
+	return m, cmd
+}
+
+func (m Model) key(k frog.KeyMsg) (frog.Model, frog.Cmd) {
+	name := keyName(k)
+	switch {
+	case name == m.keys.Close:
+		return m.Close(), nil
+	case k.Type == frog.KeyEnter:
+		return m.run(m.cursor)
+	case k.Type == frog.KeyDown || name == m.keys.Next:
+		return m.move(1), nil
+	case k.Type == frog.KeyUp || name == m.keys.Prev:
+		return m.move(-1), nil
+	case k.Type == frog.KeyBackspace:
+		if m.query != "" {
+			r := []rune(m.query)
+			m.query = string(r[:len(r)-1])
+			return m.search(), nil
+		}
+	case k.Type == frog.KeyRune && !k.Alt && !k.Ctrl:
+		m.query += string(k.Rune)
+		return m.search(), nil
+	}
+	return m, nil
+}
+
+// mouse runs a clicked result, closes the palette on a click outside it and
+// scrolls the results with the wheel.
+func (m Model) mouse(msg frog.MouseMsg) (frog.Model, frog.Cmd) {
+	x, y, w, h := m.box()
+	col, row := msg.X-1, msg.Y-1
+	switch msg.Action {
+	case frog.MouseWheel:
+		if msg.Button == frog.MouseWheelUp {
+			return m.move(-1), nil
+		}
+		return m.move(1), nil
+	case frog.MousePress:
+		if col < x || col >= x+w || row < y || row >= y+h {
+			return m.Close(), nil
+		}
+		if i := m.top + row - y - 3; row-y >= 3 && i < len(m.results) && i < m.top+m.maxRows {
+			return m.run(i)
+		}
+	}
+	return m, nil
+}
+
+func (m Model) move(n int) Model {
+	if len(m.results) == 0 {
+		return m
+	}
+	m.cursor = max(min(m.cursor+n, len(m.results)-1), 0)
+	if m.cursor < m.top {
+		m.top = m.cursor
+	} else if m.cursor >= m.top+m.maxRows {
+		m.top = m.cursor - m.maxRows + 1
+	}
+	return m
+}
+
+// run closes the palette and runs result i.
+func (m Model) run(i int) (frog.Model, frog.Cmd) {
+	if i < 0 || i >= len(m.results) {
+		return m, nil
+	}
+	m = m.Close()
+	return m, m.actions[m.results[i].action].Run
+}
+
+// search ranks the actions against the query, best first. An empty query
+// lists every action in order.
+func (m Model) search() Model {
+	m.results = m.results[:0:0]
+	for i, a := range m.actions {
+		if score, pos, ok := fuzzy(m.query, a.Name); ok {
+			m.results = append(m.results, result{action: i, score: score, pos: pos})
+		}
+	}
+	sort.SliceStable(m.results, func(i, j int) bool { return m.results[i].score > m.results[j].score })
+	m.cursor, m.top = 0, 0
+	return m
+}
+
+// box returns the palette's position and size on screen: centred
+// horizontally in the upper part of the screen.
+func (m Model) box() (x, y, w, h int) {
+	width, height := m.width, m.height
+	if width <= 0 {
+		width = 80
+	}
+	if height <= 0 {
+		height = 24
+	}
+	w = max(min(60, width-4), 20)
+	h = 4 + max(min(len(m.results), m.maxRows), 1)
+	return max((width-w)/2, 0), height / 5, w, h
+}
+
+// View renders the child with the palette on top when it is open.
+func (m Model) View() string {
+	view := m.Child.View()
+	if !m.open {
+		return view
+	}
+	x, y, w, _ := m.box()
+	inner := w - 2
+	bar := func(l, r string) string { return m.borderStyle.Render(l + strings.Repeat("─", inner) + r) }
+	side := m.borderStyle.Render("│")
+	rows := []string{
+		bar("╭", "╮"),
+		side + fit(" > "+m.query+"█", inner) + side,
+		bar("├", "┤"),
+	}
+	if len(m.results) == 0 {
+		rows = append(rows, side+m.hintStyle.Render(fit(" no matching commands", inner))+side)
+	}
+	for i := m.top; i < min(m.top+m.maxRows, len(m.results)); i++ {
+		rows = append(rows, side+m.item(m.results[i], inner, i == m.cursor)+side)
+	}
+	rows = append(rows, bar("╰", "╯"))
+	return frog.Overlay(view, strings.Join(rows, "\n"), x, y)
+}
+
+// item renders one result w columns wide: the name with its matched
+// characters marked and the action's keys right-aligned.
+func (m Model) item(r result, w int, selected bool) string {
+	a := m.actions[r.action]
+	keys := ""
+	if a.Keys != "" {
+		keys = a.Keys + " "
+	}
+	nameW := max(w-1-frog.DisplayWidth(keys)-1, 1)
+	name := frog.Truncate(a.Name, nameW)
+	pad := strings.Repeat(" ", max(w-1-frog.DisplayWidth(name)-frog.DisplayWidth(keys), 0))
+	if selected {
+		return m.selectedStyle.Render(" " + name + pad + keys)
+	}
+	var b strings.Builder
+	b.WriteString(" ")
+	prev := 0
+	for _, p := range r.pos {
+		if p >= len(name) {
+			break
+		}
+		_, size := utf8.DecodeRuneInString(name[p:])
+		end := p + size
+		b.WriteString(name[prev:p])
+		b.WriteString(m.matchStyle.Render(name[p:end]))
+		prev = end
+	}
+	b.WriteString(name[prev:])
+	b.WriteString(pad)
+	b.WriteString(m.hintStyle.Render(keys))
+	return b.String()
+}
+
+// fit pads or truncates s to w columns.
+func fit(s string, w int) string {
+	s = frog.Truncate(s, w)
+	return s + strings.Repeat(" ", max(w-frog.DisplayWidth(s), 0))
+}
+
+func keyName(k frog.KeyMsg) string {
+	switch {
+	case k.Ctrl && k.Type == frog.KeyRune:
+		return "ctrl+" + string(k.Rune)
+	case k.Alt:
+		return "alt+" + k.String
+	}
+	return k.String
+}
@@ -0,0 +1,86 @@
+// Package progress draws horizontal progress bars.
+package progress
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/pondworks-lib/frog"
+)
+
+// Model is a progress bar.
+type Model struct {
+	width       int
+	percent     float64
+	full, empty string
+	fullStyle   frog.Style
+	emptyStyle  frog.Style
+	showPercent bool
+}
+
+// Option configures a Model.
+type Option func(*Model)
+
+// WithWidth sets the bar width in columns, excluding the percentage
+// (default 20).
+func WithWidth(n int) Option {
+	return func(m *Model) {
+		if n > 0 {
+			m.width = n
+		}
+	}
+}
+
+// WithChars sets the filled and empty cell characters (default "█" and "░").
+func WithChars(full, empty string) Option {
+	return func(m *Model) { m.full, m.empty = full, empty }
+}
+
+// WithStyles sets the filled and empty cell styles.
+func WithStyles(full, empty frog.Style) Option {
+	return func(m *Model) { m.fullStyle, m.emptyStyle = full, empty }
+}
+
+// WithPercentage shows the percentage after the bar.
+func WithPercentage(show bool) Option { return func(m *Model) { m.showPercent = show } }
+
+// New creates a progress bar at 0%.
+func New(opts ...Option) Model {
+	m := Model{
+		width:      20,
+		full:       "█",
+		empty:      "░",
+		fullStyle:  frog.NewStyle(),
+		emptyStyle: frog.NewStyle(),
+	}
+	for _, o := range opts {
+		o(&m)
+	}
+	return m
+}
+
+// SetPercent sets the progress, 0..1.
+func (m Model) SetPercent(p float64) Model {
+	m.percent = math.Max(0, math.Min(1, p))
+	return m
+}
+
+// Percent reports the progress, 0..1.
+func (m Model) Percent() float64 { return m.percent }
+
+// View renders the bar.
+func (m Model) View() string {
+	filled := int(math.Round(m.percent * float64(m.width)))
+	var b strings.Builder
+	if filled > 0 {
+		b.WriteString(m.fullStyle.Render(strings.Repeat(m.full, filled)))
+	}
+	if filled < m.width {
+		b.WriteString(m.emptyStyle.Render(strings.Repeat(m.empty, m.width-filled)))
+	}
+	if m.showPercent {
+		fmt.Fprintf(&b, " %3.0f%%", m.percent*100)
+	}
+	return b.String()
+}
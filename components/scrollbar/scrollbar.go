@@ -0,0 +1,244 @@
+// Package scrollbar draws scrollbars (a track with a proportional thumb) for
+// scrollable components and maps mouse clicks, drags and wheel events on the
+// bar to a scroll offset. Mouse events carry screen coordinates only, so the
+// bar is told where it is drawn (SetPosition) and tests the events itself.
+package scrollbar
+
+import (
+	"math"
+	"strings"
+
+	"github.com/pondworks-lib/frog"
+)
+
+// Orientation selects a vertical (right edge) or horizontal (bottom edge) bar.
+type Orientation int
+
+const (
+	Vertical Orientation = iota
+	Horizontal
+)
+
+// Model is a scrollbar. It holds the content geometry (total, visible,
+// offset) and where the bar is drawn, so it can tell which mouse events
+// land on it.
+type Model struct {
+	orientation Orientation
+	length      int // track length in cells
+	x, y        int // 0-based screen position of the first cell
+
+	total, visible, offset int
+
+	track, thumb           string
+	trackStyle, thumbStyle frog.Style
+	wheelStep              int
+
+	dragging   bool
+	dragAnchor int // pointer position within the thumb when the drag began
+}
+
+// Option configures a Model.
+type Option func(*Model)
+
+// WithOrientation sets the bar direction (default Vertical).
+func WithOrientation(o Orientation) Option { return func(m *Model) { m.orientation = o } }
+
+// WithChars sets the track and thumb characters (default "░" and "█").
+func WithChars(track, thumb string) Option {
+	return func(m *Model) { m.track, m.thumb = track, thumb }
+}
+
+// WithStyles sets the track and thumb styles.
+func WithStyles(track, thumb frog.Style) Option {
+	return func(m *Model) { m.trackStyle, m.thumbStyle = track, thumb }
+}
+
+// WithWheelStep sets how far one wheel notch over the bar scrolls (default 3).
+func WithWheelStep(n int) Option {
+	return func(m *Model) {
+		if n > 0 {
+			m.wheelStep = n
+		}
+	}
+}
+
+// New creates a scrollbar.
+func New(opts ...Option) Model {
+	m := Model{
+		track:      "░",
+		thumb:      "█",
+		trackStyle: frog.NewStyle(),
+		thumbStyle: frog.NewStyle(),
+		wheelStep:  3,
+	}
+	for _, o := range opts {
+		o(&m)
+	}
+	return m
+}
+
+// SetContent sets the scrollable geometry: total content size, the visible
+// part and the current offset, all in rows (or columns).
+func (m Model) SetContent(total, visible, offset int) Model {
+	m.total, m.visible = max(total, 0), max(visible, 0)
+	m.offset = clamp(offset, 0, m.maxOffset())
+	return m
+}
+
+// SetLength sets the track length in cells.
+func (m Model) SetLength(n int) Model {
+	m.length = max(n, 0)
+	return m
+}
+
+// SetPosition records where the bar's first cell is drawn (0-based screen
+// coordinates), which Hit and Update compare mouse events with.
+func (m Model) SetPosition(x, y int) Model {
+	m.x, m.y = x, y
+	return m
+}
+
+// Offset returns the scroll offset.
+func (m Model) Offset() int { return m.offset }
+
+// Scrollable reports whether the content is larger than the visible area.
+func (m Model) Scrollable() bool { return m.total > m.visible }
+
+// Thumb returns the thumb's first cell and size along the track.
+func (m Model) Thumb() (start, size int) {
+	if m.length <= 0 {
+		return 0, 0
+	}
+	if !m.Scrollable() {
+		return 0, m.length
+	}
+	size = int(math.Round(float64(m.length) * float64(m.visible) / float64(m.total)))
+	size = clamp(size, 1, m.length)
+	start = int(math.Round(float64(m.length-size) * float64(m.offset) / float64(m.maxOffset())))
+	return start, size
+}
+
+// Hit reports whether the mouse event is over the bar and, if so, the cell
+// along the track it points at.
+func (m Model) Hit(msg frog.MouseMsg) (cell int, ok bool) {
+	col, row := msg.X-1-m.x, msg.Y-1-m.y
+	if m.orientation == Horizontal {
+		return col, row == 0 && col >= 0 && col < m.length
+	}
+	return row, col == 0 && row >= 0 && row < m.length
+}
+
+// Init implements frog.Model.
+func (m Model) Init() frog.Cmd { return nil }
+
+// Update handles mouse events on the bar: wheel scrolls, a click on the
+// track pages towards the pointer and dragging the thumb scrolls with it.
+// Other messages are ignored. The result is always a Model; read the new
+// position with Offset.
+func (m Model) Update(msg frog.Msg) (frog.Model, frog.Cmd) {
+	switch msg := msg.(type) {
+	case frog.MouseMsg:
+		m = m.mouse(msg)
+	}
+	return m, nil
+}
+
+func (m Model) mouse(msg frog.MouseMsg) Model {
+	cell, hit := m.Hit(msg) // cell is meaningful off the bar too, for drags
+
+	switch {
+	case m.dragging && msg.Action == frog.MouseDrag:
+		m.offset = m.offsetForThumb(cell - m.dragAnchor)
+	case m.dragging && msg.Action == frog.MouseRelease:
+		m.dragging = false
+	case !hit:
+		return m
+	case msg.Action == frog.MouseWheel:
+		if msg.Button == frog.MouseWheelUp {
+			m.offset -= m.wheelStep
+		} else {
+			m.offset += m.wheelStep
+		}
+	case msg.Action == frog.MousePress && msg.Button == frog.MouseLeft:
+		start, size := m.Thumb()
+		switch {
+		case cell < start:
+			m.offset -= m.visible
+		case cell >= start+size:
+			m.offset += m.visible
+		default:
+			m.dragging = true
+			m.dragAnchor = cell - start
+		}
+	}
+	m.offset = clamp(m.offset, 0, m.maxOffset())
+	return m
+}
+
+// offsetForThumb converts a thumb start cell into a content offset.
+func (m Model) offsetForThumb(start int) int {
+	_, size := m.Thumb()
+	free := m.length - size
+	if free <= 0 {
+		return 0
+	}
+	return int(math.Round(float64(clamp(start, 0, free)) * float64(m.maxOffset()) / float64(free)))
+}
+
+func (m Model) maxOffset() int { return max(m.total-m.visible, 0) }
+
+// View renders the bar: one column of length rows when vertical, one row
+// of length columns when horizontal.
+func (m Model) View() string {
+	start, size := m.Thumb()
+	cells := make([]string, m.length)
+	for i := range cells {
+		if i >= start && i < start+size {
+			cells[i] = m.thumbStyle.Render(m.thumb)
+		} else {
+			cells[i] = m.trackStyle.Render(m.track)
+		}
+	}
+	if m.orientation == Horizontal {
+		return strings.Join(cells, "")
+	}
+	return strings.Join(cells, "\n")
+}
+
+// AttachRight appends a vertical bar to the right edge of content, padding
+// content lines to width columns.
+func AttachRight(content string, width int, bar Model) string {
+	lines := strings.Split(content, "\n")
+	cells := strings.Split(bar.View(), "\n")
+	n := max(len(lines), len(cells))
+	out := make([]string, n)
+	for i := range out {
+		var line, cell string
+		if i < len(lines) {
+			line = frog.Truncate(lines[i], width)
+		}
+		if pad := width - frog.DisplayWidth(line); pad > 0 {
+			line += strings.Repeat(" ", pad)
+		}
+		if i < len(cells) {
+			cell = cells[i]
+		}
+		out[i] = line + cell
+	}
+	return strings.Join(out, "\n")
+}
+
+// AttachBottom appends a horizontal bar below content.
+func AttachBottom(content string, bar Model) string {
+	return content + "\n" + bar.View()
+}
+
+func clamp(n, lo, hi int) int {
+	if n > hi {
+		n = hi
+	}
+	if n < lo {
+		n = lo
+	}
+	return n
+}
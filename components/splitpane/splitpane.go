@@ -0,0 +1,362 @@
+// Package splitpane provides a container that shows two models side by side
+// or stacked, separated by a divider that can be moved with the keyboard or
+// dragged with the mouse.
+package splitpane
+
+import (
+	"math"
+	"strings"
+
+	"github.com/pondworks-lib/frog"
+)
+
+// Orientation selects how the panes are arranged.
+type Orientation int
+
+const (
+	Horizontal Orientation = iota // panes side by side, vertical divider
+	Vertical                      // panes stacked, horizontal divider
+)
+
+// KeyMap names the keys the split pane handles itself. Keys are written as
+// KeyMsg.String, prefixed with "alt+" or "ctrl+" for modified runes.
+type KeyMap struct {
+	Shrink string // move the divider towards the first pane
+	Grow   string // move the divider towards the second pane
+	Focus  string // switch keyboard focus between panes
+}
+
+// DefaultKeyMap is the key map used by New.
+var DefaultKeyMap = KeyMap{Shrink: "alt+<", Grow: "alt+>", Focus: "ctrl+w"}
+
+// Bindings describes the keys for a help overlay.
+func (k KeyMap) Bindings() []frog.Binding {
+	return []frog.Binding{
+		{Keys: k.Shrink + "/" + k.Grow, Help: "move divider"},
+		{Keys: k.Focus, Help: "switch pane"},
+	}
+}
+
+// Model is a split-pane container. Key messages go to the focused pane,
+// mouse messages to the pane under the pointer (with coordinates made
+// relative to it) and all other messages to both panes.
+type Model struct {
+	First, Second frog.Model
+
+	orientation   Orientation
+	ratio         float64
+	minSize       int
+	step          int
+	keys          KeyMap
+	divider       string
+	dividerStyle  frog.Style
+	focus         int // 0 = First, 1 = Second
+	x, y          int // 0-based screen offset of the container
+	width, height int
+	dragging      bool
+}
+
+// Option configures a Model.
+type Option func(*Model)
+
+// WithOrientation sets the pane arrangement (default Horizontal).
+func WithOrientation(o Orientation) Option { return func(m *Model) { m.orientation = o } }
+
+// WithRatio sets the share of space given to the first pane, 0..1 (default 0.5).
+func WithRatio(r float64) Option { return func(m *Model) { m.ratio = clampRatio(r) } }
+
+// WithMinSize sets the smallest size either pane may shrink to (default 1).
+func WithMinSize(n int) Option {
+	return func(m *Model) {
+		if n >= 0 {
+			m.minSize = n
+		}
+	}
+}
+
+// WithStep sets how many cells a resize key moves the divider (default 1).
+func WithStep(n int) Option {
+	return func(m *Model) {
+		if n > 0 {
+			m.step = n
+		}
+	}
+}
+
+// WithKeyMap replaces the default key map.
+func WithKeyMap(k KeyMap) Option { return func(m *Model) { m.keys = k } }
+
+// WithDivider sets the divider character and style. The default is "│" for
+// Horizontal and "─" for Vertical.
+func WithDivider(ch string, style frog.Style) Option {
+	return func(m *Model) { m.divider, m.dividerStyle = ch, style }
+}
+
+// WithOffset tells the container where it is drawn on screen (0-based), so
+// mouse coordinates can be mapped when it is not at the top-left corner.
+func WithOffset(x, y int) Option { return func(m *Model) { m.x, m.y = x, y } }
+
+// New creates a split pane around first and second.
+func New(first, second frog.Model, opts ...Option) Model {
+	m := Model{
+		First:        first,
+		Second:       second,
+		ratio:        0.5,
+		minSize:      1,
+		step:         1,
+		keys:         DefaultKeyMap,
+		dividerStyle: frog.NewStyle(),
+	}
+	for _, o := range opts {
+		o(&m)
+	}
+	if m.divider == "" {
+		m.divider = "│"
+		if m.orientation == Vertical {
+			m.divider = "─"
+		}
+	}
+	return m
+}
+
+// Init initializes both panes.
+func (m Model) Init() frog.Cmd {
+	return frog.BatchAll(m.First.Init(), m.Second.Init())
+}
+
+// Ratio reports the share of space given to the first pane.
+func (m Model) Ratio() float64 { return m.ratio }
+
+// Focused reports which pane has keyboard focus (0 or 1).
+func (m Model) Focused() int { return m.focus }
+
+// KeyBindings lists the focused pane's keys, if it describes them, and the
+// split pane's own.
+func (m Model) KeyBindings() []frog.Binding {
+	var b []frog.Binding
+	focused := m.First
+	if m.focus == 1 {
+		focused = m.Second
+	}
+	if kh, ok := focused.(frog.KeyHelper); ok {
+		b = kh.KeyBindings()
+	}
+	return append(b, m.keys.Bindings()...)
+}
+
+// SetSize resizes the container and sends each pane its new size.
+func (m Model) SetSize(width, height int) (Model, frog.Cmd) {
+	m.width, m.height = width, height
+	return m.layout()
+}
+
+// Update routes msg to the panes and handles resizing and focus keys.
+func (m Model) Update(msg frog.Msg) (frog.Model, frog.Cmd) {
+	switch msg := msg.(type) {
+	case frog.ResizeMsg:
+		return m.SetSize(msg.Width, msg.Height)
+
+	case frog.KeyMsg:
+		switch keyName(msg) {
+		case m.keys.Shrink:
+			return m.move(-m.step)
+		case m.keys.Grow:
+			return m.move(m.step)
+		case m.keys.Focus:
+			m.focus = 1 - m.focus
+			return m, nil
+		}
+		var cmd frog.Cmd
+		if m.focus == 0 {
+			m.First, cmd = m.First.Update(msg)
+		} else {
+			m.Second, cmd = m.Second.Update(msg)
+		}
+		return m, cmd
+
+	case frog.MouseMsg:
+		return m.mouse(msg)
+	}
+
+	var c1, c2 frog.Cmd
+	m.First, c1 = m.First.Update(msg)
+	m.Second, c2 = m.Second.Update(msg)
+	return m, frog.BatchAll(c1, c2)
+}
+
+// mouse drags the divider or forwards the event to the pane under it.
+func (m Model) mouse(msg frog.MouseMsg) (frog.Model, frog.Cmd) {
+	// pos is the 0-based cell along the split axis, relative to the container.
+	pos := msg.X - 1 - m.x
+	if m.orientation == Vertical {
+		pos = msg.Y - 1 - m.y
+	}
+	first := m.firstSize()
+
+	switch {
+	case m.dragging && msg.Action == frog.MouseDrag:
+		return m.setFirstSize(pos)
+	case m.dragging && msg.Action == frog.MouseRelease:
+		m.dragging = false
+		return m, nil
+	case msg.Action == frog.MousePress && msg.Button == frog.MouseLeft && pos == first:
+		m.dragging = true
+		m.focus = 0
+		return m, nil
+	}
+
+	var cmd frog.Cmd
+	if pos < first {
+		m.focus = focusOnPress(msg, m.focus, 0)
+		m.First, cmd = m.First.Update(m.relative(msg, 0))
+	} else if pos > first {
+		m.focus = focusOnPress(msg, m.focus, 1)
+		m.Second, cmd = m.Second.Update(m.relative(msg, first+1))
+	}
+	return m, cmd
+}
+
+func focusOnPress(msg frog.MouseMsg, cur, pane int) int {
+	if msg.Action == frog.MousePress {
+		return pane
+	}
+	return cur
+}
+
+// relative shifts msg so it is relative to a pane starting at off cells
+// along the split axis.
+func (m Model) relative(msg frog.MouseMsg, off int) frog.MouseMsg {
+	msg.X -= m.x
+	msg.Y -= m.y
+	if m.orientation == Vertical {
+		msg.Y -= off
+	} else {
+		msg.X -= off
+	}
+	return msg
+}
+
+func (m Model) move(delta int) (frog.Model, frog.Cmd) {
+	return m.setFirstSize(m.firstSize() + delta)
+}
+
+func (m Model) setFirstSize(n int) (Model, frog.Cmd) {
+	avail := m.avail()
+	if avail <= 0 {
+		return m, nil
+	}
+	n = clamp(n, m.minSize, avail-m.minSize)
+	m.ratio = float64(n) / float64(avail)
+	return m.layout()
+}
+
+// layout sends both panes their current sizes.
+func (m Model) layout() (Model, frog.Cmd) {
+	first := m.firstSize()
+	second := m.avail() - first
+	var c1, c2 frog.Cmd
+	if m.orientation == Vertical {
+		m.First, c1 = resize(m.First, m.width, first)
+		m.Second, c2 = resize(m.Second, m.width, second)
+	} else {
+		m.First, c1 = resize(m.First, first, m.height)
+		m.Second, c2 = resize(m.Second, second, m.height)
+	}
+	return m, frog.BatchAll(c1, c2)
+}
+
+func resize(child frog.Model, w, h int) (frog.Model, frog.Cmd) {
+	if r, ok := child.(frog.Resizer); ok {
+		return r.OnResize(w, h)
+	}
+	return child.Update(frog.ResizeMsg{Width: w, Height: h})
+}
+
+// avail is the space along the split axis left after the divider.
+func (m Model) avail() int {
+	n := m.width
+	if m.orientation == Vertical {
+		n = m.height
+	}
+	if n--; n < 0 {
+		return 0
+	}
+	return n
+}
+
+func (m Model) firstSize() int {
+	avail := m.avail()
+	n := int(math.Round(m.ratio * float64(avail)))
+	if avail >= 2*m.minSize {
+		n = clamp(n, m.minSize, avail-m.minSize)
+	}
+	return clamp(n, 0, avail)
+}
+
+// View renders both panes fitted to their sizes with the divider between.
+func (m Model) View() string {
+	first := m.firstSize()
+	second := m.avail() - first
+	div := m.dividerStyle.Render(m.divider)
+
+	if m.orientation == Vertical {
+		var b strings.Builder
+		b.WriteString(fit(m.First.View(), m.width, first))
+		if first > 0 {
+			b.WriteByte('\n')
+		}
+		b.WriteString(m.dividerStyle.Render(strings.Repeat(m.divider, m.width)))
+		if second > 0 {
+			b.WriteByte('\n')
+			b.WriteString(fit(m.Second.View(), m.width, second))
+		}
+		return b.String()
+	}
+
+	left := strings.Split(fit(m.First.View(), first, m.height), "\n")
+	right := strings.Split(fit(m.Second.View(), second, m.height), "\n")
+	rows := make([]string, m.height)
+	for i := range rows {
+		rows[i] = left[i] + div + right[i]
+	}
+	return strings.Join(rows, "\n")
+}
+
+// fit pads or truncates view to exactly w columns by h rows.
+func fit(view string, w, h int) string {
+	lines := strings.Split(view, "\n")
+	out := make([]string, h)
+	for i := range out {
+		var line string
+		if i < len(lines) {
+			line = frog.Truncate(lines[i], w)
+		}
+		if pad := w - frog.DisplayWidth(line); pad > 0 {
+			line += strings.Repeat(" ", pad)
+		}
+		out[i] = line
+	}
+	return strings.Join(out, "\n")
+}
+
+func keyName(k frog.KeyMsg) string {
+	switch {
+	case k.Ctrl && k.Type == frog.KeyRune:
+		return "ctrl+" + string(k.Rune)
+	case k.Alt:
+		return "alt+" + k.String
+	}
+	return k.String
+}
+
+func clamp(n, lo, hi int) int {
+	if n > hi {
+		n = hi
+	}
+	if n < lo {
+		n = lo
+	}
+	return n
+}
+
+func clampRatio(r float64) float64 { return math.Max(0, math.Min(1, r)) }
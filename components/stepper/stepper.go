@@ -0,0 +1,200 @@
+// Package stepper shows progress through a multi-step flow such as an
+// installer or setup wizard — completed steps checked, the current one
+// highlighted — and moves between steps on NextMsg and BackMsg.
+package stepper
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/pondworks-lib/frog"
+)
+
+// NextMsg moves the stepper to the next step, or finishes the flow on the
+// last step.
+type NextMsg struct{}
+
+// BackMsg moves the stepper to the previous step.
+type BackMsg struct{}
+
+// ChangedMsg is emitted after the current step changes.
+type ChangedMsg struct{ From, To int }
+
+// FinishedMsg is emitted on NextMsg at the last step.
+type FinishedMsg struct{}
+
+// Next returns a command that sends NextMsg, for use from a step's Update
+// once its input is valid.
+func Next() frog.Cmd { return func() frog.Msg { return NextMsg{} } }
+
+// Back returns a command that sends BackMsg.
+func Back() frog.Cmd { return func() frog.Msg { return BackMsg{} } }
+
+// Orientation selects how the steps are laid out.
+type Orientation int
+
+const (
+	Horizontal Orientation = iota // breadcrumb style, on one row
+	Vertical                      // one step per row
+)
+
+// Model is a stepper.
+type Model struct {
+	steps    []string
+	current  int
+	finished bool
+	layout   Orientation
+	width    int
+	counter  bool
+
+	doneMark, currentMark, pendingMark string
+	separator                          string
+
+	doneStyle, currentStyle, pendingStyle frog.Style
+}
+
+// Option configures a Model.
+type Option func(*Model)
+
+// WithOrientation sets the layout (default Horizontal).
+func WithOrientation(o Orientation) Option { return func(m *Model) { m.layout = o } }
+
+// WithWidth sets the width available to a horizontal stepper. Steps that do
+// not fit are shown by their marks only, except the current one.
+func WithWidth(n int) Option { return func(m *Model) { m.width = n } }
+
+// WithCounter prefixes a horizontal stepper with "Step n of N".
+func WithCounter() Option { return func(m *Model) { m.counter = true } }
+
+// WithMarks sets the marks for completed, current and pending steps
+// (default "✓", "●" and "○").
+func WithMarks(done, current, pending string) Option {
+	return func(m *Model) { m.doneMark, m.currentMark, m.pendingMark = done, current, pending }
+}
+
+// WithSeparator sets the text between steps in a horizontal stepper
+// (default " › ").
+func WithSeparator(s string) Option { return func(m *Model) { m.separator = s } }
+
+// WithStyles sets the styles for completed, current and pending steps.
+func WithStyles(done, current, pending frog.Style) Option {
+	return func(m *Model) { m.doneStyle, m.currentStyle, m.pendingStyle = done, current, pending }
+}
+
+// New creates a stepper over steps, at the first step.
+func New(steps []string, opts ...Option) Model {
+	m := Model{
+		steps:        steps,
+		doneMark:     "✓",
+		currentMark:  "●",
+		pendingMark:  "○",
+		separator:    " › ",
+		doneStyle:    frog.NewStyle().Fg(frog.ColorGreen),
+		currentStyle: frog.NewStyle().Bolded(),
+		pendingStyle: frog.NewStyle().Fainted(),
+	}
+	for _, o := range opts {
+		o(&m)
+	}
+	return m
+}
+
+// Current returns the index of the current step.
+func (m Model) Current() int { return m.current }
+
+// Finished reports whether NextMsg was received at the last step; every
+// step then shows as completed.
+func (m Model) Finished() bool { return m.finished }
+
+// Len returns the number of steps.
+func (m Model) Len() int { return len(m.steps) }
+
+// SetCurrent jumps to step i, clamped to the steps.
+func (m Model) SetCurrent(i int) Model {
+	m.current = max(min(i, len(m.steps)-1), 0)
+	m.finished = false
+	return m
+}
+
+// Init implements frog.Model.
+func (m Model) Init() frog.Cmd { return nil }
+
+// Update moves between steps on NextMsg and BackMsg.
+func (m Model) Update(msg frog.Msg) (frog.Model, frog.Cmd) {
+	from := m.current
+	switch msg.(type) {
+	case NextMsg:
+		if m.finished {
+			return m, nil
+		}
+		if m.current == len(m.steps)-1 {
+			m.finished = true
+			return m, func() frog.Msg { return FinishedMsg{} }
+		}
+		m.current++
+	case BackMsg:
+		if m.finished { // reopen the last step
+			m.finished = false
+			return m, nil
+		}
+		if m.current == 0 {
+			return m, nil
+		}
+		m.current--
+	default:
+		return m, nil
+	}
+	to := m.current
+	return m, func() frog.Msg { return ChangedMsg{From: from, To: to} }
+}
+
+// state returns the mark and style for step i.
+func (m Model) state(i int) (string, frog.Style) {
+	switch {
+	case i < m.current || m.finished:
+		return m.doneMark, m.doneStyle
+	case i == m.current:
+		return m.currentMark, m.currentStyle
+	}
+	return m.pendingMark, m.pendingStyle
+}
+
+// View renders the steps.
+func (m Model) View() string {
+	if m.layout == Vertical {
+		rows := make([]string, len(m.steps))
+		for i, s := range m.steps {
+			rows[i] = m.crumb(i, s)
+		}
+		return strings.Join(rows, "\n")
+	}
+
+	prefix := ""
+	if m.counter && len(m.steps) > 0 {
+		prefix = fmt.Sprintf("Step %d of %d  ", m.current+1, len(m.steps))
+	}
+	full := make([]string, len(m.steps))
+	for i, s := range m.steps {
+		full[i] = m.crumb(i, s)
+	}
+	line := prefix + strings.Join(full, m.separator)
+	if m.width <= 0 || frog.DisplayWidth(line) <= m.width {
+		return line
+	}
+	// Too wide: keep the current step's title and show the others by mark.
+	compact := make([]string, len(m.steps))
+	for i := range m.steps {
+		if i == m.current {
+			compact[i] = full[i]
+			continue
+		}
+		mark, st := m.state(i)
+		compact[i] = st.Render(mark)
+	}
+	return frog.Truncate(prefix+strings.Join(compact, " "), m.width)
+}
+
+func (m Model) crumb(i int, title string) string {
+	mark, st := m.state(i)
+	return st.Render(mark + " " + title)
+}
@@ -0,0 +1,304 @@
+package table
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// WithNumberFormat sets how FromCSV, FromJSON and FromStructs print cells
+// of numeric columns, such as FormatThousands(2). By default numbers are
+// shown as given.
+func WithNumberFormat(fn func(float64) string) Option {
+	return func(m *Model) { m.numberFormat = fn }
+}
+
+// FormatThousands returns a number format with the given number of
+// decimals and commas between groups of thousands, for WithNumberFormat.
+func FormatThousands(decimals int) func(float64) string {
+	return func(v float64) string {
+		s := strconv.FormatFloat(v, 'f', decimals, 64)
+		sign := ""
+		if strings.HasPrefix(s, "-") {
+			sign, s = "-", s[1:]
+		}
+		whole, frac, _ := strings.Cut(s, ".")
+		var b strings.Builder
+		b.WriteString(sign)
+		for i, r := range whole {
+			if i > 0 && (len(whole)-i)%3 == 0 {
+				b.WriteByte(',')
+			}
+			b.WriteRune(r)
+		}
+		if frac != "" {
+			b.WriteString("." + frac)
+		}
+		return b.String()
+	}
+}
+
+// FromCSV creates a table from comma-separated values whose first record
+// holds the column titles. Columns whose cells are all numbers are
+// right-aligned, formatted by WithNumberFormat and sorted numerically.
+func FromCSV(r io.Reader, width, height int, opts ...Option) (Model, error) {
+	return fromDelimited(r, ',', width, height, opts)
+}
+
+// FromTSV is FromCSV for tab-separated values.
+func FromTSV(r io.Reader, width, height int, opts ...Option) (Model, error) {
+	return fromDelimited(r, '\t', width, height, opts)
+}
+
+func fromDelimited(r io.Reader, comma rune, width, height int, opts []Option) (Model, error) {
+	cr := csv.NewReader(r)
+	cr.Comma = comma
+	cr.FieldsPerRecord = -1
+	records, err := cr.ReadAll()
+	if err != nil {
+		return Model{}, fmt.Errorf("table: %w", err)
+	}
+	if len(records) == 0 {
+		return Model{}, errors.New("table: no header record")
+	}
+	rows := make([]Row, len(records)-1)
+	for i, rec := range records[1:] {
+		rows[i] = rec
+	}
+	return build(records[0], rows, nil, width, height, opts), nil
+}
+
+// FromJSON creates a table from a JSON array of objects. Columns are the
+// objects' keys in the order they first appear; missing keys and nulls
+// give empty cells, and nested values are shown as compact JSON. Columns
+// are aligned and formatted as in FromCSV.
+func FromJSON(data []byte, width, height int, opts ...Option) (Model, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	if err := expectDelim(dec, '['); err != nil {
+		return Model{}, err
+	}
+	var titles []string
+	index := map[string]int{}
+	var rows []Row
+	for dec.More() {
+		if err := expectDelim(dec, '{'); err != nil {
+			return Model{}, err
+		}
+		row := make(Row, len(titles))
+		for dec.More() {
+			tok, err := dec.Token()
+			if err != nil {
+				return Model{}, fmt.Errorf("table: %w", err)
+			}
+			key := tok.(string)
+			var raw json.RawMessage
+			if err := dec.Decode(&raw); err != nil {
+				return Model{}, fmt.Errorf("table: %w", err)
+			}
+			col, ok := index[key]
+			if !ok {
+				col = len(titles)
+				index[key] = col
+				titles = append(titles, key)
+			}
+			for len(row) <= col {
+				row = append(row, "")
+			}
+			row[col] = jsonCell(raw)
+		}
+		if err := expectDelim(dec, '}'); err != nil {
+			return Model{}, err
+		}
+		rows = append(rows, row)
+	}
+	if err := expectDelim(dec, ']'); err != nil {
+		return Model{}, err
+	}
+	return build(titles, rows, nil, width, height, opts), nil
+}
+
+func expectDelim(dec *json.Decoder, want json.Delim) error {
+	tok, err := dec.Token()
+	if err != nil {
+		return fmt.Errorf("table: %w", err)
+	}
+	if d, ok := tok.(json.Delim); !ok || d != want {
+		return fmt.Errorf("table: expected %q in JSON, got %v", want, tok)
+	}
+	return nil
+}
+
+func jsonCell(raw json.RawMessage) string {
+	switch {
+	case string(raw) == "null":
+		return ""
+	case raw[0] == '"':
+		var s string
+		json.Unmarshal(raw, &s)
+		return s
+	}
+	var b bytes.Buffer
+	if json.Compact(&b, raw) != nil {
+		return string(raw)
+	}
+	return b.String()
+}
+
+// FromStructs creates a table with a row per item of a struct type, or
+// pointer to one, and a column per exported field. A `table:"Title"` tag
+// renames a field's column and `table:"-"` leaves it out. Pointer fields
+// show what they point to, or nothing if nil. Numeric fields are aligned
+// and formatted as in FromCSV.
+func FromStructs[T any](items []T, width, height int, opts ...Option) (Model, error) {
+	t := reflect.TypeFor[T]()
+	if t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		return Model{}, fmt.Errorf("table: FromStructs needs a struct type, not %v", t)
+	}
+	var (
+		titles  []string
+		fields  [][]int
+		numeric []bool
+	)
+	for _, f := range reflect.VisibleFields(t) {
+		if !f.IsExported() || f.Anonymous {
+			continue
+		}
+		title := f.Tag.Get("table")
+		switch title {
+		case "-":
+			continue
+		case "":
+			title = f.Name
+		}
+		titles = append(titles, title)
+		fields = append(fields, f.Index)
+		ft := f.Type
+		for ft.Kind() == reflect.Pointer {
+			ft = ft.Elem()
+		}
+		numeric = append(numeric, isNumber(ft.Kind()))
+	}
+	rows := make([]Row, len(items))
+	for i, item := range items {
+		v := reflect.ValueOf(item)
+		if v.Kind() == reflect.Pointer {
+			if v.IsNil() {
+				rows[i] = make(Row, len(fields))
+				continue
+			}
+			v = v.Elem()
+		}
+		row := make(Row, len(fields))
+		for c, idx := range fields {
+			f, err := v.FieldByIndexErr(idx)
+			if err != nil {
+				continue // through a nil embedded pointer
+			}
+			row[c] = fieldText(f)
+		}
+		rows[i] = row
+	}
+	return build(titles, rows, numeric, width, height, opts), nil
+}
+
+var stringerType = reflect.TypeFor[fmt.Stringer]()
+
+// fieldText prints a field, following pointers and interfaces to the value
+// unless the pointer has a String method. Nil prints as nothing.
+func fieldText(v reflect.Value) string {
+	for v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return ""
+		}
+		if v.Kind() == reflect.Pointer && v.Type().Implements(stringerType) {
+			break
+		}
+		v = v.Elem()
+	}
+	return fmt.Sprint(v.Interface())
+}
+
+func isNumber(k reflect.Kind) bool {
+	return k >= reflect.Int && k <= reflect.Float64
+}
+
+// build creates the table, inferring which columns are numeric unless
+// numeric is given.
+func build(titles []string, rows []Row, numeric []bool, width, height int, opts []Option) Model {
+	m := New(nil, nil, width, height, opts...)
+	if numeric == nil {
+		numeric = inferNumeric(len(titles), rows)
+	}
+	columns := make([]Column, len(titles))
+	for i, title := range titles {
+		columns[i] = Column{Title: title}
+		if !numeric[i] {
+			continue
+		}
+		columns[i].Align = AlignRight
+		columns[i].Less = lessNumber
+		if m.numberFormat == nil {
+			continue
+		}
+		for _, r := range rows {
+			if i >= len(r) {
+				continue
+			}
+			if v, err := strconv.ParseFloat(r[i], 64); err == nil {
+				r[i] = m.numberFormat(v)
+			}
+		}
+	}
+	return m.SetColumns(columns, rows)
+}
+
+// inferNumeric reports the columns with at least one cell whose non-empty
+// cells are all numbers.
+func inferNumeric(n int, rows []Row) []bool {
+	numeric := make([]bool, n)
+	seen := make([]bool, n)
+	for i := range numeric {
+		numeric[i] = true
+	}
+	for _, r := range rows {
+		for i := 0; i < n && i < len(r); i++ {
+			if r[i] == "" {
+				continue
+			}
+			seen[i] = true
+			if _, err := strconv.ParseFloat(r[i], 64); err != nil {
+				numeric[i] = false
+			}
+		}
+	}
+	for i := range numeric {
+		numeric[i] = numeric[i] && seen[i]
+	}
+	return numeric
+}
+
+// lessNumber orders formatted numbers, ignoring group separators; cells
+// that are not numbers sort first.
+func lessNumber(a, b string) bool {
+	x, errX := parseNumber(a)
+	y, errY := parseNumber(b)
+	switch {
+	case errX != nil || errY != nil:
+		return errX != nil && errY == nil
+	}
+	return x < y
+}
+
+func parseNumber(s string) (float64, error) {
+	s = strings.NewReplacer(",", "", "_", "", " ", "").Replace(s)
+	return strconv.ParseFloat(s, 64)
+}
@@ -0,0 +1,108 @@
+package table
+
+import (
+	"net/url"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+)
+
+// checkTable compares m's column titles, right-aligned columns and rows.
+func checkTable(t *testing.T, m Model, titles []string, right []bool, rows []Row) {
+	t.Helper()
+	var gotTitles []string
+	var gotRight []bool
+	for _, c := range m.Columns() {
+		gotTitles = append(gotTitles, c.Title)
+		gotRight = append(gotRight, c.Align == AlignRight)
+	}
+	if !slices.Equal(gotTitles, titles) {
+		t.Errorf("titles = %q, want %q", gotTitles, titles)
+	}
+	if !slices.Equal(gotRight, right) {
+		t.Errorf("right-aligned = %v, want %v", gotRight, right)
+	}
+	if !reflect.DeepEqual(m.Rows(), rows) {
+		t.Errorf("rows = %q, want %q", m.Rows(), rows)
+	}
+}
+
+func TestFromCSV(t *testing.T) {
+	m, err := FromCSV(strings.NewReader("name,qty\npen,1200\n\"ink, blue\",\nnib,3\n"), 40, 10,
+		WithNumberFormat(FormatThousands(0)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkTable(t, m, []string{"name", "qty"}, []bool{false, true},
+		[]Row{{"pen", "1,200"}, {"ink, blue", ""}, {"nib", "3"}})
+
+	if _, err := FromCSV(strings.NewReader(""), 40, 10); err == nil {
+		t.Error("empty input: no error")
+	}
+	if _, err := FromCSV(strings.NewReader("a\n\"open"), 40, 10); err == nil {
+		t.Error("bad quoting: no error")
+	}
+}
+
+func TestFromTSV(t *testing.T) {
+	m, err := FromTSV(strings.NewReader("id\tnote\n2\ta,b\n10\t\n"), 40, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkTable(t, m, []string{"id", "note"}, []bool{true, false}, []Row{{"2", "a,b"}, {"10", ""}})
+}
+
+func TestFromJSON(t *testing.T) {
+	m, err := FromJSON([]byte(`[
+		{"name": "pen", "qty": 2},
+		{"qty": null, "tags": ["a", "b"], "name": "ink"},
+		{"name": "nib", "meta": {"x": 1}}
+	]`), 40, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkTable(t, m, []string{"name", "qty", "tags", "meta"}, []bool{false, true, false, false},
+		[]Row{{"pen", "2"}, {"ink", "", `["a","b"]`}, {"nib", "", "", `{"x":1}`}})
+
+	for _, bad := range []string{`{}`, `[1]`, `[{"a": 1}`, `[{"a": }]`} {
+		if _, err := FromJSON([]byte(bad), 40, 10); err == nil {
+			t.Errorf("FromJSON(%s): no error", bad)
+		}
+	}
+}
+
+type part struct {
+	Name   string
+	Qty    *int
+	Price  float64 `table:"Unit price"`
+	Note   *string
+	Link   *url.URL
+	Secret string `table:"-"`
+	hidden int
+}
+
+func TestFromStructs(t *testing.T) {
+	qty, note := 4, "spare"
+	link, _ := url.Parse("https://example.com/pen")
+	items := []*part{
+		{Name: "pen", Qty: &qty, Price: 1.5, Note: &note, Link: link, Secret: "x", hidden: 1},
+		{Name: "nib"},
+		nil,
+	}
+	m, err := FromStructs(items, 60, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkTable(t, m, []string{"Name", "Qty", "Unit price", "Note", "Link"},
+		[]bool{false, true, true, false, false},
+		[]Row{
+			{"pen", "4", "1.5", "spare", "https://example.com/pen"},
+			{"nib", "", "0", "", ""},
+			{"", "", "", "", ""},
+		})
+
+	if _, err := FromStructs([]int{1}, 40, 10); err == nil {
+		t.Error("FromStructs([]int): no error")
+	}
+}
@@ -0,0 +1,200 @@
+package table
+
+import (
+	"math"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/pondworks-lib/frog"
+)
+
+// SortKey is one column of the sort order.
+type SortKey struct {
+	Column int
+	Desc   bool
+}
+
+// SortChangedMsg is emitted when the user changes the sort order. Keys
+// lists the sort columns, most significant first; it is empty when the
+// table is unsorted. Applications whose rows come from a database can
+// re-query with the new order.
+type SortChangedMsg struct{ Keys []SortKey }
+
+// FilterChangedMsg is emitted when the user changes a column filter.
+// Filters holds one entry per column; "" means no filter.
+type FilterChangedMsg struct{ Filters []string }
+
+// WithExternalSort leaves sorting and filtering to the application: the
+// table still shows the sort marks and filter row and emits SortChangedMsg
+// and FilterChangedMsg, but displays rows in the order given, for rows
+// that a backing store has already sorted and filtered.
+func WithExternalSort() Option { return func(m *Model) { m.external = true } }
+
+// Sort returns the current sort order.
+func (m Model) Sort() []SortKey { return m.sort }
+
+// SetSort sets the sort order, most significant column first. Keys for
+// columns the table does not have are ignored.
+func (m Model) SetSort(keys ...SortKey) Model {
+	m.sort = slices.DeleteFunc(slices.Clone(keys), func(k SortKey) bool {
+		return k.Column < 0 || k.Column >= len(m.columns)
+	})
+	return m.refresh()
+}
+
+// Filters returns the column filters.
+func (m Model) Filters() []string { return m.filters }
+
+// SetFilter filters column col to rows containing s, ignoring case; ""
+// removes the filter.
+func (m Model) SetFilter(col int, s string) Model {
+	if col < 0 || col >= len(m.filters) {
+		return m
+	}
+	m.filters = append([]string(nil), m.filters...)
+	m.filters[col] = s
+	return m.refresh()
+}
+
+func (m Model) filtered() bool {
+	return slices.ContainsFunc(m.filters, func(f string) bool { return f != "" })
+}
+
+// toggleSort cycles col through ascending, descending and unsorted. With
+// add, the other sort columns are kept and col is appended; otherwise col
+// becomes the only one.
+func (m Model) toggleSort(col int, add bool) (frog.Model, frog.Cmd) {
+	if col < 0 || col >= len(m.columns) {
+		return m, nil
+	}
+	i := slices.IndexFunc(m.sort, func(k SortKey) bool { return k.Column == col })
+	var keys []SortKey
+	if add {
+		keys = append(keys, m.sort...)
+	}
+	switch {
+	case i < 0:
+		keys = append(keys, SortKey{Column: col})
+	case !m.sort[i].Desc:
+		k := SortKey{Column: col, Desc: true}
+		if add {
+			keys[i] = k
+		} else {
+			keys = append(keys, k)
+		}
+	case add:
+		keys = slices.Delete(keys, i, i+1)
+	}
+	m = m.SetSort(keys...)
+	sorted := slices.Clone(keys)
+	return m, func() frog.Msg { return SortChangedMsg{Keys: sorted} }
+}
+
+func (m Model) filtersChanged() (frog.Model, frog.Cmd) {
+	m = m.refresh()
+	filters := slices.Clone(m.filters)
+	return m, func() frog.Msg { return FilterChangedMsg{Filters: filters} }
+}
+
+// sortMark returns the header mark for col: an arrow, numbered when
+// several columns are sorted.
+func (m Model) sortMark(col int) string {
+	i := slices.IndexFunc(m.sort, func(k SortKey) bool { return k.Column == col })
+	if i < 0 {
+		return ""
+	}
+	mark := "▲"
+	if m.sort[i].Desc {
+		mark = "▼"
+	}
+	if len(m.sort) > 1 {
+		mark += strconv.Itoa(i + 1)
+	}
+	return " " + mark
+}
+
+// refresh recomputes the rows shown, keeping the selected row selected
+// where it is still shown.
+func (m Model) refresh() Model {
+	selected := m.Cursor()
+	m.view = m.view[:0:0]
+	lower := make([]string, len(m.filters))
+	for i, f := range m.filters {
+		lower[i] = strings.ToLower(f)
+	}
+	for i, r := range m.rows {
+		if m.external || m.matches(r, lower) {
+			m.view = append(m.view, i)
+		}
+	}
+	if !m.external && len(m.sort) > 0 {
+		sort.SliceStable(m.view, func(a, b int) bool {
+			return m.less(m.rows[m.view[a]], m.rows[m.view[b]])
+		})
+	}
+	m.cursor = 0
+	if i := slices.Index(m.view, selected); i >= 0 {
+		m.cursor = i
+	}
+	return m.scrollToCursor()
+}
+
+func (m Model) matches(r Row, filters []string) bool {
+	for i, f := range filters {
+		if f != "" && (i >= len(r) || !strings.Contains(strings.ToLower(r[i]), f)) {
+			return false
+		}
+	}
+	return true
+}
+
+// less orders rows by the sort keys in turn.
+func (m Model) less(a, b Row) bool {
+	for _, k := range m.sort {
+		x, y := cell(a, k.Column), cell(b, k.Column)
+		if k.Desc {
+			x, y = y, x
+		}
+		cmpLess := compare
+		if fn := m.columns[k.Column].Less; fn != nil {
+			cmpLess = fn
+		}
+		if cmpLess(x, y) {
+			return true
+		}
+		if cmpLess(y, x) {
+			return false
+		}
+	}
+	return false
+}
+
+func cell(r Row, i int) string {
+	if i < len(r) {
+		return r[i]
+	}
+	return ""
+}
+
+// compare is the default cell order: numbers first, in numeric order, then
+// everything else case-insensitively.
+func compare(a, b string) bool {
+	x, okX := number(a)
+	y, okY := number(b)
+	switch {
+	case okX && okY:
+		return x < y
+	case okX || okY:
+		return okX
+	}
+	return strings.ToLower(a) < strings.ToLower(b)
+}
+
+// number parses a numeric cell. NaN is not a number here, as it would not
+// be ordered against the others.
+func number(s string) (float64, bool) {
+	f, err := strconv.ParseFloat(strings.TrimSpace(s), 64)
+	return f, err == nil && !math.IsNaN(f)
+}
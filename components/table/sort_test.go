@@ -0,0 +1,56 @@
+package table
+
+import (
+	"slices"
+	"testing"
+)
+
+// shown returns the first cell of each row in display order.
+func shown(m Model) []string {
+	var out []string
+	for _, i := range m.view {
+		out = append(out, m.rows[i][0])
+	}
+	return out
+}
+
+func TestSortOrder(t *testing.T) {
+	tests := []struct {
+		name  string
+		cells []string
+		desc  bool
+		want  []string
+	}{
+		{"numeric", []string{"10", "2", "1.5"}, false, []string{"1.5", "2", "10"}},
+		{"text ignores case", []string{"b", "A", "c"}, false, []string{"A", "b", "c"}},
+		{"numbers before text", []string{"1a", "10", "2"}, false, []string{"2", "10", "1a"}},
+		{"numbers before text reordered", []string{"2", "1a", "10"}, false, []string{"2", "10", "1a"}},
+		{"NaN is text", []string{"NaN", "3", "1"}, false, []string{"1", "3", "NaN"}},
+		{"descending", []string{"1a", "2", "10"}, true, []string{"1a", "10", "2"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var rows []Row
+			for _, c := range tt.cells {
+				rows = append(rows, Row{c})
+			}
+			m := New([]Column{{Title: "v"}}, rows, 20, 10).SetSort(SortKey{Column: 0, Desc: tt.desc})
+			if got := shown(m); !slices.Equal(got, tt.want) {
+				t.Errorf("sorted %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+// Keys for missing columns are dropped rather than indexing past the
+// columns.
+func TestSetSortIgnoresInvalidColumns(t *testing.T) {
+	m := New([]Column{{Title: "v"}}, []Row{{"b"}, {"a"}}, 20, 10)
+	m = m.SetSort(SortKey{Column: 5}, SortKey{Column: -1}, SortKey{Column: 0})
+	if want := []SortKey{{Column: 0}}; !slices.Equal(m.Sort(), want) {
+		t.Errorf("Sort() = %v, want %v", m.Sort(), want)
+	}
+	if got := shown(m); !slices.Equal(got, []string{"a", "b"}) {
+		t.Errorf("sorted %q, want [a b]", got)
+	}
+}
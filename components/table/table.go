@@ -0,0 +1,454 @@
+// Package table provides a scrollable table with a header row, sorting by
+// one or more columns and per-column filters.
+package table
+
+import (
+	"strings"
+
+	"github.com/pondworks-lib/frog"
+)
+
+// Align positions cell text within its column.
+type Align int
+
+const (
+	AlignLeft Align = iota
+	AlignRight
+	AlignCenter
+)
+
+// Column describes a table column.
+type Column struct {
+	Title string
+	Width int // 0 sizes the column to its content, up to 40 columns
+	Align Align
+	// Less orders two cells when sorting; nil puts numbers first, in
+	// numeric order, and other cells after them case-insensitively.
+	Less func(a, b string) bool
+}
+
+// Row is one table row, a cell per column.
+type Row []string
+
+// SelectedMsg is emitted when the user presses Enter on a row. Index is
+// the row's position in the rows given to the table.
+type SelectedMsg struct {
+	Index int
+	Row   Row
+}
+
+// KeyMap names the keys the table handles besides the arrow, page and
+// Home/End keys; ←/→ pick the column that sorting and filtering apply to.
+// Keys are written as KeyMsg.String, prefixed with "ctrl+" for control
+// runes.
+type KeyMap struct {
+	Sort         string // sort by the current column, cycling ascending, descending, off
+	AddSort      string // as Sort, but keeps the other sort columns
+	Filter       string // type a filter for the current column
+	ClearFilters string
+}
+
+// DefaultKeyMap is the key map used by New.
+var DefaultKeyMap = KeyMap{Sort: "s", AddSort: "S", Filter: "/", ClearFilters: "ctrl+l"}
+
+// Bindings describes the table's keys for a help overlay.
+func (k KeyMap) Bindings() []frog.Binding {
+	return []frog.Binding{
+		{Keys: "↑/↓ pgup/pgdn", Help: "move"},
+		{Keys: "←/→", Help: "choose column"},
+		{Keys: k.Sort, Help: "sort by column"},
+		{Keys: k.AddSort, Help: "add column to sort"},
+		{Keys: k.Filter, Help: "filter column"},
+		{Keys: k.ClearFilters, Help: "clear filters"},
+	}
+}
+
+// Model is a table.
+type Model struct {
+	columns       []Column
+	widths        []int
+	rows          []Row
+	view          []int // indices into rows after filtering and sorting
+	cursor        int   // index into view
+	offset        int   // first row shown
+	column        int   // column sort and filter keys apply to
+	width, height int
+	x, y          int // 0-based screen position, for mouse mapping
+	keys          KeyMap
+
+	sort     []SortKey
+	filters  []string
+	editing  bool // the current column's filter is being typed
+	external bool // sorting and filtering are left to the application
+
+	headerStyle   frog.Style
+	selectedStyle frog.Style
+	filterStyle   frog.Style
+
+	numberFormat func(float64) string // for FromCSV, FromJSON and FromStructs
+}
+
+// Option configures a Model.
+type Option func(*Model)
+
+// WithKeyMap replaces the default key map.
+func WithKeyMap(k KeyMap) Option { return func(m *Model) { m.keys = k } }
+
+// WithStyles sets the styles for the header, the selected row and the
+// filter row.
+func WithStyles(header, selected, filter frog.Style) Option {
+	return func(m *Model) { m.headerStyle, m.selectedStyle, m.filterStyle = header, selected, filter }
+}
+
+// New creates a table of the given size.
+func New(columns []Column, rows []Row, width, height int, opts ...Option) Model {
+	m := Model{
+		columns:       columns,
+		filters:       make([]string, len(columns)),
+		width:         width,
+		height:        height,
+		keys:          DefaultKeyMap,
+		headerStyle:   frog.NewStyle().Bolded(),
+		selectedStyle: frog.NewStyle().Reversed(),
+		filterStyle:   frog.NewStyle().Fainted(),
+	}
+	for _, o := range opts {
+		o(&m)
+	}
+	return m.SetRows(rows)
+}
+
+// Columns returns the table's columns.
+func (m Model) Columns() []Column { return m.columns }
+
+// SetColumns replaces the columns and rows together, clearing the sort
+// and filters.
+func (m Model) SetColumns(columns []Column, rows []Row) Model {
+	m.columns = columns
+	m.filters = make([]string, len(columns))
+	m.sort, m.editing, m.column = nil, false, 0
+	return m.SetRows(rows)
+}
+
+// Rows returns the rows given to the table, unsorted and unfiltered.
+func (m Model) Rows() []Row { return m.rows }
+
+// SetRows replaces the rows, keeping the sort and filters.
+func (m Model) SetRows(rows []Row) Model {
+	m.rows = rows
+	m.widths = m.columnWidths()
+	return m.refresh()
+}
+
+// Cursor returns the index, in the rows given to the table, of the
+// selected row, or -1 if no row is shown.
+func (m Model) Cursor() int {
+	if m.cursor >= len(m.view) {
+		return -1
+	}
+	return m.view[m.cursor]
+}
+
+// SelectedRow returns the selected row, or nil if no row is shown.
+func (m Model) SelectedRow() Row {
+	if i := m.Cursor(); i >= 0 {
+		return m.rows[i]
+	}
+	return nil
+}
+
+// Len returns the number of rows shown after filtering.
+func (m Model) Len() int { return len(m.view) }
+
+// SetSize resizes the table.
+func (m Model) SetSize(width, height int) Model {
+	m.width, m.height = width, height
+	return m.scrollToCursor()
+}
+
+// SetPosition records where the table is drawn (0-based screen
+// coordinates) so mouse events can be mapped to cells.
+func (m Model) SetPosition(x, y int) Model {
+	m.x, m.y = x, y
+	return m
+}
+
+// columnWidths sizes columns without a fixed width to their content.
+func (m Model) columnWidths() []int {
+	w := make([]int, len(m.columns))
+	for i, c := range m.columns {
+		if c.Width > 0 {
+			w[i] = c.Width
+			continue
+		}
+		w[i] = frog.DisplayWidth(c.Title) + 3 // room for the sort mark
+		for _, r := range m.rows {
+			if i < len(r) {
+				w[i] = max(w[i], frog.DisplayWidth(r[i]))
+			}
+		}
+		w[i] = min(w[i], 40)
+	}
+	return w
+}
+
+// bodyTop is the number of rows above the first table row.
+func (m Model) bodyTop() int {
+	if m.editing || m.filtered() {
+		return 2
+	}
+	return 1
+}
+
+func (m Model) bodyHeight() int { return max(m.height-m.bodyTop(), 0) }
+
+func (m Model) scrollToCursor() Model {
+	m.cursor = max(min(m.cursor, len(m.view)-1), 0)
+	h := m.bodyHeight()
+	if m.cursor < m.offset {
+		m.offset = m.cursor
+	} else if h > 0 && m.cursor >= m.offset+h {
+		m.offset = m.cursor - h + 1
+	}
+	m.offset = max(min(m.offset, len(m.view)-h), 0)
+	return m
+}
+
+// Init implements frog.Model.
+func (m Model) Init() frog.Cmd { return nil }
+
+// KeyBindings implements frog.KeyHelper.
+func (m Model) KeyBindings() []frog.Binding { return m.keys.Bindings() }
+
+// Update handles movement, selection, sorting and filtering.
+func (m Model) Update(msg frog.Msg) (frog.Model, frog.Cmd) {
+	switch msg := msg.(type) {
+	case frog.ResizeMsg:
+		return m.SetSize(msg.Width, msg.Height), nil
+	case frog.KeyMsg:
+		if m.editing {
+			return m.editFilter(msg)
+		}
+		return m.key(msg)
+	case frog.MouseMsg:
+		return m.mouse(msg)
+	}
+	return m, nil
+}
+
+func (m Model) key(k frog.KeyMsg) (frog.Model, frog.Cmd) {
+	switch keyName(k) {
+	case m.keys.Sort:
+		return m.toggleSort(m.column, false)
+	case m.keys.AddSort:
+		return m.toggleSort(m.column, true)
+	case m.keys.Filter:
+		if len(m.columns) > 0 {
+			m.editing = true
+		}
+		return m.scrollToCursor(), nil
+	case m.keys.ClearFilters:
+		if !m.filtered() {
+			return m, nil
+		}
+		clear(m.filters)
+		return m.filtersChanged()
+	}
+	switch k.Type {
+	case frog.KeyUp:
+		m.cursor--
+	case frog.KeyDown:
+		m.cursor++
+	case frog.KeyPgUp:
+		m.cursor -= m.bodyHeight()
+	case frog.KeyPgDn:
+		m.cursor += m.bodyHeight()
+	case frog.KeyHome:
+		m.cursor = 0
+	case frog.KeyEnd:
+		m.cursor = len(m.view) - 1
+	case frog.KeyLeft:
+		m.column = max(m.column-1, 0)
+	case frog.KeyRight:
+		m.column = max(min(m.column+1, len(m.columns)-1), 0)
+	case frog.KeyEnter:
+		if i := m.Cursor(); i >= 0 {
+			row := m.rows[i]
+			return m, func() frog.Msg { return SelectedMsg{Index: i, Row: row} }
+		}
+	}
+	return m.scrollToCursor(), nil
+}
+
+// editFilter handles keys while a filter is typed; the filter applies as
+// it is typed. Enter keeps it, Esc clears it.
+func (m Model) editFilter(k frog.KeyMsg) (frog.Model, frog.Cmd) {
+	f := m.filters[m.column]
+	switch k.Type {
+	case frog.KeyEnter:
+		m.editing = false
+		return m.scrollToCursor(), nil
+	case frog.KeyEsc:
+		m.editing = false
+		if f == "" {
+			return m.scrollToCursor(), nil
+		}
+		m.filters = append([]string(nil), m.filters...)
+		m.filters[m.column] = ""
+		return m.filtersChanged()
+	case frog.KeyBackspace:
+		if f == "" {
+			return m, nil
+		}
+		r := []rune(f)
+		f = string(r[:len(r)-1])
+	case frog.KeyRune, frog.KeySpace:
+		if k.Alt || k.Ctrl {
+			return m, nil
+		}
+		f += string(k.Rune)
+	default:
+		return m, nil
+	}
+	m.filters = append([]string(nil), m.filters...)
+	m.filters[m.column] = f
+	return m.filtersChanged()
+}
+
+// mouse sorts on header clicks (Shift adds the column to the sort),
+// selects clicked rows and scrolls with the wheel.
+func (m Model) mouse(msg frog.MouseMsg) (frog.Model, frog.Cmd) {
+	col, row := msg.X-1-m.x, msg.Y-1-m.y
+	if col < 0 || col >= m.width || row < 0 || row >= m.height {
+		return m, nil
+	}
+	switch msg.Action {
+	case frog.MouseWheel:
+		if msg.Button == frog.MouseWheelUp {
+			m.cursor -= 3
+		} else {
+			m.cursor += 3
+		}
+		return m.scrollToCursor(), nil
+	case frog.MousePress:
+		if msg.Button != frog.MouseLeft {
+			return m, nil
+		}
+		c := m.columnAt(col)
+		switch {
+		case row == 0 && c >= 0:
+			m.column = c
+			return m.toggleSort(c, msg.Shift)
+		case row == 1 && m.bodyTop() == 2 && c >= 0:
+			m.column, m.editing = c, true
+		case row >= m.bodyTop():
+			if i := m.offset + row - m.bodyTop(); i < len(m.view) {
+				m.cursor = i
+			}
+			if c >= 0 {
+				m.column = c
+			}
+		}
+	}
+	return m, nil
+}
+
+// columnAt returns the column under screen column x, or -1.
+func (m Model) columnAt(x int) int {
+	left := 0
+	for i, w := range m.widths {
+		if x >= left && x < left+w {
+			return i
+		}
+		left += w + len(gap)
+	}
+	return -1
+}
+
+// gap separates columns.
+const gap = "  "
+
+// View renders the header, the filter row when filters are in use, and
+// the visible rows.
+func (m Model) View() string {
+	lines := make([]string, 0, m.height)
+	header := make([]string, len(m.columns))
+	for i, c := range m.columns {
+		title := c.Title
+		if mark := m.sortMark(i); mark != "" {
+			title = frog.Truncate(title, m.widths[i]-frog.DisplayWidth(mark)) + mark
+		}
+		st := m.headerStyle
+		if i == m.column {
+			st = st.Underlined()
+		}
+		header[i] = st.Render(pad(title, m.widths[i], c.Align))
+	}
+	lines = append(lines, m.fit(strings.Join(header, m.headerStyle.Render(gap))))
+
+	if m.bodyTop() == 2 {
+		cells := make([]string, len(m.columns))
+		for i, f := range m.filters {
+			if m.editing && i == m.column {
+				f += "█"
+			}
+			if f != "" {
+				f = "/" + f
+			}
+			cells[i] = pad(f, m.widths[i], AlignLeft)
+		}
+		lines = append(lines, m.filterStyle.Render(m.fit(strings.Join(cells, gap))))
+	}
+
+	for r := 0; r < m.bodyHeight(); r++ {
+		n := m.offset + r
+		if n >= len(m.view) {
+			lines = append(lines, strings.Repeat(" ", max(m.width, 0)))
+			continue
+		}
+		row := m.rows[m.view[n]]
+		cells := make([]string, len(m.columns))
+		for i, c := range m.columns {
+			var v string
+			if i < len(row) {
+				v = row[i]
+			}
+			cells[i] = pad(v, m.widths[i], c.Align)
+		}
+		line := m.fit(strings.Join(cells, gap))
+		if n == m.cursor {
+			line = m.selectedStyle.Render(line)
+		}
+		lines = append(lines, line)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// fit pads or truncates a line to the table width.
+func (m Model) fit(s string) string {
+	s = frog.Truncate(s, m.width)
+	return s + strings.Repeat(" ", max(m.width-frog.DisplayWidth(s), 0))
+}
+
+// pad truncates s to w columns and aligns it within them.
+func pad(s string, w int, a Align) string {
+	s = frog.Truncate(s, w)
+	space := max(w-frog.DisplayWidth(s), 0)
+	switch a {
+	case AlignRight:
+		return strings.Repeat(" ", space) + s
+	case AlignCenter:
+		return strings.Repeat(" ", space/2) + s + strings.Repeat(" ", space-space/2)
+	}
+	return s + strings.Repeat(" ", space)
+}
+
+func keyName(k frog.KeyMsg) string {
+	switch {
+	case k.Ctrl && k.Type == frog.KeyRune:
+		return "ctrl+" + string(k.Rune)
+	case k.Alt:
+		return "alt+" + k.String
+	}
+	return k.String
+}
@@ -0,0 +1,502 @@
+// Package textarea provides a multi-line text editor with text selection by
+// Shift+arrows or mouse drag, and copy and cut to the clipboard via OSC 52.
+// Selecting inside the editor keeps terminal-native selection from grabbing
+// the rest of the UI.
+package textarea
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/pondworks-lib/frog"
+	"github.com/pondworks-lib/frog/core/text"
+)
+
+// Pos is a position in the text: a line index and a column, the byte
+// offset of a grapheme boundary in the line.
+type Pos struct{ Line, Col int }
+
+func (p Pos) before(q Pos) bool {
+	return p.Line < q.Line || (p.Line == q.Line && p.Col < q.Col)
+}
+
+// KeyMap names the editing keys besides the arrow, Home/End, Backspace,
+// Delete and Enter keys. Keys are written as KeyMsg.Canonical names.
+type KeyMap struct {
+	Copy  string // copy the selection to the clipboard
+	Cut   string // copy the selection and delete it
+	Clear string // drop the selection
+}
+
+// DefaultKeyMap is the key map used by New.
+var DefaultKeyMap = KeyMap{Copy: "ctrl+c", Cut: "ctrl+x", Clear: "esc"}
+
+// Bindings describes the editor's keys for a help overlay.
+func (k KeyMap) Bindings() []frog.Binding {
+	return []frog.Binding{
+		{Keys: "arrows", Help: "move"},
+		{Keys: "shift+arrows", Help: "select text"},
+		{Keys: k.Copy, Help: "copy selection"},
+		{Keys: k.Cut, Help: "cut selection"},
+		{Keys: k.Clear, Help: "clear selection"},
+	}
+}
+
+// Model is a multi-line text editor.
+type Model struct {
+	lines            []string // never empty
+	cursor           Pos
+	anchor           Pos  // the other end of the selection
+	selActive        bool // anchor is set
+	goal             int  // cell column kept by up/down moves
+	hasGoal          bool
+	yOffset, xOffset int // first visible line and cell
+	width, height    int
+	x, y             int // 0-based screen position, for mouse mapping
+
+	keys        KeyMap
+	selStyle    frog.Style
+	cursorStyle frog.Style
+	selecting   bool // a mouse drag is in progress
+}
+
+// Option configures a Model.
+type Option func(*Model)
+
+// WithKeyMap replaces the default key map.
+func WithKeyMap(k KeyMap) Option { return func(m *Model) { m.keys = k } }
+
+// WithSelectionStyle sets the highlight used for selected text (default reversed).
+func WithSelectionStyle(s frog.Style) Option { return func(m *Model) { m.selStyle = s } }
+
+// WithCursorStyle sets the style of the cell under the cursor (default reversed).
+func WithCursorStyle(s frog.Style) Option { return func(m *Model) { m.cursorStyle = s } }
+
+// New creates an empty editor of the given size.
+func New(width, height int, opts ...Option) Model {
+	m := Model{
+		lines:       []string{""},
+		width:       width,
+		height:      height,
+		keys:        DefaultKeyMap,
+		selStyle:    frog.NewStyle().Reversed(),
+		cursorStyle: frog.NewStyle().Reversed(),
+	}
+	for _, o := range opts {
+		o(&m)
+	}
+	return m
+}
+
+// SetValue replaces the text and moves the cursor to its end. Tabs become
+// four spaces and escape sequences are removed.
+func (m Model) SetValue(s string) Model {
+	m.lines = strings.Split(clean(s), "\n")
+	last := len(m.lines) - 1
+	m.cursor = Pos{Line: last, Col: len(m.lines[last])}
+	m.selActive, m.hasGoal = false, false
+	return m.scroll()
+}
+
+// Value returns the text.
+func (m Model) Value() string { return strings.Join(m.lines, "\n") }
+
+// Cursor returns the cursor position.
+func (m Model) Cursor() Pos { return m.cursor }
+
+// SetSize resizes the editor.
+func (m Model) SetSize(width, height int) Model {
+	m.width, m.height = width, height
+	return m.scroll()
+}
+
+// SetPosition records where the editor is drawn (0-based screen
+// coordinates) so mouse events can be mapped to text.
+func (m Model) SetPosition(x, y int) Model {
+	m.x, m.y = x, y
+	return m
+}
+
+// Selection returns the selection bounds and whether a selection exists.
+func (m Model) Selection() (start, end Pos, ok bool) {
+	start, end = m.anchor, m.cursor
+	if end.before(start) {
+		start, end = end, start
+	}
+	return start, end, m.selActive && start != end
+}
+
+// SelectedText returns the selected text.
+func (m Model) SelectedText() string {
+	start, end, ok := m.Selection()
+	if !ok {
+		return ""
+	}
+	if start.Line == end.Line {
+		return m.lines[start.Line][start.Col:end.Col]
+	}
+	parts := []string{m.lines[start.Line][start.Col:]}
+	parts = append(parts, m.lines[start.Line+1:end.Line]...)
+	parts = append(parts, m.lines[end.Line][:end.Col])
+	return strings.Join(parts, "\n")
+}
+
+// Copy returns a command that copies the selection to the system clipboard
+// via OSC 52, or nil if nothing is selected.
+func (m Model) Copy() frog.Cmd {
+	s := m.SelectedText()
+	if s == "" {
+		return nil
+	}
+	return frog.SetClipboard(s)
+}
+
+// Init implements frog.Model.
+func (m Model) Init() frog.Cmd { return nil }
+
+// KeyBindings implements frog.KeyHelper.
+func (m Model) KeyBindings() []frog.Binding { return m.keys.Bindings() }
+
+// Update handles editing, selection, copy and paste.
+func (m Model) Update(msg frog.Msg) (frog.Model, frog.Cmd) {
+	switch msg := msg.(type) {
+	case frog.KeyMsg:
+		return m.key(msg)
+	case frog.PasteMsg:
+		return m.insert(msg.Text).scroll(), nil
+	case frog.MouseMsg:
+		return m.mouse(msg), nil
+	}
+	return m, nil
+}
+
+func (m Model) key(k frog.KeyMsg) (Model, frog.Cmd) {
+	switch k.Canonical() {
+	case "":
+	case m.keys.Copy:
+		return m, m.Copy()
+	case m.keys.Cut:
+		cmd := m.Copy()
+		if cmd == nil {
+			return m, nil
+		}
+		return m.deleteSelection().scroll(), cmd
+	case m.keys.Clear:
+		m.selActive = false
+		return m, nil
+	}
+	switch k.Type {
+	case frog.KeyLeft, frog.KeyRight, frog.KeyUp, frog.KeyDown, frog.KeyHome, frog.KeyEnd:
+		return m.move(k.Type, k.Shift).scroll(), nil
+	case frog.KeyEnter:
+		m = m.insert("\n")
+	case frog.KeyBackspace:
+		m = m.deleteBack()
+	case frog.KeyDelete:
+		m = m.deleteForward()
+	case frog.KeyRune, frog.KeySpace, frog.KeyQ:
+		if k.Ctrl || k.Alt || k.Rune == 0 {
+			return m, nil
+		}
+		m = m.insert(string(k.Rune))
+	default:
+		return m, nil
+	}
+	return m.scroll(), nil
+}
+
+// move moves the cursor, extending the selection when extend is set and
+// dropping it otherwise.
+func (m Model) move(t frog.KeyType, extend bool) Model {
+	if extend && !m.selActive {
+		m.anchor, m.selActive = m.cursor, true
+	}
+	if !extend && m.selActive {
+		start, end, ok := m.Selection()
+		m.selActive = false
+		// Left and Right go to the edge of a selection.
+		if ok && (t == frog.KeyLeft || t == frog.KeyRight) {
+			m.cursor, m.hasGoal = start, false
+			if t == frog.KeyRight {
+				m.cursor = end
+			}
+			return m
+		}
+	}
+	c := m.cursor
+	line := m.lines[c.Line]
+	switch t {
+	case frog.KeyLeft:
+		if c.Col > 0 {
+			c.Col = text.PrevGrapheme(line, c.Col)
+		} else if c.Line > 0 {
+			c.Line--
+			c.Col = len(m.lines[c.Line])
+		}
+	case frog.KeyRight:
+		if c.Col < len(line) {
+			c.Col = text.NextGrapheme(line, c.Col)
+		} else if c.Line < len(m.lines)-1 {
+			c.Line, c.Col = c.Line+1, 0
+		}
+	case frog.KeyUp, frog.KeyDown:
+		if !m.hasGoal {
+			m.goal, m.hasGoal = text.Width(line[:c.Col]), true
+		}
+		switch {
+		case t == frog.KeyUp && c.Line == 0:
+			c.Col = 0
+		case t == frog.KeyDown && c.Line == len(m.lines)-1:
+			c.Col = len(line)
+		default:
+			if t == frog.KeyUp {
+				c.Line--
+			} else {
+				c.Line++
+			}
+			c.Col = colAt(m.lines[c.Line], m.goal)
+		}
+		m.cursor = c
+		return m
+	case frog.KeyHome:
+		c.Col = 0
+	case frog.KeyEnd:
+		c.Col = len(line)
+	}
+	m.cursor, m.hasGoal = c, false
+	return m
+}
+
+// insert replaces the selection, if any, with s at the cursor.
+func (m Model) insert(s string) Model {
+	m = m.deleteSelection()
+	c := m.cursor
+	line := m.lines[c.Line]
+	parts := strings.Split(clean(s), "\n")
+	last := len(parts) - 1
+	col := len(parts[last])
+	parts[0] = line[:c.Col] + parts[0]
+	parts[last] += line[c.Col:]
+	m.lines = slices.Concat(m.lines[:c.Line], parts, m.lines[c.Line+1:])
+	if last > 0 {
+		m.cursor = Pos{Line: c.Line + last, Col: col}
+	} else {
+		m.cursor.Col += col
+	}
+	m.hasGoal = false
+	return m
+}
+
+// deleteBack deletes the selection or the grapheme before the cursor,
+// joining lines at the start of one.
+func (m Model) deleteBack() Model {
+	if _, _, ok := m.Selection(); ok {
+		return m.deleteSelection()
+	}
+	c := m.cursor
+	switch {
+	case c.Col > 0:
+		return m.delete(Pos{Line: c.Line, Col: text.PrevGrapheme(m.lines[c.Line], c.Col)}, c)
+	case c.Line > 0:
+		return m.delete(Pos{Line: c.Line - 1, Col: len(m.lines[c.Line-1])}, c)
+	}
+	return m
+}
+
+// deleteForward deletes the selection or the grapheme after the cursor,
+// joining lines at the end of one.
+func (m Model) deleteForward() Model {
+	if _, _, ok := m.Selection(); ok {
+		return m.deleteSelection()
+	}
+	c := m.cursor
+	switch line := m.lines[c.Line]; {
+	case c.Col < len(line):
+		return m.delete(c, Pos{Line: c.Line, Col: text.NextGrapheme(line, c.Col)})
+	case c.Line < len(m.lines)-1:
+		return m.delete(c, Pos{Line: c.Line + 1})
+	}
+	return m
+}
+
+// deleteSelection deletes the selected text, if any.
+func (m Model) deleteSelection() Model {
+	start, end, ok := m.Selection()
+	m.selActive = false
+	if !ok {
+		return m
+	}
+	return m.delete(start, end)
+}
+
+// delete removes the text from start to end and leaves the cursor at start.
+func (m Model) delete(start, end Pos) Model {
+	joined := m.lines[start.Line][:start.Col] + m.lines[end.Line][end.Col:]
+	m.lines = slices.Concat(m.lines[:start.Line], []string{joined}, m.lines[end.Line+1:])
+	m.cursor, m.selActive, m.hasGoal = start, false, false
+	return m
+}
+
+func (m Model) mouse(msg frog.MouseMsg) Model {
+	row, col := msg.Y-1-m.y, msg.X-1-m.x
+	switch msg.Action {
+	case frog.MouseWheel:
+		if msg.Button == frog.MouseWheelUp {
+			m.yOffset--
+		} else {
+			m.yOffset++
+		}
+		m.yOffset = clamp(m.yOffset, 0, max(len(m.lines)-m.height, 0))
+	case frog.MousePress:
+		if msg.Button != frog.MouseLeft || row < 0 || row >= m.height || col < 0 || col >= m.width {
+			return m
+		}
+		p := m.posAt(row, col)
+		m.cursor, m.anchor, m.selActive, m.hasGoal = p, p, true, false
+		m.selecting = true
+	case frog.MouseDrag:
+		if !m.selecting {
+			return m
+		}
+		// Dragging past an edge scrolls.
+		if row < 0 {
+			m.yOffset = max(m.yOffset-1, 0)
+		} else if row >= m.height {
+			m.yOffset = clamp(m.yOffset+1, 0, max(len(m.lines)-m.height, 0))
+		}
+		m.cursor = m.posAt(clamp(row, 0, m.height-1), clamp(col, 0, m.width))
+	case frog.MouseRelease:
+		m.selecting = false
+	}
+	return m
+}
+
+// posAt maps a cell inside the editor to a text position.
+func (m Model) posAt(row, col int) Pos {
+	line := clamp(m.yOffset+row, 0, len(m.lines)-1)
+	return Pos{Line: line, Col: colAt(m.lines[line], m.xOffset+col)}
+}
+
+// scroll keeps the cursor in view.
+func (m Model) scroll() Model {
+	c := m.cursor
+	if c.Line < m.yOffset {
+		m.yOffset = c.Line
+	} else if m.height > 0 && c.Line >= m.yOffset+m.height {
+		m.yOffset = c.Line - m.height + 1
+	}
+	cx := text.Width(m.lines[c.Line][:c.Col])
+	if cx < m.xOffset {
+		m.xOffset = cx
+	} else if m.width > 0 && cx >= m.xOffset+m.width {
+		m.xOffset = cx - m.width + 1
+	}
+	return m
+}
+
+// View renders the visible text with the selection and cursor.
+func (m Model) View() string {
+	start, end, sel := m.Selection()
+	rows := make([]string, m.height)
+	for i := range rows {
+		n := m.yOffset + i
+		if n >= len(m.lines) {
+			rows[i] = strings.Repeat(" ", m.width)
+			continue
+		}
+		rows[i] = m.renderLine(n, start, end, sel)
+	}
+	return strings.Join(rows, "\n")
+}
+
+// Kinds of cells drawn by renderLine.
+const (
+	plain = iota
+	selected
+	cursor
+)
+
+// renderLine draws the visible cells of line n, m.width wide.
+func (m Model) renderLine(n int, start, end Pos, sel bool) string {
+	line := m.lines[n]
+	styles := [...]frog.Style{plain: frog.NewStyle(), selected: m.selStyle, cursor: m.cursorStyle}
+	var b, run strings.Builder
+	kind := plain
+	add := func(k int, s string) {
+		if k != kind && run.Len() > 0 {
+			b.WriteString(styles[kind].Render(run.String()))
+			run.Reset()
+		}
+		kind = k
+		run.WriteString(s)
+	}
+	left, right := m.xOffset, m.xOffset+m.width
+	cell := 0
+	for i := 0; i < len(line) && cell < right; {
+		j := text.NextGrapheme(line, i)
+		g, w := line[i:j], text.GraphemeWidth(line[i:j])
+		k := plain
+		p := Pos{Line: n, Col: i}
+		switch {
+		case p == m.cursor:
+			k = cursor
+		case sel && !p.before(start) && p.before(end):
+			k = selected
+		}
+		switch {
+		case cell >= left && cell+w <= right:
+			add(k, g)
+		case cell+w > left:
+			// A wide character cut by an edge shows as blanks.
+			add(plain, strings.Repeat(" ", min(cell+w, right)-max(cell, left)))
+		}
+		cell += w
+		i = j
+	}
+	if m.cursor == (Pos{Line: n, Col: len(line)}) && cell >= left && cell < right {
+		add(cursor, " ")
+		cell++
+	}
+	add(plain, strings.Repeat(" ", max(right-max(cell, left), 0)))
+	b.WriteString(styles[kind].Render(run.String()))
+	return b.String()
+}
+
+// colAt returns the offset of the grapheme in line covering cell, or the
+// line's length past its end.
+func colAt(line string, cell int) int {
+	w := 0
+	for i := 0; i < len(line); {
+		j := text.NextGrapheme(line, i)
+		if w += text.GraphemeWidth(line[i:j]); w > cell {
+			return i
+		}
+		i = j
+	}
+	return len(line)
+}
+
+// clean normalizes line endings, expands tabs and drops escape sequences
+// and other control characters from text to be inserted.
+func clean(s string) string {
+	s = frog.StripANSI(s)
+	s = strings.ReplaceAll(s, "\r\n", "\n")
+	s = strings.ReplaceAll(s, "\r", "\n")
+	s = strings.ReplaceAll(s, "\t", "    ")
+	return strings.Map(func(r rune) rune {
+		if r < 0x20 && r != '\n' || r == 0x7f {
+			return -1
+		}
+		return r
+	}, s)
+}
+
+func clamp(n, lo, hi int) int {
+	if n > hi {
+		n = hi
+	}
+	if n < lo {
+		n = lo
+	}
+	return n
+}
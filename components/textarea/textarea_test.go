@@ -0,0 +1,95 @@
+package textarea
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/pondworks-lib/frog"
+)
+
+func send(m Model, msgs ...frog.Msg) Model {
+	for _, msg := range msgs {
+		next, _ := m.Update(msg)
+		m = next.(Model)
+	}
+	return m
+}
+
+func typed(s string) []frog.Msg {
+	var msgs []frog.Msg
+	for _, r := range s {
+		if r == '\n' {
+			msgs = append(msgs, frog.KeyMsg{Type: frog.KeyEnter})
+			continue
+		}
+		msgs = append(msgs, frog.KeyMsg{Type: frog.KeyRune, Rune: r, String: string(r)})
+	}
+	return msgs
+}
+
+func key(t frog.KeyType, shift bool) frog.KeyMsg { return frog.KeyMsg{Type: t, Shift: shift} }
+
+func TestEditing(t *testing.T) {
+	m := send(New(20, 3), typed("héllo\n日本x")...)
+	m = send(m, key(frog.KeyBackspace, false), key(frog.KeyUp, false), key(frog.KeyEnd, false), key(frog.KeyDelete, false))
+	if got := m.Value(); got != "héllo日本" {
+		t.Fatalf("Value = %q, want %q", got, "héllo日本")
+	}
+}
+
+func TestKeyboardSelection(t *testing.T) {
+	m := New(20, 3).SetValue("ab\n日本語")
+	m = send(m, key(frog.KeyLeft, true), key(frog.KeyLeft, true), key(frog.KeyUp, true))
+	if got := m.SelectedText(); got != "\n日本語" {
+		t.Fatalf("selected %q, want %q", got, "\n日本語")
+	}
+	m = send(m, frog.PasteMsg{Text: "-"})
+	if got := m.Value(); got != "ab-" {
+		t.Fatalf("after paste Value = %q, want %q", got, "ab-")
+	}
+}
+
+// Mouse columns are cells, so wide characters take two each.
+func TestMouseSelectionWideCharacters(t *testing.T) {
+	tests := []struct {
+		name       string
+		fromX, toX int // 1-based, as in MouseMsg
+		want       string
+	}{
+		{"wide", 3, 7, "本語"},
+		{"right half of wide", 4, 7, "本語"},
+		{"after wide", 8, 12, "text"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := send(New(20, 3).SetValue("日本語 text"),
+				frog.MouseMsg{Button: frog.MouseLeft, Action: frog.MousePress, X: tt.fromX, Y: 1},
+				frog.MouseMsg{Button: frog.MouseLeft, Action: frog.MouseDrag, X: tt.toX, Y: 1},
+				frog.MouseMsg{Button: frog.MouseLeft, Action: frog.MouseRelease, X: tt.toX, Y: 1},
+			)
+			if got := m.SelectedText(); got != tt.want {
+				t.Errorf("selected %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCut(t *testing.T) {
+	m := send(New(20, 3).SetValue("hello"), key(frog.KeyHome, false), key(frog.KeyRight, true), key(frog.KeyRight, true))
+	next, cmd := m.Update(frog.KeyMsg{Type: frog.KeyRune, Rune: 'x', String: "\x18", Ctrl: true})
+	if cmd == nil {
+		t.Fatal("cut returned no clipboard command")
+	}
+	if got := next.(Model).Value(); got != "llo" {
+		t.Fatalf("Value = %q, want %q", got, "llo")
+	}
+}
+
+func TestViewWidth(t *testing.T) {
+	m := New(5, 2).SetValue("日本語テキスト\nab")
+	for _, row := range strings.Split(frog.StripANSI(m.View()), "\n") {
+		if w := frog.DisplayWidth(row); w != 5 {
+			t.Errorf("row %q is %d cells wide, want 5", row, w)
+		}
+	}
+}
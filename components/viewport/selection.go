@@ -0,0 +1,163 @@
+package viewport
+
+import (
+	"strings"
+
+	"github.com/pondworks-lib/frog"
+)
+
+// Pos is a position in the content: a line index and a column (rune index
+// into the line's text without escape sequences, not a screen cell).
+type Pos struct{ Line, Col int }
+
+func (p Pos) before(q Pos) bool {
+	return p.Line < q.Line || (p.Line == q.Line && p.Col < q.Col)
+}
+
+// selection spans from anchor to cursor, in either direction.
+type selection struct {
+	anchor, cursor Pos
+	active         bool
+}
+
+// bounds returns the selection ordered start..end (end exclusive).
+func (s selection) bounds() (start, end Pos) {
+	if s.cursor.before(s.anchor) {
+		return s.cursor, s.anchor
+	}
+	return s.anchor, s.cursor
+}
+
+// Shift+arrow sequences (xterm modifier 2) and the direction they move.
+var shiftArrows = map[string]Pos{
+	"\x1b[1;2A": {Line: -1},
+	"\x1b[1;2B": {Line: 1},
+	"\x1b[1;2C": {Col: 1},
+	"\x1b[1;2D": {Col: -1},
+}
+
+// Selection returns the selection bounds and whether a selection exists.
+func (m Model) Selection() (start, end Pos, ok bool) {
+	start, end = m.sel.bounds()
+	return start, end, m.sel.active && start != end
+}
+
+// SelectedText returns the selected text without escape sequences.
+func (m Model) SelectedText() string {
+	start, end, ok := m.Selection()
+	if !ok {
+		return ""
+	}
+	var b strings.Builder
+	for n := start.Line; n <= end.Line && n < len(m.lines); n++ {
+		r := []rune(frog.StripANSI(m.lines[n]))
+		from, to := 0, len(r)
+		if n == start.Line {
+			from = min(start.Col, len(r))
+		}
+		if n == end.Line {
+			to = min(end.Col, len(r))
+		}
+		if n > start.Line {
+			b.WriteByte('\n')
+		}
+		if from < to {
+			b.WriteString(string(r[from:to]))
+		}
+	}
+	return b.String()
+}
+
+// Copy returns a command that copies the selection to the system clipboard
+// via OSC 52, or nil if nothing is selected.
+func (m Model) Copy() frog.Cmd {
+	text := m.SelectedText()
+	if text == "" {
+		return nil
+	}
+	return frog.SetClipboard(text)
+}
+
+// extendSelection moves the selection cursor by dir, starting a selection
+// at the top-left visible cell if there is none, and keeps it in view.
+func (m Model) extendSelection(dir Pos) Model {
+	if !m.sel.active {
+		p := Pos{Line: m.yOffset}
+		m.sel = selection{anchor: p, cursor: p, active: true}
+	}
+	c := m.sel.cursor
+	c.Line = clamp(c.Line+dir.Line, 0, max(len(m.lines)-1, 0))
+	c.Col += dir.Col
+	lineLen := m.lineLen(c.Line)
+	switch {
+	case c.Col < 0 && c.Line > 0 && dir.Col < 0:
+		c.Line--
+		c.Col = m.lineLen(c.Line)
+	case c.Col > lineLen && c.Line < len(m.lines)-1 && dir.Col > 0:
+		c.Line++
+		c.Col = 0
+	default:
+		c.Col = clamp(c.Col, 0, lineLen)
+	}
+	m.sel.cursor = c
+
+	if c.Line < m.yOffset {
+		m.yOffset = c.Line
+	} else if c.Line >= m.yOffset+m.height {
+		m.yOffset = c.Line - m.height + 1
+	}
+	m.yOffset = clamp(m.yOffset, 0, m.maxOffset())
+	return m
+}
+
+// posAt maps a cell inside the viewport to a content position. Columns of
+// a Pos count runes while cells count display width, so a cell on the
+// right half of a wide character maps to that character.
+func (m Model) posAt(row, col int) Pos {
+	line := clamp(m.yOffset+row, 0, max(len(m.lines)-1, 0))
+	if line >= len(m.lines) {
+		return Pos{Line: line}
+	}
+	r := []rune(frog.StripANSI(m.lines[line]))
+	w := 0
+	for i, c := range r {
+		if w += frog.DisplayWidth(string(c)); w > col {
+			return Pos{Line: line, Col: i}
+		}
+	}
+	return Pos{Line: line, Col: len(r)}
+}
+
+func (m Model) lineLen(n int) int {
+	if n < 0 || n >= len(m.lines) {
+		return 0
+	}
+	return len([]rune(frog.StripANSI(m.lines[n])))
+}
+
+// highlight renders line n with the selected columns in the selection
+// style. Selected lines are drawn without their own styling.
+func (m Model) highlight(line string, n int, start, end Pos) string {
+	r := []rune(frog.StripANSI(line))
+	from, to := 0, len(r)
+	if n == start.Line {
+		from = min(start.Col, len(r))
+	}
+	if n == end.Line {
+		to = min(end.Col, len(r))
+	}
+	if from >= to {
+		return string(r)
+	}
+	return string(r[:from]) + m.selStyle.Render(string(r[from:to])) + string(r[to:])
+}
+
+func clamp(n, lo, hi int) int {
+	if n > hi {
+		n = hi
+	}
+	if n < lo {
+		n = lo
+	}
+	return n
+}
@@ -0,0 +1,268 @@
+// Package viewport provides a scrollable view over a block of text with
+// keyboard and mouse scrolling, text selection and copy to the clipboard.
+package viewport
+
+import (
+	"strings"
+
+	"github.com/pondworks-lib/frog"
+	"github.com/pondworks-lib/frog/components/scrollbar"
+)
+
+// KeyMap names the keys the viewport handles besides the arrow, page,
+// Home/End and Shift+arrow keys. Keys are written as KeyMsg.String.
+type KeyMap struct {
+	Copy  string // copy the selection to the clipboard
+	Clear string // drop the selection
+}
+
+// DefaultKeyMap is the key map used by New.
+var DefaultKeyMap = KeyMap{Copy: "y", Clear: "\x1b"}
+
+// Bindings describes the viewport's keys for a help overlay.
+func (k KeyMap) Bindings() []frog.Binding {
+	return []frog.Binding{
+		{Keys: "↑/↓", Help: "scroll"},
+		{Keys: "pgup/pgdn", Help: "scroll a page"},
+		{Keys: "home/end", Help: "go to top/bottom"},
+		{Keys: "shift+arrows", Help: "select text"},
+		{Keys: keyLabel(k.Copy), Help: "copy selection"},
+		{Keys: keyLabel(k.Clear), Help: "clear selection"},
+	}
+}
+
+func keyLabel(s string) string {
+	if s == "\x1b" {
+		return "esc"
+	}
+	return s
+}
+
+// Model is a scrollable text viewport.
+type Model struct {
+	lines         []string
+	yOffset       int
+	width, height int
+	x, y          int // 0-based screen position, for mouse mapping
+
+	keys       KeyMap
+	wheelStep  int
+	selStyle   frog.Style
+	bar        scrollbar.Model
+	hasBar     bool
+	sel        selection
+	selecting  bool // a mouse drag is in progress
+	barDragged bool // the current drag started on the scrollbar
+}
+
+// Option configures a Model.
+type Option func(*Model)
+
+// WithKeyMap replaces the default key map.
+func WithKeyMap(k KeyMap) Option { return func(m *Model) { m.keys = k } }
+
+// WithSelectionStyle sets the highlight used for selected text (default reversed).
+func WithSelectionStyle(s frog.Style) Option { return func(m *Model) { m.selStyle = s } }
+
+// WithWheelStep sets how many lines one wheel notch scrolls (default 3).
+func WithWheelStep(n int) Option {
+	return func(m *Model) {
+		if n > 0 {
+			m.wheelStep = n
+		}
+	}
+}
+
+// WithScrollbar draws a vertical scrollbar in the rightmost column.
+func WithScrollbar(opts ...scrollbar.Option) Option {
+	return func(m *Model) {
+		m.bar, m.hasBar = scrollbar.New(opts...), true
+	}
+}
+
+// New creates a viewport of the given size.
+func New(width, height int, opts ...Option) Model {
+	m := Model{
+		width:     width,
+		height:    height,
+		keys:      DefaultKeyMap,
+		wheelStep: 3,
+		selStyle:  frog.NewStyle().Reversed(),
+	}
+	for _, o := range opts {
+		o(&m)
+	}
+	return m
+}
+
+// SetContent replaces the text shown. The selection is cleared.
+func (m Model) SetContent(s string) Model {
+	m.lines = strings.Split(s, "\n")
+	m.sel = selection{}
+	m.yOffset = clamp(m.yOffset, 0, m.maxOffset())
+	return m
+}
+
+// SetSize resizes the viewport.
+func (m Model) SetSize(width, height int) Model {
+	m.width, m.height = width, height
+	m.yOffset = clamp(m.yOffset, 0, m.maxOffset())
+	return m
+}
+
+// SetPosition records where the viewport is drawn (0-based screen
+// coordinates) so mouse events can be mapped to text.
+func (m Model) SetPosition(x, y int) Model {
+	m.x, m.y = x, y
+	return m
+}
+
+// YOffset returns the index of the first visible line.
+func (m Model) YOffset() int { return m.yOffset }
+
+// SetYOffset scrolls to line n.
+func (m Model) SetYOffset(n int) Model {
+	m.yOffset = clamp(n, 0, m.maxOffset())
+	return m
+}
+
+// AtTop reports whether the first line is visible.
+func (m Model) AtTop() bool { return m.yOffset == 0 }
+
+// AtBottom reports whether the last line is visible.
+func (m Model) AtBottom() bool { return m.yOffset >= m.maxOffset() }
+
+func (m Model) maxOffset() int { return max(len(m.lines)-m.height, 0) }
+
+// textWidth is the width available to text, excluding the scrollbar.
+func (m Model) textWidth() int {
+	if m.hasBar {
+		return max(m.width-1, 0)
+	}
+	return m.width
+}
+
+// Init implements frog.Model.
+func (m Model) Init() frog.Cmd { return nil }
+
+// KeyBindings implements frog.KeyHelper.
+func (m Model) KeyBindings() []frog.Binding { return m.keys.Bindings() }
+
+// Update handles scrolling, selection and copy.
+func (m Model) Update(msg frog.Msg) (frog.Model, frog.Cmd) {
+	switch msg := msg.(type) {
+	case frog.ResizeMsg:
+		return m.SetSize(msg.Width, msg.Height), nil
+	case frog.KeyMsg:
+		return m.key(msg)
+	case frog.MouseMsg:
+		return m.mouse(msg), nil
+	}
+	return m, nil
+}
+
+func (m Model) key(k frog.KeyMsg) (Model, frog.Cmd) {
+	if dir, ok := shiftArrows[k.String]; ok {
+		return m.extendSelection(dir), nil
+	}
+	switch k.String {
+	case m.keys.Copy:
+		return m, m.Copy()
+	case m.keys.Clear:
+		m.sel = selection{}
+		return m, nil
+	}
+	switch k.Type {
+	case frog.KeyUp:
+		m.yOffset--
+	case frog.KeyDown:
+		m.yOffset++
+	case frog.KeyPgUp:
+		m.yOffset -= m.height
+	case frog.KeyPgDn:
+		m.yOffset += m.height
+	case frog.KeyHome:
+		m.yOffset = 0
+	case frog.KeyEnd:
+		m.yOffset = m.maxOffset()
+	}
+	m.yOffset = clamp(m.yOffset, 0, m.maxOffset())
+	return m, nil
+}
+
+func (m Model) mouse(msg frog.MouseMsg) Model {
+	if m.hasBar && (m.barDragged || msg.X-1-m.x == m.textWidth()) {
+		bar, _ := m.syncBar().Update(msg)
+		m.bar = bar.(scrollbar.Model)
+		m.yOffset = m.bar.Offset()
+		m.barDragged = msg.Action == frog.MousePress || (m.barDragged && msg.Action == frog.MouseDrag)
+		return m
+	}
+
+	row, col := msg.Y-1-m.y, msg.X-1-m.x
+	switch msg.Action {
+	case frog.MouseWheel:
+		if msg.Button == frog.MouseWheelUp {
+			m.yOffset -= m.wheelStep
+		} else {
+			m.yOffset += m.wheelStep
+		}
+		m.yOffset = clamp(m.yOffset, 0, m.maxOffset())
+	case frog.MousePress:
+		if msg.Button != frog.MouseLeft || row < 0 || row >= m.height || col < 0 || col >= m.textWidth() {
+			return m
+		}
+		p := m.posAt(row, col)
+		m.sel = selection{anchor: p, cursor: p, active: true}
+		m.selecting = true
+	case frog.MouseDrag:
+		if !m.selecting {
+			return m
+		}
+		// Dragging past an edge scrolls.
+		if row < 0 {
+			m.yOffset = clamp(m.yOffset-1, 0, m.maxOffset())
+		} else if row >= m.height {
+			m.yOffset = clamp(m.yOffset+1, 0, m.maxOffset())
+		}
+		m.sel.cursor = m.posAt(clamp(row, 0, m.height-1), clamp(col, 0, m.textWidth()))
+	case frog.MouseRelease:
+		m.selecting = false
+	}
+	return m
+}
+
+// syncBar updates the scrollbar geometry from the viewport.
+func (m Model) syncBar() scrollbar.Model {
+	return m.bar.SetLength(m.height).
+		SetContent(len(m.lines), m.height, m.yOffset).
+		SetPosition(m.x+m.textWidth(), m.y)
+}
+
+// View renders the visible lines, highlighting the selection.
+func (m Model) View() string {
+	w := m.textWidth()
+	start, end := m.sel.bounds()
+	rows := make([]string, m.height)
+	for i := range rows {
+		n := m.yOffset + i
+		if n >= len(m.lines) {
+			rows[i] = strings.Repeat(" ", w)
+			continue
+		}
+		line := m.lines[n]
+		if m.sel.active && n >= start.Line && n <= end.Line {
+			line = m.highlight(line, n, start, end)
+		}
+		line = frog.Truncate(line, w)
+		if pad := w - frog.DisplayWidth(line); pad > 0 {
+			line += strings.Repeat(" ", pad)
+		}
+		rows[i] = line
+	}
+	view := strings.Join(rows, "\n")
+	if m.hasBar {
+		return scrollbar.AttachRight(view, w, m.syncBar())
+	}
+	return view
+}
@@ -0,0 +1,68 @@
+package viewport
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/pondworks-lib/frog"
+)
+
+func drag(m Model, fromX, toX int) Model {
+	for _, msg := range []frog.MouseMsg{
+		{Button: frog.MouseLeft, Action: frog.MousePress, X: fromX, Y: 1},
+		{Button: frog.MouseLeft, Action: frog.MouseDrag, X: toX, Y: 1},
+		{Button: frog.MouseLeft, Action: frog.MouseRelease, X: toX, Y: 1},
+	} {
+		next, _ := m.Update(msg)
+		m = next.(Model)
+	}
+	return m
+}
+
+// Mouse columns are cells; selections count runes, so wide characters
+// take two cells each.
+func TestMouseSelectionWideCharacters(t *testing.T) {
+	tests := []struct {
+		name       string
+		content    string
+		fromX, toX int // 1-based, as in MouseMsg
+		want       string
+	}{
+		{"ascii", "hello world", 1, 6, "hello"},
+		{"wide", "日本語 text", 3, 7, "本語"},
+		{"right half of wide", "日本語 text", 4, 7, "本語"},
+		{"after wide", "日本語 text", 8, 12, "text"},
+		{"past the end", "日本", 3, 20, "本"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := drag(New(20, 3).SetContent(tt.content), tt.fromX, tt.toX)
+			if got := m.SelectedText(); got != tt.want {
+				t.Errorf("selected %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+// Mouse events on the scrollbar column scroll through the bar.
+func TestScrollbarMouse(t *testing.T) {
+	content := strings.Repeat("line\n", 19) + "line"
+	tests := []struct {
+		name string
+		msg  frog.MouseMsg
+		want int
+	}{
+		{"page down", frog.MouseMsg{Button: frog.MouseLeft, Action: frog.MousePress, X: 10, Y: 4}, 4},
+		{"wheel", frog.MouseMsg{Button: frog.MouseWheelDown, Action: frog.MouseWheel, X: 10, Y: 2}, 3},
+		{"off the bar", frog.MouseMsg{Button: frog.MouseLeft, Action: frog.MousePress, X: 11, Y: 4}, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := New(10, 4, WithScrollbar()).SetContent(content)
+			next, _ := m.Update(tt.msg)
+			if got := next.(Model).YOffset(); got != tt.want {
+				t.Errorf("YOffset = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,218 @@
+// Package virtual renders very large lists by asking a DataSource for only
+// the items in view. Items are fetched a page at a time by commands, off
+// the Update loop, and cached; rows whose items have not arrived yet show a
+// spinner.
+package virtual
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/pondworks-lib/frog"
+)
+
+// SelectedMsg is emitted when the user presses Enter on an item.
+type SelectedMsg[T any] struct {
+	Index int
+	Item  T
+}
+
+// Model is a list over a DataSource, rendering only the visible rows.
+type Model[T any] struct {
+	win           Window[T]
+	cursor        int
+	width         int
+	render        func(item T, selected bool) string
+	wheelStep     int
+	x, y          int // 0-based screen position, for mouse mapping
+	winOpts       []WindowOption
+	selectedStyle frog.Style
+	loadingStyle  frog.Style
+	failedStyle   frog.Style
+}
+
+// Option configures a Model.
+type Option[T any] func(*Model[T])
+
+// WithRender sets how items are drawn. The default prints the item with
+// fmt's %v, reversed when selected.
+func WithRender[T any](fn func(item T, selected bool) string) Option[T] {
+	return func(m *Model[T]) { m.render = fn }
+}
+
+// WithWindow passes options to the list's Window, such as its page and
+// cache sizes.
+func WithWindow[T any](opts ...WindowOption) Option[T] {
+	return func(m *Model[T]) { m.winOpts = append(m.winOpts, opts...) }
+}
+
+// WithStyles sets the styles for the selected row, rows being loaded and
+// rows that failed to load.
+func WithStyles[T any](selected, loading, failed frog.Style) Option[T] {
+	return func(m *Model[T]) { m.selectedStyle, m.loadingStyle, m.failedStyle = selected, loading, failed }
+}
+
+// New creates a list of the given size over src. Call Init, or return its
+// command from the parent's Init, to load the first page.
+func New[T any](src DataSource[T], width, height int, opts ...Option[T]) Model[T] {
+	m := Model[T]{
+		width:         width,
+		wheelStep:     3,
+		selectedStyle: frog.NewStyle().Reversed(),
+		loadingStyle:  frog.NewStyle().Fainted(),
+		failedStyle:   frog.NewStyle().Fg(frog.ColorRed),
+	}
+	for _, o := range opts {
+		o(&m)
+	}
+	m.win = NewWindow(src, height, m.winOpts...)
+	return m
+}
+
+// Cursor returns the index of the selected item.
+func (m Model[T]) Cursor() int { return m.cursor }
+
+// Selected returns the selected item if it is loaded.
+func (m Model[T]) Selected() (T, bool) { return m.win.At(m.cursor) }
+
+// Window returns the list's window, for its count and loading state.
+func (m Model[T]) Window() Window[T] { return m.win }
+
+// SetCursor selects item i, scrolling it into view.
+func (m Model[T]) SetCursor(i int) (Model[T], frog.Cmd) {
+	m.cursor = max(min(i, m.win.count-1), 0)
+	off := m.win.offset
+	switch {
+	case m.cursor < off:
+		off = m.cursor
+	case m.cursor >= off+m.win.height:
+		off = m.cursor - m.win.height + 1
+	}
+	var cmd frog.Cmd
+	m.win, cmd = m.win.SetOffset(off)
+	return m, cmd
+}
+
+// SetSize resizes the list.
+func (m Model[T]) SetSize(width, height int) (Model[T], frog.Cmd) {
+	m.width = width
+	var cmd frog.Cmd
+	m.win, cmd = m.win.SetHeight(height)
+	return m, cmd
+}
+
+// SetPosition records where the list is drawn (0-based screen coordinates)
+// so mouse events can be mapped to rows.
+func (m Model[T]) SetPosition(x, y int) Model[T] {
+	m.x, m.y = x, y
+	return m
+}
+
+// Reload drops cached items and fetches the visible ones again, for when
+// the source changed. To reload from elsewhere, send InvalidateMsg.
+func (m Model[T]) Reload() (Model[T], frog.Cmd) {
+	var cmd frog.Cmd
+	m.win, cmd = m.win.Reload()
+	return m, cmd
+}
+
+// Init loads the first page.
+func (m Model[T]) Init() frog.Cmd { return m.win.Init() }
+
+// KeyBindings implements frog.KeyHelper.
+func (m Model[T]) KeyBindings() []frog.Binding {
+	return []frog.Binding{
+		{Keys: "↑/↓ pgup/pgdn", Help: "move"},
+		{Keys: "home/end", Help: "first/last"},
+		{Keys: "enter", Help: "select"},
+	}
+}
+
+// Update handles fetched items, InvalidateMsg, movement and selection.
+func (m Model[T]) Update(msg frog.Msg) (frog.Model, frog.Cmd) {
+	if win, cmd, ok := m.win.Update(msg); ok {
+		m.win = win
+		m.cursor = max(min(m.cursor, m.win.count-1), 0)
+		return m, cmd
+	}
+	switch msg := msg.(type) {
+	case frog.ResizeMsg:
+		return m.SetSize(msg.Width, msg.Height)
+	case frog.KeyMsg:
+		switch msg.Type {
+		case frog.KeyUp:
+			return m.SetCursor(m.cursor - 1)
+		case frog.KeyDown:
+			return m.SetCursor(m.cursor + 1)
+		case frog.KeyPgUp:
+			return m.SetCursor(m.cursor - m.win.height)
+		case frog.KeyPgDn:
+			return m.SetCursor(m.cursor + m.win.height)
+		case frog.KeyHome:
+			return m.SetCursor(0)
+		case frog.KeyEnd:
+			return m.SetCursor(m.win.count - 1)
+		case frog.KeyEnter:
+			if item, ok := m.win.At(m.cursor); ok {
+				i := m.cursor
+				return m, func() frog.Msg { return SelectedMsg[T]{Index: i, Item: item} }
+			}
+		}
+	case frog.MouseMsg:
+		row := msg.Y - 1 - m.y
+		if row < 0 || row >= m.win.height || msg.X-1 < m.x || msg.X-1 >= m.x+m.width {
+			return m, nil
+		}
+		switch {
+		case msg.Action == frog.MouseWheel && msg.Button == frog.MouseWheelUp:
+			var cmd frog.Cmd
+			m.win, cmd = m.win.SetOffset(m.win.offset - m.wheelStep)
+			return m, cmd
+		case msg.Action == frog.MouseWheel:
+			var cmd frog.Cmd
+			m.win, cmd = m.win.SetOffset(m.win.offset + m.wheelStep)
+			return m, cmd
+		case msg.Action == frog.MousePress && msg.Button == frog.MouseLeft:
+			if i := m.win.offset + row; i < m.win.count {
+				m.cursor = i
+			}
+		}
+	}
+	return m, nil
+}
+
+// View renders the visible rows.
+func (m Model[T]) View() string {
+	rows := make([]string, m.win.height)
+	for r := range rows {
+		i := m.win.offset + r
+		var line string
+		item, _ := m.win.At(i)
+		switch m.win.State(i) {
+		case RowMissing:
+		case RowLoading:
+			line = m.loadingStyle.Render(m.win.Spinner() + " loading")
+		case RowFailed:
+			line = m.failedStyle.Render("! " + m.win.Err().Error())
+		default:
+			if m.render != nil {
+				line = m.render(item, i == m.cursor)
+				break
+			}
+			line = defaultRender(item)
+			if i == m.cursor {
+				line = m.selectedStyle.Render(fit(line, m.width))
+			}
+		}
+		rows[r] = fit(line, m.width)
+	}
+	return strings.Join(rows, "\n")
+}
+
+func defaultRender(item any) string { return fmt.Sprint(item) }
+
+// fit pads or truncates s to w columns.
+func fit(s string, w int) string {
+	s = frog.Truncate(s, w)
+	return s + strings.Repeat(" ", max(w-frog.DisplayWidth(s), 0))
+}
@@ -0,0 +1,424 @@
+package virtual
+
+import (
+	"context"
+	"slices"
+	"sync/atomic"
+	"time"
+
+	"github.com/pondworks-lib/frog"
+)
+
+// DataSource supplies items by index. Count and Slice are only called from
+// commands, off the Update loop, so they may be slow.
+type DataSource[T any] interface {
+	// Count returns the number of items.
+	Count() int
+	// Slice returns items [start, end). It may return fewer items if the
+	// source shrank.
+	Slice(start, end int) []T
+}
+
+// Fetcher is implemented by data sources that can fail or need
+// cancelling, such as ones backed by SQL or HTTP. When a DataSource is also
+// a Fetcher, the window calls Fetch instead of Count and Slice.
+type Fetcher[T any] interface {
+	// Fetch returns items [start, end) and the total number of items.
+	Fetch(ctx context.Context, start, end int) (items []T, total int, err error)
+}
+
+// FetchFunc adapts a function to a DataSource and Fetcher.
+type FetchFunc[T any] func(ctx context.Context, start, end int) (items []T, total int, err error)
+
+// Fetch implements Fetcher.
+func (f FetchFunc[T]) Fetch(ctx context.Context, start, end int) ([]T, int, error) {
+	return f(ctx, start, end)
+}
+
+// Count implements DataSource, reporting 0 on error.
+func (f FetchFunc[T]) Count() int {
+	_, n, _ := f(context.Background(), 0, 0)
+	return n
+}
+
+// Slice implements DataSource, reporting no items on error.
+func (f FetchFunc[T]) Slice(start, end int) []T {
+	items, _, _ := f(context.Background(), start, end)
+	return items
+}
+
+// SliceSource is a DataSource over an in-memory slice.
+type SliceSource[T any] []T
+
+// Count implements DataSource.
+func (s SliceSource[T]) Count() int { return len(s) }
+
+// Slice implements DataSource.
+func (s SliceSource[T]) Slice(start, end int) []T {
+	start, end = min(start, len(s)), min(end, len(s))
+	return s[start:end]
+}
+
+// InvalidateMsg tells every window that items [Start, End) changed in its
+// source; an End of 0 means everything. Cached pages overlapping the range
+// are dropped and the visible ones fetched again.
+type InvalidateMsg struct{ Start, End int }
+
+// Invalidate returns a command that sends InvalidateMsg.
+func Invalidate(start, end int) frog.Cmd {
+	return func() frog.Msg { return InvalidateMsg{Start: start, End: end} }
+}
+
+// RowState says whether a row's item can be shown.
+type RowState int
+
+const (
+	RowLoaded  RowState = iota
+	RowLoading          // being fetched
+	RowFailed           // the last fetch failed; scrolling retries it
+	RowMissing          // past the end, or not requested yet
+)
+
+// lastID numbers windows so fetched pages reach the window that asked.
+var lastID atomic.Int64
+
+// spinnerFrames animate rows that are being fetched.
+var spinnerFrames = []string{"⠋", "⠙", "⠹", "⠸", "⠼", "⠴", "⠦", "⠧", "⠇", "⠏"}
+
+// spinInterval is how often a loading window advances its spinner.
+const spinInterval = 100 * time.Millisecond
+
+// pagesMsg delivers fetched pages to the window with the same id.
+type pagesMsg[T any] struct {
+	id    int64
+	epoch int
+	pages map[int][]T
+	count int
+	err   error
+}
+
+// spinMsg is sent while a fetch is still running, to advance the spinner
+// and wait again.
+type spinMsg[T any] struct {
+	id    int64
+	epoch int
+	reply <-chan pagesMsg[T]
+}
+
+// cache holds fetched pages, dropping the least recently used beyond its
+// limit.
+type cache[T any] struct {
+	pages map[int][]T
+	order []int // least recently used first
+	limit int
+}
+
+func (c *cache[T]) get(page int) ([]T, bool) {
+	p, ok := c.pages[page]
+	return p, ok
+}
+
+func (c *cache[T]) touch(page int) {
+	if i := slices.Index(c.order, page); i >= 0 {
+		c.order = append(c.order[:i], c.order[i+1:]...)
+	}
+	c.order = append(c.order, page)
+}
+
+// put stores a page, evicting old pages other than those keep reports.
+func (c *cache[T]) put(page int, items []T, keep func(int) bool) {
+	c.pages[page] = items
+	c.touch(page)
+	for i := 0; len(c.pages) > c.limit && i < len(c.order); {
+		if p := c.order[i]; !keep(p) {
+			delete(c.pages, p)
+			c.order = append(c.order[:i], c.order[i+1:]...)
+			continue
+		}
+		i++
+	}
+}
+
+func (c *cache[T]) drop(page int) {
+	delete(c.pages, page)
+	if i := slices.Index(c.order, page); i >= 0 {
+		c.order = append(c.order[:i], c.order[i+1:]...)
+	}
+}
+
+// Window tracks which part of a DataSource is visible and keeps the pages
+// under and around it loaded. It is the engine behind Model and can drive
+// other components, such as tables, that show one item per row. Copies of
+// a Window share its page cache.
+type Window[T any] struct {
+	fetch    func(ctx context.Context, start, end int) ([]T, int, error)
+	id       int64
+	count    int // -1 until the first fetch
+	offset   int // first visible item
+	height   int
+	pageSize int
+	timeout  time.Duration
+	cache    *cache[T]
+
+	epoch    int // bumped by invalidation; replies from older epochs are dropped
+	fetching bool
+	frame    int // spinner frame
+	err      error
+}
+
+// WindowOption configures a Window.
+type WindowOption func(*windowConfig)
+
+type windowConfig struct {
+	pageSize, cachePages int
+	timeout              time.Duration
+}
+
+// WithPageSize sets how many items are fetched at a time (default 100).
+func WithPageSize(n int) WindowOption {
+	return func(c *windowConfig) {
+		if n > 0 {
+			c.pageSize = n
+		}
+	}
+}
+
+// WithCacheSize sets how many pages are kept (default 20). Visible pages
+// are never dropped.
+func WithCacheSize(pages int) WindowOption {
+	return func(c *windowConfig) {
+		if pages > 0 {
+			c.cachePages = pages
+		}
+	}
+}
+
+// WithFetchTimeout bounds each fetch from a Fetcher (default 30s).
+func WithFetchTimeout(d time.Duration) WindowOption {
+	return func(c *windowConfig) {
+		if d > 0 {
+			c.timeout = d
+		}
+	}
+}
+
+// NewWindow creates a window of height rows over src. The source is not
+// read until the window's Init command runs.
+func NewWindow[T any](src DataSource[T], height int, opts ...WindowOption) Window[T] {
+	cfg := windowConfig{pageSize: 100, cachePages: 20, timeout: 30 * time.Second}
+	for _, o := range opts {
+		o(&cfg)
+	}
+	fetch := func(_ context.Context, start, end int) ([]T, int, error) {
+		n := src.Count()
+		return src.Slice(min(start, n), min(end, n)), n, nil
+	}
+	if f, ok := src.(Fetcher[T]); ok {
+		fetch = f.Fetch
+	}
+	return Window[T]{
+		fetch:    fetch,
+		id:       lastID.Add(1),
+		count:    -1,
+		height:   max(height, 0),
+		pageSize: cfg.pageSize,
+		timeout:  cfg.timeout,
+		cache:    &cache[T]{pages: map[int][]T{}, limit: cfg.cachePages},
+	}
+}
+
+// Count returns the number of items as of the last fetch, or -1 before the
+// first fetch completes.
+func (w Window[T]) Count() int { return w.count }
+
+// Offset returns the index of the first visible item.
+func (w Window[T]) Offset() int { return w.offset }
+
+// Height returns the number of visible rows.
+func (w Window[T]) Height() int { return w.height }
+
+// Loading reports whether a fetch is in flight.
+func (w Window[T]) Loading() bool { return w.fetching }
+
+// Err returns the error of the last fetch, if it failed.
+func (w Window[T]) Err() error { return w.err }
+
+// Spinner returns the current frame of the loading animation.
+func (w Window[T]) Spinner() string { return spinnerFrames[w.frame%len(spinnerFrames)] }
+
+// At returns item i if it is loaded.
+func (w Window[T]) At(i int) (item T, ok bool) {
+	if i < 0 || w.count >= 0 && i >= w.count {
+		return item, false
+	}
+	p, ok := w.cache.get(i / w.pageSize)
+	if !ok || i%w.pageSize >= len(p) {
+		return item, false
+	}
+	return p[i%w.pageSize], true
+}
+
+// State reports whether item i is loaded, loading or failed.
+func (w Window[T]) State(i int) RowState {
+	switch _, ok := w.At(i); {
+	case ok:
+		return RowLoaded
+	case i < 0 || w.count >= 0 && i >= w.count:
+		return RowMissing
+	case w.fetching:
+		return RowLoading
+	case w.err != nil:
+		return RowFailed
+	}
+	return RowMissing
+}
+
+// SetOffset scrolls so item n is the first visible, fetching pages as
+// needed.
+func (w Window[T]) SetOffset(n int) (Window[T], frog.Cmd) {
+	w.offset = w.clamp(n)
+	return w.ensure()
+}
+
+func (w Window[T]) clamp(n int) int {
+	if w.count >= 0 {
+		n = min(n, w.count-w.height)
+	}
+	return max(n, 0)
+}
+
+// SetHeight resizes the view, fetching pages as needed.
+func (w Window[T]) SetHeight(h int) (Window[T], frog.Cmd) {
+	w.height = max(h, 0)
+	return w.SetOffset(w.offset)
+}
+
+// Reload drops every cached page and fetches the visible ones again, for
+// when the source changed.
+func (w Window[T]) Reload() (Window[T], frog.Cmd) { return w.Invalidate(0, 0) }
+
+// Invalidate drops cached pages overlapping items [start, end), or all
+// pages if end is 0, and fetches the visible ones again.
+func (w Window[T]) Invalidate(start, end int) (Window[T], frog.Cmd) {
+	for page := range w.cache.pages {
+		if end == 0 || page*w.pageSize < end && (page+1)*w.pageSize > start {
+			w.cache.drop(page)
+		}
+	}
+	w.epoch++
+	w.fetching, w.err = false, nil
+	return w.ensure()
+}
+
+// Init fetches the first pages. Init cannot record the fetch, so the
+// window only learns it is loading when the first spinner frame arrives.
+func (w Window[T]) Init() frog.Cmd {
+	_, cmd := w.ensure()
+	return cmd
+}
+
+// Update handles fetched pages, the loading animation and InvalidateMsg.
+// It reports whether msg was for the window.
+func (w Window[T]) Update(msg frog.Msg) (Window[T], frog.Cmd, bool) {
+	switch msg := msg.(type) {
+	case InvalidateMsg:
+		w, cmd := w.Invalidate(msg.Start, msg.End)
+		return w, cmd, true
+	case spinMsg[T]:
+		if msg.id != w.id {
+			return w, nil, false
+		}
+		if msg.epoch != w.epoch {
+			return w, nil, true
+		}
+		w.fetching = true
+		w.frame++
+		return w, wait(msg), true
+	case pagesMsg[T]:
+		if msg.id != w.id {
+			return w, nil, false
+		}
+		if msg.epoch != w.epoch {
+			return w, nil, true
+		}
+		w.fetching, w.err = false, msg.err
+		if msg.err != nil {
+			return w, nil, true // retried on the next scroll
+		}
+		w.count = msg.count
+		vis := w.visiblePages()
+		for page, items := range msg.pages {
+			w.cache.put(page, items, func(p int) bool { return p >= vis[0] && p <= vis[1] })
+		}
+		w.offset = w.clamp(w.offset)
+		w, cmd := w.ensure()
+		return w, cmd, true
+	}
+	return w, nil, false
+}
+
+// visiblePages returns the first and last page under the view.
+func (w Window[T]) visiblePages() [2]int {
+	return [2]int{w.offset / w.pageSize, (w.offset + max(w.height, 1) - 1) / w.pageSize}
+}
+
+// ensure starts fetching the visible pages that are not cached, plus the
+// pages either side so scrolling rarely waits. One fetch runs at a time.
+func (w Window[T]) ensure() (Window[T], frog.Cmd) {
+	if w.fetching {
+		return w, nil
+	}
+	vis := w.visiblePages()
+	var missing []int
+	for page := max(vis[0]-1, 0); page <= vis[1]+1; page++ {
+		if w.count >= 0 && page*w.pageSize >= w.count {
+			break
+		}
+		if _, ok := w.cache.get(page); ok {
+			if page >= vis[0] && page <= vis[1] {
+				w.cache.touch(page)
+			}
+			continue
+		}
+		missing = append(missing, page)
+	}
+	if len(missing) == 0 {
+		return w, nil
+	}
+	w.fetching, w.frame = true, 0
+	reply := make(chan pagesMsg[T], 1)
+	fetch, size, timeout := w.fetch, w.pageSize, w.timeout
+	msg := pagesMsg[T]{id: w.id, epoch: w.epoch, pages: map[int][]T{}}
+	spin := spinMsg[T]{id: w.id, epoch: w.epoch, reply: reply}
+	return w, func() frog.Msg {
+		go func() {
+			ctx, cancel := context.WithTimeout(context.Background(), timeout)
+			defer cancel()
+			for _, page := range missing {
+				items, total, err := fetch(ctx, page*size, (page+1)*size)
+				if err != nil {
+					msg.err = err
+					break
+				}
+				msg.pages[page], msg.count = items, total
+			}
+			reply <- msg
+		}()
+		return wait(spin)()
+	}
+}
+
+// wait returns a command that delivers the fetch's reply, or a spinMsg to
+// animate the placeholders if it takes longer than spinInterval.
+func wait[T any](s spinMsg[T]) frog.Cmd {
+	return func() frog.Msg {
+		t := time.NewTimer(spinInterval)
+		defer t.Stop()
+		select {
+		case msg := <-s.reply:
+			return msg
+		case <-t.C:
+			return s
+		}
+	}
+}
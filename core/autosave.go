@@ -0,0 +1,151 @@
+package core
+
+import (
+	"math/rand/v2"
+	"time"
+)
+
+// ErrMsg reports that a command the session runs on the model's behalf,
+// such as an autosave, failed. Op names the operation.
+type ErrMsg struct {
+	Op  string
+	Err error
+}
+
+func (e ErrMsg) Error() string { return e.Op + ": " + e.Err.Error() }
+func (e ErrMsg) Unwrap() error { return e.Err }
+
+// autosaveMsg asks the session to save periodically with save.
+type autosaveMsg struct {
+	interval time.Duration
+	save     func(Model) Cmd
+}
+
+// autosaveDueMsg is sent when the autosave schedule gen comes due.
+type autosaveDueMsg struct{ gen uint64 }
+
+// autosaveDoneMsg carries the result of a save.
+type autosaveDoneMsg struct{ result Msg }
+
+// autosaveJitter is the fraction by which each autosave delay varies, so
+// instances started together do not write at the same moment.
+const autosaveJitter = 0.1
+
+// AutoSave returns a command that has the session save every interval,
+// give or take a tenth, for as long as it runs, and once more when it
+// quits. Each time, the session calls save with the current model, between
+// messages like Update, and runs the command it returns; a nil command
+// skips that save. A save that returns an error reports it to Update as an
+// ErrMsg with Op "autosave"; any other message it returns is delivered as
+// usual. Saves never overlap: one that comes due while the previous is
+// still running is skipped. The final save runs within the shutdown timeout
+// and only logs failures, as the model gets no more messages.
+//
+//	frog.AutoSave(time.Minute, func(m frog.Model) frog.Cmd {
+//		doc := m.(*editor).doc.Clone()
+//		return func() frog.Msg { return doc.Save() }
+//	})
+//
+// The command runs outside Update, so save should hand it a snapshot rather
+// than the model itself. Returning AutoSave again replaces save without
+// restarting the schedule; a zero interval or nil save stops autosaving.
+func AutoSave(interval time.Duration, save func(Model) Cmd) Cmd {
+	return func() Msg { return autosaveMsg{interval: interval, save: save} }
+}
+
+func (p *Session) setAutoSave(msg autosaveMsg) {
+	if msg.interval <= 0 || msg.save == nil {
+		p.stopAutoSave()
+		p.autosave = nil
+		return
+	}
+	p.autosave = msg.save
+	if msg.interval == p.autosaveInterval && p.autosaveStop != nil {
+		return
+	}
+	p.stopAutoSave()
+	p.autosaveInterval = msg.interval
+	p.scheduleAutoSave()
+}
+
+func (p *Session) stopAutoSave() {
+	if p.autosaveStop != nil {
+		close(p.autosaveStop)
+		p.autosaveStop = nil
+	}
+	p.autosaveInterval = 0
+}
+
+// scheduleAutoSave arms the next autosave.
+func (p *Session) scheduleAutoSave() {
+	if p.autosaveStop == nil {
+		p.autosaveStop = make(chan struct{})
+	}
+	p.autosaveGen++
+	due := autosaveDueMsg{gen: p.autosaveGen}
+	jitter := (rand.Float64()*2 - 1) * autosaveJitter
+	d := p.autosaveInterval + time.Duration(jitter*float64(p.autosaveInterval))
+	p.deliverAfter(max(d, time.Millisecond), p.autosaveStop, func() Msg { return due })
+}
+
+// autosaveDue starts a save unless one is running and arms the next.
+func (p *Session) autosaveDue(msg autosaveDueMsg) {
+	if msg.gen != p.autosaveGen || p.autosave == nil {
+		return // superseded schedule
+	}
+	if !p.autosaving {
+		if save := p.autosave(p.m); save != nil {
+			p.autosaving = true
+			p.exec(func() Msg { return autosaveDoneMsg{result: save()} })
+		}
+	}
+	p.scheduleAutoSave()
+}
+
+// autosaveDone delivers the result of a save to the model.
+func (p *Session) autosaveDone(msg autosaveDoneMsg) {
+	p.autosaving = false
+	result := msg.result
+	if err, ok := result.(error); ok {
+		if _, isErrMsg := result.(ErrMsg); !isErrMsg {
+			result = ErrMsg{Op: "autosave", Err: err}
+		}
+	}
+	if result == nil {
+		return
+	}
+	cmd := p.update(result)
+	p.render()
+	p.exec(cmd)
+}
+
+// finalAutoSave runs the autosave once more as the session stops.
+func (p *Session) finalAutoSave() {
+	if p.autosave == nil {
+		return
+	}
+	p.stopAutoSave()
+	save, m := p.autosave, p.m
+	done := make(chan Msg, 1)
+	go func() {
+		defer func() {
+			if r := recover(); r != nil {
+				p.logger.Errorf("autosave panic: %v", r)
+				done <- nil
+			}
+		}()
+		var result Msg
+		if cmd := save(m); cmd != nil {
+			result = cmd()
+		}
+		done <- result
+	}()
+	select {
+	case result := <-done:
+		if err, ok := result.(error); ok {
+			p.logger.Errorf("autosave: %v", err)
+		}
+	case <-time.After(p.shutdownTimeout):
+		p.logger.Warnf("autosave did not finish within %v", p.shutdownTimeout)
+	}
+}
@@ -0,0 +1,39 @@
+package core
+
+import (
+	"testing"
+	"time"
+)
+
+// countModel counts the keys it gets and quits after three.
+type countModel struct {
+	n    int
+	save func(Model) Cmd
+}
+
+func (m countModel) Init() Cmd { return AutoSave(time.Hour, m.save) }
+
+func (m countModel) Update(msg Msg) (Model, Cmd) {
+	if _, ok := msg.(KeyMsg); ok {
+		if m.n++; m.n == 3 {
+			return m, Quit()
+		}
+	}
+	return m, nil
+}
+
+func (m countModel) View() string { return "" }
+
+// The final save sees the model as it is when the session quits, not as it
+// was when AutoSave was returned.
+func TestFinalAutoSaveUsesCurrentModel(t *testing.T) {
+	saved := -1
+	m := countModel{save: func(m Model) Cmd {
+		n := m.(countModel).n
+		return func() Msg { saved = n; return nil }
+	}}
+	runSession(t, m, "abc")
+	if saved != 3 {
+		t.Fatalf("saved count %d, want 3", saved)
+	}
+}
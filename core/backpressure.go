@@ -0,0 +1,33 @@
+package core
+
+// WithFrameSkipping keeps a slow output, such as a terminal over a poor
+// network link, from holding up input. While a frame is being written,
+// messages queue up; with frame skipping, the session updates the model
+// with all of them and draws only the state after the last, rather than
+// a frame for each. Outputs that refuse writes when full drop frames the
+// same way (see WithFrameDrop). Script output still records every frame.
+func WithFrameSkipping() Option { return func(p *Session) { p.frameSkip = true } }
+
+// deferFrame reports whether the frame after msg is left for later,
+// because more messages are waiting or the FPS cap does not allow it yet.
+// The loop draws the owed frame as soon as the queue runs dry and the cap
+// allows, so the screen always ends on the latest state.
+func (p *Session) deferFrame(msg Msg) bool {
+	if p.script != nil {
+		return false
+	}
+	switch msg.(type) {
+	case QuitMsg, InterruptMsg:
+		return false // the loop may end here
+	}
+	if p.fpsWait() > 0 {
+		p.frameOwed = true
+		p.armFPS()
+		return true
+	}
+	if p.frameSkip && len(p.msgCh) > 0 {
+		p.frameOwed = true
+		return true
+	}
+	return false
+}
@@ -0,0 +1,146 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// Synthetic views are benchLines lines of benchWidth columns. Compare runs
+// with benchstat:
+//
+//	go test ./core -run '^$' -bench . -count 10 > new.txt
+//	benchstat old.txt new.txt
+const (
+	benchLines = 10000
+	benchWidth = 120
+)
+
+// byteCounter discards output but counts bytes, like a terminal would receive.
+type byteCounter struct{ n int64 }
+
+func (w *byteCounter) Write(p []byte) (int, error) {
+	w.n += int64(len(p))
+	return len(p), nil
+}
+
+func plainView(lines, width, frame int) string {
+	b := NewViewBuilder()
+	defer b.Release()
+	line := strings.Repeat("x", width-12)
+	for i := 0; i < lines; i++ {
+		b.Line(fmt.Sprintf("%6d %4d ", i, frame), line)
+	}
+	return b.String()
+}
+
+func colorView(lines, width, frame int) string {
+	styles := []Style{
+		NewStyle().Fg(ColorRed).Bolded(),
+		NewStyle().Fg(RGB(40, 200, 120)).Bg(ANSI256(236)),
+		NewStyle().Fg(ANSI256(208)).Underlined(),
+	}
+	b := NewViewBuilder()
+	defer b.Release()
+	for i := 0; i < lines; i++ {
+		b.Line()
+		for c := 0; c+10 <= width; c += 10 {
+			b.WriteStyled(styles[(i+c+frame)%len(styles)], "cell-text ")
+		}
+	}
+	return b.String()
+}
+
+func BenchmarkRenderFull(b *testing.B) {
+	frames := [2]string{plainView(benchLines, benchWidth, 0), plainView(benchLines, benchWidth, 1)}
+	w := &byteCounter{}
+	r := NewRenderer(w, WithDiff(false), WithColorProfile(ColorTrueColor))
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		r.Render(frames[i%2])
+	}
+	b.ReportMetric(float64(w.n)/float64(b.N), "written/op")
+}
+
+func BenchmarkRenderDiff(b *testing.B) {
+	// Frames differ in a single line, the common case for dashboards.
+	base := strings.Split(plainView(benchLines, benchWidth, 0), "\n")
+	frames := make([]string, 8)
+	for f := range frames {
+		lines := append([]string(nil), base...)
+		lines[(f*benchLines)/len(frames)] = fmt.Sprintf("changed line in frame %d", f)
+		frames[f] = strings.Join(lines, "\n")
+	}
+	w := &byteCounter{}
+	r := NewRenderer(w, WithColorProfile(ColorTrueColor))
+	r.Render(frames[0])
+	w.n = 0
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		r.Render(frames[(i+1)%len(frames)])
+	}
+	b.ReportMetric(float64(w.n)/float64(b.N), "written/op")
+}
+
+func BenchmarkRenderColor(b *testing.B) {
+	frames := [2]string{colorView(benchLines, benchWidth, 0), colorView(benchLines, benchWidth, 1)}
+	w := &byteCounter{}
+	r := NewRenderer(w, WithColorProfile(ColorTrueColor))
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		r.Render(frames[i%2])
+	}
+	b.ReportMetric(float64(w.n)/float64(b.N), "written/op")
+}
+
+func BenchmarkStyleRender(b *testing.B) {
+	st := NewStyle().Fg(RGB(200, 100, 50)).Bg(ANSI256(17)).Bolded().Underlined()
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_ = st.Render("table cell")
+	}
+}
+
+func BenchmarkStyled(b *testing.B) {
+	prefix := NewStyle().Fg(RGB(200, 100, 50)).Bg(ANSI256(17)).Bolded().Prefix()
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_ = Styled(prefix, "table cell")
+	}
+}
+
+func BenchmarkCenter(b *testing.B) {
+	block := colorView(40, 60, 0)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_ = Center(block, benchWidth, 50)
+	}
+}
+
+func BenchmarkInputDecode(b *testing.B) {
+	// Mix of runes, UTF-8, arrows, SGR mouse and a bracketed paste.
+	chunk := "hello wörld\x1b[A\x1b[B\x1b[3~\x1b[<0;10;5M\x1b[<0;10;5m" +
+		"\x1b[200~pasted text\x1b[201~"
+	input := strings.Repeat(chunk, 256)
+	b.ReportAllocs()
+	b.SetBytes(int64(len(input)))
+	var msgs int
+	for i := 0; i < b.N; i++ {
+		ch := make(chan Msg, 64)
+		done := make(chan struct{})
+		go func() {
+			for range ch {
+				msgs++
+			}
+			close(done)
+		}()
+		newInput(strings.NewReader(input)).readKeys(context.Background(), ch)
+		close(ch)
+		<-done
+	}
+	b.ReportMetric(float64(msgs)/float64(b.N), "msgs/op")
+}
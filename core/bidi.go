@@ -0,0 +1,72 @@
+package core
+
+import (
+	"strings"
+
+	"github.com/pondworks-lib/frog/core/text"
+)
+
+// Reorder converts s from logical to display order for right-to-left text
+// (Arabic, Hebrew), line by line, per the implicit rules of UAX #9. Each
+// line's base direction comes from its first strong character. Escape
+// sequences stay attached to the text they style. Lines without RTL text
+// are returned unchanged.
+func Reorder(s string) string {
+	if !text.HasRTL(s) {
+		return s
+	}
+	lines := strings.Split(s, "\n")
+	for i, line := range lines {
+		lines[i] = reorderLine(line)
+	}
+	return strings.Join(lines, "\n")
+}
+
+func reorderLine(line string) string {
+	if !text.HasRTL(line) {
+		return line
+	}
+	if escapeIndex(line) < 0 {
+		return text.Reorder(line, text.DirAuto)
+	}
+
+	// Split into clusters, remembering the escape sequences in effect for
+	// each so styling can be re-emitted in display order.
+	var clusters, states []string
+	var state strings.Builder
+	for i := 0; i < len(line); {
+		if n := ansiLen(line, i); n > 0 {
+			if seq := line[i : i+n]; seq == sgrReset || seq == "\x1b[m" {
+				state.Reset()
+			} else {
+				state.WriteString(seq)
+			}
+			i += n
+			continue
+		}
+		size, _ := nextCell(line[i:], 0)
+		clusters = append(clusters, line[i:i+size])
+		states = append(states, state.String())
+		i += size
+	}
+
+	levels := text.ResolveLevels(clusters, text.DirAuto)
+	var b strings.Builder
+	cur := ""
+	for _, i := range text.VisualOrder(levels) {
+		if states[i] != cur {
+			b.WriteString(sgrReset)
+			b.WriteString(states[i])
+			cur = states[i]
+		}
+		c := clusters[i]
+		if levels[i]%2 == 1 {
+			c = text.Mirror(c)
+		}
+		b.WriteString(c)
+	}
+	if cur != "" {
+		b.WriteString(sgrReset)
+	}
+	return b.String()
+}
@@ -0,0 +1,105 @@
+package core
+
+import (
+	"reflect"
+	"slices"
+)
+
+// EventMsg carries an event published with Publish to one subscriber, the
+// one that subscribed as To. Composite models forward it to their children
+// like any other message, and each component picks out its own with
+// EventFor, so a list can tell a detail pane about a new selection without
+// the parent translating between them:
+//
+//	// list
+//	return m, frog.Publish(Selected{Item: it})
+//
+//	// detail pane
+//	func (m Detail) Init() frog.Cmd { return frog.Subscribe[Selected]("detail") }
+//	...
+//	if ev, ok := frog.EventFor[Selected](msg, "detail"); ok {
+//		m.item = ev.Item
+//	}
+type EventMsg struct {
+	To    string
+	Event any
+}
+
+// EventFor returns msg's event when msg is an EventMsg for the subscriber
+// id carrying a T.
+func EventFor[T any](msg Msg, id string) (T, bool) {
+	var zero T
+	em, ok := msg.(EventMsg)
+	if !ok || em.To != id {
+		return zero, false
+	}
+	ev, ok := em.Event.(T)
+	return ev, ok
+}
+
+// busSub is one subscription: the subscriber id and the event type, which
+// may be an interface to receive every event implementing it.
+type busSub struct {
+	id  string
+	typ reflect.Type
+}
+
+// busSubMsg asks the session to add or remove a subscription.
+type busSubMsg struct {
+	sub busSub
+	on  bool
+}
+
+// busPublishMsg asks the session to deliver an event to its subscribers.
+type busPublishMsg struct{ event any }
+
+// Subscribe returns a command that subscribes id to events of type T,
+// delivered as EventMsg{To: id}. T may be an interface, matching every
+// event that implements it. Subscribing twice has no further effect.
+func Subscribe[T any](id string) Cmd {
+	sub := busSub{id: id, typ: reflect.TypeFor[T]()}
+	return func() Msg { return busSubMsg{sub: sub, on: true} }
+}
+
+// Unsubscribe returns a command that cancels id's subscription to T.
+func Unsubscribe[T any](id string) Cmd {
+	sub := busSub{id: id, typ: reflect.TypeFor[T]()}
+	return func() Msg { return busSubMsg{sub: sub} }
+}
+
+// Publish returns a command that delivers event to each subscriber of its
+// type, in the order they subscribed. An event nobody subscribes to is
+// dropped.
+func Publish(event any) Cmd {
+	return func() Msg { return busPublishMsg{event: event} }
+}
+
+func (p *Session) handleSub(msg busSubMsg) {
+	i := slices.Index(p.subs, msg.sub)
+	switch {
+	case msg.on && i < 0:
+		p.subs = append(p.subs, msg.sub)
+	case !msg.on && i >= 0:
+		p.subs = slices.Delete(p.subs, i, i+1)
+	}
+}
+
+// publish updates the model with the event once per subscriber, then draws
+// a frame.
+func (p *Session) publish(msg busPublishMsg) {
+	if msg.event == nil {
+		return
+	}
+	t := reflect.TypeOf(msg.event)
+	delivered := false
+	for _, s := range slices.Clone(p.subs) {
+		if t != s.typ && (s.typ.Kind() != reflect.Interface || !t.Implements(s.typ)) {
+			continue
+		}
+		p.exec(p.update(EventMsg{To: s.id, Event: msg.event}))
+		delivered = true
+	}
+	if delivered {
+		p.render()
+	}
+}
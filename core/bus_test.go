@@ -0,0 +1,78 @@
+package core
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+	"testing"
+)
+
+type busEvent struct{ n int }
+
+func (e busEvent) String() string { return fmt.Sprint(e.n) }
+
+func TestHandleSub(t *testing.T) {
+	a := busSub{id: "a", typ: reflect.TypeFor[busEvent]()}
+	b := busSub{id: "b", typ: reflect.TypeFor[busEvent]()}
+	s := busSub{id: "a", typ: reflect.TypeFor[fmt.Stringer]()}
+	tests := []struct {
+		name string
+		msgs []busSubMsg
+		want []busSub
+	}{
+		{"subscribe", []busSubMsg{{a, true}, {b, true}}, []busSub{a, b}},
+		{"twice", []busSubMsg{{a, true}, {a, true}}, []busSub{a}},
+		{"types apart", []busSubMsg{{a, true}, {s, true}}, []busSub{a, s}},
+		{"unsubscribe", []busSubMsg{{a, true}, {b, true}, {a, false}}, []busSub{b}},
+		{"unsubscribe unknown", []busSubMsg{{a, true}, {s, false}}, []busSub{a}},
+		{"resubscribe moves last", []busSubMsg{{a, true}, {b, true}, {a, false}, {a, true}}, []busSub{b, a}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := &Session{}
+			for _, msg := range tt.msgs {
+				p.handleSub(msg)
+			}
+			if !slices.Equal(p.subs, tt.want) {
+				t.Errorf("subs = %v, want %v", p.subs, tt.want)
+			}
+		})
+	}
+}
+
+func TestPublish(t *testing.T) {
+	tests := []struct {
+		name  string
+		subs  []Cmd
+		event any
+		want  []string // "id:event"
+	}{
+		{"no subscribers", nil, busEvent{1}, nil},
+		{"by type", []Cmd{Subscribe[busEvent]("a"), Subscribe[int]("b")}, busEvent{1}, []string{"a:1"}},
+		{"in order", []Cmd{Subscribe[busEvent]("b"), Subscribe[busEvent]("a")}, busEvent{2}, []string{"b:2", "a:2"}},
+		{"by interface", []Cmd{Subscribe[fmt.Stringer]("s")}, busEvent{3}, []string{"s:3"}},
+		{"not implemented", []Cmd{Subscribe[fmt.Stringer]("s")}, 4, nil},
+		{"unsubscribed", []Cmd{Subscribe[busEvent]("a"), Unsubscribe[busEvent]("a")}, busEvent{5}, nil},
+		{"nil event", []Cmd{Subscribe[busEvent]("a")}, nil, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			m := funcModel{
+				init: func() Cmd {
+					return Sequence(append(tt.subs, Publish(tt.event), Quit())...)
+				},
+				update: func(msg Msg) Cmd {
+					if em, ok := msg.(EventMsg); ok {
+						got = append(got, fmt.Sprintf("%s:%v", em.To, em.Event))
+					}
+					return nil
+				},
+			}
+			runSession(t, m, "")
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("delivered %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,108 @@
+package core
+
+import (
+	"context"
+	"io"
+	"sync"
+	"time"
+)
+
+// byteSource reads from an io.Reader on a background goroutine so the input
+// decoder can wait for the rest of a sequence with a timeout instead of
+// blocking forever or giving up on bytes split across reads. Reading ends
+// with ctx or stop; see stop.
+type byteSource struct {
+	ctx      context.Context
+	chunks   <-chan []byte
+	in       cancelReader
+	stopped  chan struct{} // closed by stop
+	done     chan struct{} // closed when the reading goroutine returns
+	stopOnce sync.Once
+}
+
+func newByteSource(ctx context.Context, r io.Reader) *byteSource {
+	ch := make(chan []byte, 4)
+	s := &byteSource{
+		ctx:     ctx,
+		chunks:  ch,
+		in:      newCancelReader(r),
+		stopped: make(chan struct{}),
+		done:    make(chan struct{}),
+	}
+	go func() {
+		defer close(s.done)
+		defer close(ch)
+		for {
+			b := make([]byte, 4096)
+			n, err := s.in.Read(b)
+			if n > 0 {
+				select {
+				case ch <- b[:n]:
+				case <-s.stopped:
+					return
+				}
+			}
+			if err != nil {
+				return
+			}
+		}
+	}()
+	go func() {
+		select {
+		case <-ctx.Done():
+			s.stop()
+		case <-s.done:
+		}
+	}()
+	return s
+}
+
+// stop ends reading. A cancelable reader returns without taking more input,
+// and stop waits for it, so nothing reads the terminal after the session;
+// a plain reader's blocked read is left behind.
+func (s *byteSource) stop() {
+	s.stopOnce.Do(func() {
+		close(s.stopped)
+		s.in.Cancel()
+		if _, plain := s.in.(*plainCancelReader); !plain {
+			<-s.done
+			s.in.Close()
+		}
+	})
+}
+
+// next blocks for the next chunk. It reports false once the reader is done
+// or ctx is.
+func (s *byteSource) next() ([]byte, bool) {
+	select {
+	case chunk, ok := <-s.chunks:
+		return chunk, ok
+	case <-s.ctx.Done():
+		return nil, false
+	}
+}
+
+// nextTimeout waits at most d for the next chunk; d <= 0 only takes a chunk
+// that is already available. closed reports that the reader is done.
+func (s *byteSource) nextTimeout(d time.Duration) (chunk []byte, ok, closed bool) {
+	if d <= 0 {
+		select {
+		case chunk, ok = <-s.chunks:
+			return chunk, ok, !ok
+		case <-s.ctx.Done():
+			return nil, false, true
+		default:
+			return nil, false, false
+		}
+	}
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case chunk, ok = <-s.chunks:
+		return chunk, ok, !ok
+	case <-s.ctx.Done():
+		return nil, false, true
+	case <-t.C:
+		return nil, false, false
+	}
+}
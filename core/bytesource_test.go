@@ -0,0 +1,40 @@
+//go:build linux || darwin || freebsd || netbsd || openbsd || dragonfly
+
+package core
+
+import (
+	"io"
+	"os"
+	"testing"
+	"time"
+)
+
+// After a session ends, input goes to whoever reads the terminal next.
+func TestInputNotReadAfterSession(t *testing.T) {
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+	defer w.Close()
+	p := NewSession(funcModel{init: Quit},
+		WithTerminal(fakeTerminal{80, 24}), WithIn(r), WithOut(io.Discard), WithoutSignalHandler())
+	if err := p.Run(); err != nil {
+		t.Fatal(err)
+	}
+	w.Write([]byte("x"))
+	got := make(chan string, 1)
+	go func() {
+		buf := make([]byte, 8)
+		n, _ := r.Read(buf)
+		got <- string(buf[:n])
+	}()
+	select {
+	case s := <-got:
+		if s != "x" {
+			t.Fatalf("read %q after the session, want x", s)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("input typed after the session was taken by its reader")
+	}
+}
@@ -0,0 +1,60 @@
+package core
+
+import (
+	"errors"
+	"io"
+	"os"
+	"sync"
+)
+
+// errCanceled is returned by a cancelReader's Read after Cancel.
+var errCanceled = errors.New("frog: read canceled")
+
+// cancelReader is an input reader whose pending Read can be abandoned.
+type cancelReader interface {
+	io.Reader
+	// Cancel makes a pending Read, and every later one, return errCanceled.
+	// Where the reader supports it, a pending Read returns without having
+	// consumed anything, so unread input stays with the terminal.
+	Cancel()
+	// Close releases the reader's resources once no Read is pending. It
+	// does not close the underlying reader.
+	Close() error
+}
+
+// newCancelReader wraps r. Files are waited on with the system's readiness
+// API where there is one; on other readers, and elsewhere, Cancel cannot
+// interrupt a Read already blocked and only stops later ones.
+func newCancelReader(r io.Reader) cancelReader {
+	if f, ok := r.(*os.File); ok {
+		if cr, err := newFileCancelReader(f); err == nil {
+			return cr
+		}
+	}
+	return &plainCancelReader{r: r}
+}
+
+// plainCancelReader is the fallback cancelReader.
+type plainCancelReader struct {
+	r        io.Reader
+	mu       sync.Mutex
+	canceled bool
+}
+
+func (c *plainCancelReader) Read(p []byte) (int, error) {
+	c.mu.Lock()
+	canceled := c.canceled
+	c.mu.Unlock()
+	if canceled {
+		return 0, errCanceled
+	}
+	return c.r.Read(p)
+}
+
+func (c *plainCancelReader) Cancel() {
+	c.mu.Lock()
+	c.canceled = true
+	c.mu.Unlock()
+}
+
+func (c *plainCancelReader) Close() error { return nil }
@@ -0,0 +1,14 @@
+//go:build !(linux || darwin || freebsd || netbsd || openbsd || dragonfly)
+
+package core
+
+import (
+	"errors"
+	"os"
+)
+
+// newFileCancelReader is not available here: there is no way to wait for
+// console input without reading it, so files get the plain fallback.
+func newFileCancelReader(*os.File) (cancelReader, error) {
+	return nil, errors.ErrUnsupported
+}
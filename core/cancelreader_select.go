@@ -0,0 +1,81 @@
+//go:build linux || darwin || freebsd || netbsd || openbsd || dragonfly
+
+package core
+
+import (
+	"errors"
+	"os"
+	"sync"
+
+	"golang.org/x/sys/unix"
+)
+
+// selectReader waits for input with select(2) on the file and a wake pipe,
+// so Cancel returns from a pending Read before it takes any bytes. select
+// rather than poll or kqueue, which do not work on terminals on macOS.
+type selectReader struct {
+	f        *os.File
+	fd       int
+	wakeR    *os.File
+	wakeW    *os.File
+	mu       sync.Mutex
+	canceled bool
+}
+
+func newFileCancelReader(f *os.File) (cancelReader, error) {
+	fd := int(f.Fd())
+	if fd >= unix.FD_SETSIZE {
+		return nil, errors.New("descriptor too large for select")
+	}
+	r, w, err := os.Pipe()
+	if err != nil {
+		return nil, err
+	}
+	if int(r.Fd()) >= unix.FD_SETSIZE {
+		r.Close()
+		w.Close()
+		return nil, errors.New("descriptor too large for select")
+	}
+	return &selectReader{f: f, fd: fd, wakeR: r, wakeW: w}, nil
+}
+
+func (c *selectReader) Read(p []byte) (int, error) {
+	wake := int(c.wakeR.Fd())
+	for {
+		c.mu.Lock()
+		canceled := c.canceled
+		c.mu.Unlock()
+		if canceled {
+			return 0, errCanceled
+		}
+		var set unix.FdSet
+		set.Set(c.fd)
+		set.Set(wake)
+		if _, err := unix.Select(max(c.fd, wake)+1, &set, nil, nil, nil); err != nil {
+			if errors.Is(err, unix.EINTR) {
+				continue
+			}
+			return 0, err
+		}
+		if set.IsSet(wake) {
+			return 0, errCanceled
+		}
+		if set.IsSet(c.fd) {
+			return c.f.Read(p)
+		}
+	}
+}
+
+func (c *selectReader) Cancel() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if !c.canceled {
+		c.canceled = true
+		c.wakeW.Write([]byte{0})
+	}
+}
+
+func (c *selectReader) Close() error {
+	c.wakeW.Close()
+	return c.wakeR.Close()
+}
@@ -0,0 +1,53 @@
+//go:build linux || darwin || freebsd || netbsd || openbsd || dragonfly
+
+package core
+
+import (
+	"errors"
+	"io"
+	"os"
+	"testing"
+	"time"
+)
+
+func TestSelectReaderCancelLeavesInput(t *testing.T) {
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+	defer w.Close()
+
+	cr := newCancelReader(r)
+	if _, ok := cr.(*selectReader); !ok {
+		t.Fatalf("newCancelReader(*os.File) = %T, want *selectReader", cr)
+	}
+	w.Write([]byte("a"))
+	buf := make([]byte, 8)
+	if n, err := cr.Read(buf); err != nil || string(buf[:n]) != "a" {
+		t.Fatalf("Read = %q, %v", buf[:n], err)
+	}
+
+	done := make(chan error, 1)
+	go func() {
+		_, err := cr.Read(buf)
+		done <- err
+	}()
+	time.Sleep(10 * time.Millisecond) // let Read block
+	cr.Cancel()
+	select {
+	case err := <-done:
+		if !errors.Is(err, errCanceled) {
+			t.Fatalf("Read after Cancel = %v, want errCanceled", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Cancel did not interrupt Read")
+	}
+	cr.Close()
+
+	// Input arriving later is left for the next reader.
+	w.Write([]byte("b"))
+	if n, err := io.ReadAtLeast(r, buf, 1); err != nil || string(buf[:n]) != "b" {
+		t.Fatalf("next reader got %q, %v; want b", buf[:n], err)
+	}
+}
@@ -0,0 +1,175 @@
+package core
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Caps describes what the current terminal supports.
+type Caps struct {
+	Term           string
+	TTY            bool
+	Width, Height  int
+	ColorProfile   ColorProfile
+	Mouse          bool
+	BracketedPaste bool
+	KittyKeyboard  bool
+	SyncOutput     bool
+	Sixel          bool
+	Probed         bool // true if refined by querying the terminal
+}
+
+// Capabilities guesses the terminal's capabilities from the environment
+// without any terminal I/O. Use ProbeCapabilities for an accurate answer.
+func Capabilities() Caps {
+	return capabilitiesOf(newFileTerminal(os.Stdin, os.Stdout), os.Stdout)
+}
+
+// capabilitiesOf is Capabilities for the terminal t writing to out.
+func capabilitiesOf(t Terminal, out io.Writer) Caps {
+	termName := os.Getenv("TERM")
+	prog := os.Getenv("TERM_PROGRAM")
+	c := Caps{
+		Term:         termName,
+		TTY:          t.IsTerminal(),
+		ColorProfile: detectColorProfile(out),
+	}
+	if c.TTY {
+		c.Width, c.Height, _ = t.Size()
+	}
+
+	dumb := termName == "" || termName == "dumb"
+	console := termName == "linux" || strings.HasPrefix(termName, "vt")
+	c.Mouse = c.TTY && !dumb && !console
+	c.BracketedPaste = c.TTY && !dumb && !console
+
+	kitty := termName == "xterm-kitty" || os.Getenv("KITTY_WINDOW_ID") != ""
+	wez := prog == "WezTerm"
+	ghostty := prog == "ghostty" || termName == "xterm-ghostty"
+	foot := strings.HasPrefix(termName, "foot")
+	c.KittyKeyboard = c.TTY && (kitty || wez || ghostty || foot)
+	c.SyncOutput = c.TTY && (kitty || wez || ghostty || foot || prog == "iTerm.app" ||
+		strings.HasPrefix(termName, "contour") || os.Getenv("WT_SESSION") != "")
+	c.Sixel = c.TTY && (wez || foot || strings.HasPrefix(termName, "mlterm") ||
+		strings.HasPrefix(termName, "contour") || strings.Contains(termName, "sixel"))
+	return c
+}
+
+// ProbeCapabilities refines Capabilities by querying the terminal on
+// stdin/stdout: kitty keyboard flags, DEC private modes 2026 (synchronized
+// output), 2004 (bracketed paste) and 1006 (SGR mouse), and primary device
+// attributes (sixel). It must run before a Session takes over the terminal.
+// If the terminal does not answer within timeout, the heuristic result is
+// returned and reading stops, leaving later input to the session. On
+// systems where a console read cannot be interrupted (Windows, Plan 9) the
+// pending read is abandoned instead and may take the first key typed.
+func ProbeCapabilities(timeout time.Duration) Caps {
+	c := Capabilities()
+	if !c.TTY || !IsTerminal(os.Stdin) {
+		return c
+	}
+	restore, err := makeRawFd(int(os.Stdin.Fd()))
+	if err != nil {
+		return c
+	}
+	defer restore()
+
+	// DA1 goes last: every terminal answers it, so its reply ends the probe.
+	os.Stdout.WriteString("\x1b[?u\x1b[?2026$p\x1b[?2004$p\x1b[?1006$p\x1b[c")
+
+	in := newCancelReader(os.Stdin)
+	replies := make(chan []byte, 1)
+	go func() {
+		var buf []byte
+		tmp := make([]byte, 256)
+		for {
+			n, err := in.Read(tmp)
+			buf = append(buf, tmp[:n]...)
+			if err != nil || hasDA1(buf) {
+				replies <- buf
+				return
+			}
+		}
+	}()
+
+	select {
+	case buf := <-replies:
+		c.applyReplies(buf)
+		c.Probed = true
+		in.Close()
+	case <-time.After(timeout):
+		// Stop reading so the keys typed next reach the session. A plain
+		// reader cannot be interrupted; its read is left behind.
+		in.Cancel()
+		if _, plain := in.(*plainCancelReader); !plain {
+			<-replies
+			in.Close()
+		}
+	}
+	return c
+}
+
+// hasDA1 reports whether buf contains a primary device attributes reply
+// (ESC [ ? ... c).
+func hasDA1(buf []byte) bool {
+	i := bytes.Index(buf, []byte("\x1b[?"))
+	for i >= 0 {
+		rest := buf[i+3:]
+		j := 0
+		for j < len(rest) && (rest[j] == ';' || (rest[j] >= '0' && rest[j] <= '9')) {
+			j++
+		}
+		if j < len(rest) && rest[j] == 'c' {
+			return true
+		}
+		k := bytes.Index(rest, []byte("\x1b[?"))
+		if k < 0 {
+			return false
+		}
+		i += 3 + k
+	}
+	return false
+}
+
+// applyReplies updates c from the terminal's answers to the probe queries.
+func (c *Caps) applyReplies(buf []byte) {
+	for _, seq := range bytes.Split(buf, []byte("\x1b[?"))[1:] {
+		end := 0
+		for end < len(seq) && (seq[end] == ';' || seq[end] == '$' || (seq[end] >= '0' && seq[end] <= '9')) {
+			end++
+		}
+		if end >= len(seq) {
+			continue
+		}
+		params := string(seq[:end])
+		switch seq[end] {
+		case 'u': // kitty keyboard flags
+			c.KittyKeyboard = true
+		case 'y': // DECRPM: <mode>;<state>$
+			mode, state, ok := strings.Cut(strings.TrimSuffix(params, "$"), ";")
+			if !ok {
+				continue
+			}
+			on := state == "1" || state == "2" || state == "3"
+			switch mode {
+			case "2026":
+				c.SyncOutput = on
+			case "2004":
+				c.BracketedPaste = on
+			case "1006":
+				c.Mouse = on
+			}
+		case 'c': // DA1: attribute 4 means sixel graphics
+			c.Sixel = false
+			for _, p := range strings.Split(params, ";") {
+				if n, err := strconv.Atoi(p); err == nil && n == 4 {
+					c.Sixel = true
+				}
+			}
+		}
+	}
+}
@@ -27,15 +27,74 @@ func Batch(cmds ...Cmd) Cmd {
 	}
 }
 
-// Tick emits a TickMsg after d (min 1ms).
+// BatchAll runs commands concurrently and delivers every message they
+// produce, in no particular order. Use it to combine the commands of
+// several children, where Batch would drop all but the first result.
+func BatchAll(cmds ...Cmd) Cmd {
+	var live []Cmd
+	for _, c := range cmds {
+		if c != nil {
+			live = append(live, c)
+		}
+	}
+	switch len(live) {
+	case 0:
+		return nil
+	case 1:
+		return live[0]
+	}
+	return func() Msg { return batchMsg{cmds: live} }
+}
+
+// batchMsg asks the session to run each command.
+type batchMsg struct{ cmds []Cmd }
+
+// Sequence runs commands one after another, delivering each message before
+// the next command starts, so results arrive in order.
+func Sequence(cmds ...Cmd) Cmd {
+	var live []Cmd
+	for _, c := range cmds {
+		if c != nil {
+			live = append(live, c)
+		}
+	}
+	switch len(live) {
+	case 0:
+		return nil
+	case 1:
+		return live[0]
+	}
+	return func() Msg { return sequenceMsg{cmds: live} }
+}
+
+// sequenceMsg asks the session to run the commands in order.
+type sequenceMsg struct{ cmds []Cmd }
+
+// Tick emits a TickMsg after d (min 1ms), measured on the session clock
+// (see WithClock). The command itself returns at once with a request the
+// session resolves; outside a session, run it with RunCmd.
 func Tick(d time.Duration) Cmd {
 	if d <= 0 {
 		d = time.Millisecond
 	}
-	return func() Msg {
-		time.Sleep(d)
+	return func() Msg { return tickMsg{d: d} }
+}
+
+// tickMsg asks the session to deliver a TickMsg after d.
+type tickMsg struct{ d time.Duration }
+
+// RunCmd runs cmd outside a session, such as in a test, and returns its
+// message. A Tick is waited for in real time and returns its TickMsg.
+func RunCmd(cmd Cmd) Msg {
+	if cmd == nil {
+		return nil
+	}
+	msg := cmd()
+	if t, ok := msg.(tickMsg); ok {
+		time.Sleep(t.d)
 		return TickMsg{At: time.Now()}
 	}
+	return msg
 }
 
 // Quit requests a graceful termination.
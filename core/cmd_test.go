@@ -0,0 +1,130 @@
+package core
+
+import (
+	"reflect"
+	"slices"
+	"testing"
+	"time"
+)
+
+type numMsg int
+
+func TestBatchAllDeliversEveryMessage(t *testing.T) {
+	var got []int
+	emit := func(n int) Cmd { return func() Msg { return numMsg(n) } }
+	m := funcModel{
+		init: func() Cmd {
+			return BatchAll(emit(1), nil, BatchAll(emit(2), emit(3)))
+		},
+		update: func(msg Msg) Cmd {
+			if n, ok := msg.(numMsg); ok {
+				if got = append(got, int(n)); len(got) == 3 {
+					return Quit()
+				}
+			}
+			return nil
+		},
+	}
+	runSession(t, m, "")
+	slices.Sort(got)
+	if !slices.Equal(got, []int{1, 2, 3}) {
+		t.Fatalf("got %v, want 1, 2 and 3", got)
+	}
+}
+
+func TestBatchAllShortcuts(t *testing.T) {
+	if BatchAll() != nil || BatchAll(nil, nil) != nil {
+		t.Error("BatchAll of no commands is not nil")
+	}
+	if msg := BatchAll(nil, Quit())(); msg != (QuitMsg{}) {
+		t.Errorf("BatchAll of one command = %#v, want the command itself", msg)
+	}
+}
+
+func TestSequenceKeepsOrder(t *testing.T) {
+	var got []int
+	slow := func(n int) Cmd {
+		return func() Msg { time.Sleep(time.Duration(5-n) * time.Millisecond); return numMsg(n) }
+	}
+	m := funcModel{
+		init: func() Cmd { return Sequence(slow(1), nil, slow(2), slow(3), slow(4)) },
+		update: func(msg Msg) Cmd {
+			if n, ok := msg.(numMsg); ok {
+				if got = append(got, int(n)); len(got) == 4 {
+					return Quit()
+				}
+			}
+			return nil
+		},
+	}
+	runSession(t, m, "")
+	if !slices.Equal(got, []int{1, 2, 3, 4}) {
+		t.Fatalf("got %v, want [1 2 3 4]", got)
+	}
+}
+
+func TestRunCmdTick(t *testing.T) {
+	if msg, ok := RunCmd(Tick(time.Millisecond)).(TickMsg); !ok || msg.At.IsZero() {
+		t.Fatalf("RunCmd(Tick) = %#v, want a TickMsg", msg)
+	}
+}
+
+func TestTickUsesSessionClock(t *testing.T) {
+	for name, wrap := range map[string]func(Cmd) Cmd{
+		"plain":   func(c Cmd) Cmd { return c },
+		"batch":   func(c Cmd) Cmd { return Batch(c) },
+		"request": func(c Cmd) Cmd { c, _ = WithRequestID(c); return c },
+	} {
+		t.Run(name, func(t *testing.T) { testTickClock(t, wrap) })
+	}
+}
+
+func testTickClock(t *testing.T, wrap func(Cmd) Cmd) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	clock := NewFakeClock(start)
+	var got TickMsg
+	m := funcModel{
+		init: func() Cmd { return wrap(Tick(time.Hour)) },
+		update: func(msg Msg) Cmd {
+			if tick, ok := msg.(TickMsg); ok {
+				got = tick
+				return Quit()
+			}
+			return nil
+		},
+	}
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		for {
+			select {
+			case <-done:
+				return
+			case <-time.After(time.Millisecond):
+				clock.Advance(time.Hour)
+			}
+		}
+	}()
+	runSession(t, m, "", WithClock(clock))
+	if got.At.Before(start.Add(time.Hour)) {
+		t.Fatalf("TickMsg.At = %v, want at least an hour after %v", got.At, start)
+	}
+}
+
+func TestWithRequestIDWrapsOnlyModelMsgs(t *testing.T) {
+	emit := func(msg Msg) Cmd { return func() Msg { return msg } }
+	for _, cmd := range []Cmd{Quit(), Tick(time.Second), Batch(Quit(), Quit()), Sequence(Quit(), Quit())} {
+		c, _ := WithRequestID(cmd)
+		if msg := c(); reflect.TypeOf(msg) != reflect.TypeOf(cmd()) {
+			t.Errorf("%T was wrapped as %T", cmd(), msg)
+		}
+	}
+	c, id := WithRequestID(emit(numMsg(1)))
+	if got, msg, ok := RequestIDFromMsg(c()); !ok || got != id || msg != numMsg(1) {
+		t.Errorf("RequestIDFromMsg = %v, %v, %v", got, msg, ok)
+	}
+	c, _ = WithRequestID(emit(nil))
+	if msg := c(); msg != nil {
+		t.Errorf("nil result delivered as %v", msg)
+	}
+}
@@ -1,8 +1,8 @@
 package core
 
 import (
-	"fmt"
-	"regexp"
+	"math"
+	"strconv"
 	"strings"
 )
 
@@ -60,6 +60,69 @@ func RGB(r, g, b uint8) Color {
 	return Color{kind: colorRGB, r: r, g: g, b: b}
 }
 
+// RGB returns the color's 24-bit value. Named colors use the xterm default
+// palette; an unset color reports black.
+func (c Color) RGB() (r, g, b uint8) {
+	switch c.kind {
+	case colorRGB:
+		return c.r, c.g, c.b
+	case colorNamed16:
+		i := int(c.named)
+		if c.bright {
+			i += 8
+		}
+		p := ansi16Palette[i]
+		return p[0], p[1], p[2]
+	case colorIndex256:
+		return index256ToRGB(c.index)
+	}
+	return 0, 0, 0
+}
+
+// IsDark reports whether the color's relative luminance is below 50%.
+func (c Color) IsDark() bool { return c.luminance() < 0.5 }
+
+// luminance returns the color's relative luminance (WCAG 2.x), 0..1.
+func (c Color) luminance() float64 {
+	r, g, b := c.RGB()
+	lin := func(v uint8) float64 {
+		x := float64(v) / 255
+		if x <= 0.03928 {
+			return x / 12.92
+		}
+		return math.Pow((x+0.055)/1.055, 2.4)
+	}
+	return 0.2126*lin(r) + 0.7152*lin(g) + 0.0722*lin(b)
+}
+
+// xterm's default 16-color palette.
+var ansi16Palette = [16][3]uint8{
+	{0, 0, 0}, {205, 0, 0}, {0, 205, 0}, {205, 205, 0},
+	{0, 0, 238}, {205, 0, 205}, {0, 205, 205}, {229, 229, 229},
+	{127, 127, 127}, {255, 0, 0}, {0, 255, 0}, {255, 255, 0},
+	{92, 92, 255}, {255, 0, 255}, {0, 255, 255}, {255, 255, 255},
+}
+
+func index256ToRGB(n uint8) (r, g, b uint8) {
+	switch {
+	case n < 16:
+		p := ansi16Palette[n]
+		return p[0], p[1], p[2]
+	case n < 232:
+		n -= 16
+		level := func(v uint8) uint8 {
+			if v == 0 {
+				return 0
+			}
+			return 55 + v*40
+		}
+		return level(n / 36), level((n / 6) % 6), level(n % 6)
+	default:
+		v := 8 + (n-232)*10
+		return v, v, v
+	}
+}
+
 // ---- Style with basic attributes ----
 
 type Style struct {
@@ -71,24 +134,78 @@ type Style struct {
 	Blink    bool
 	Reverse  bool
 	Strike   bool
+
+	// prefix caches the SGR escape for this style. It is rebuilt by the
+	// builder methods and only trusted while the attribute fields still
+	// match prefixAttrs (fields may be set directly).
+	prefix      string
+	prefixAttrs uint8
+	prefixOK    bool
 }
 
+const sgrReset = "\x1b[0m"
+
 // Builder / chaining
 
-func NewStyle() Style                 { return Style{} }
-func (s Style) Fg(c Color) Style      { s.fg = &c; return s }
-func (s Style) Bg(c Color) Style      { s.bg = &c; return s }
-func (s Style) Bolded() Style         { s.Bold = true; return s }
-func (s Style) Fainted() Style        { s.Faint = true; return s }
-func (s Style) Italicized() Style     { s.Italic = true; return s }
-func (s Style) Underlined() Style     { s.Underline = true; return s }
-func (s Style) Blinking() Style       { s.Blink = true; return s }
-func (s Style) Reversed() Style       { s.Reverse = true; return s }
-func (s Style) Struck() Style         { s.Strike = true; return s }
+func NewStyle() Style             { return Style{}.cache() }
+func (s Style) Fg(c Color) Style  { s.fg = &c; return s.cache() }
+func (s Style) Bg(c Color) Style  { s.bg = &c; return s.cache() }
+func (s Style) Bolded() Style     { s.Bold = true; return s.cache() }
+func (s Style) Fainted() Style    { s.Faint = true; return s.cache() }
+func (s Style) Italicized() Style { s.Italic = true; return s.cache() }
+func (s Style) Underlined() Style { s.Underline = true; return s.cache() }
+func (s Style) Blinking() Style   { s.Blink = true; return s.cache() }
+func (s Style) Reversed() Style   { s.Reverse = true; return s.cache() }
+func (s Style) Struck() Style     { s.Strike = true; return s.cache() }
 
 // Render wraps text in ANSI SGR codes. It always emits ANSI; the renderer
+// strips it when the color profile is ColorNone.
 func (s Style) Render(text string) string {
-	codes := make([]string, 0, 6)
+	p := s.Prefix()
+	if p == "" {
+		return text
+	}
+	return p + text + sgrReset
+}
+
+// Prefix returns the SGR escape that opens this style ("" for a plain style).
+// Pair it with Styled to render many strings with the same style cheaply.
+func (s Style) Prefix() string {
+	if s.prefixOK && s.prefixAttrs == s.attrs() {
+		return s.prefix
+	}
+	return s.sgr()
+}
+
+// Styled wraps text with a prefix obtained from Style.Prefix, followed by a
+// reset. It is the fast path for bulk rendering (tables, lists).
+func Styled(prefix, text string) string {
+	if prefix == "" {
+		return text
+	}
+	return prefix + text + sgrReset
+}
+
+func (s Style) cache() Style {
+	s.prefix = s.sgr()
+	s.prefixAttrs = s.attrs()
+	s.prefixOK = true
+	return s
+}
+
+func (s Style) attrs() uint8 {
+	var a uint8
+	for i, on := range [...]bool{s.Bold, s.Faint, s.Italic, s.Underline, s.Blink, s.Reverse, s.Strike} {
+		if on {
+			a |= 1 << i
+		}
+	}
+	return a
+}
+
+// sgr builds the escape sequence from scratch.
+func (s Style) sgr() string {
+	codes := make([]string, 0, 8)
 
 	// attributes
 	if s.Bold {
@@ -122,9 +239,9 @@ func (s Style) Render(text string) string {
 	}
 
 	if len(codes) == 0 {
-		return text
+		return ""
 	}
-	return fmt.Sprintf("\x1b[%sm%s\x1b[0m", strings.Join(codes, ";"), text)
+	return "\x1b[" + strings.Join(codes, ";") + "m"
 }
 
 func (c Color) fgSGR() []string {
@@ -135,11 +252,11 @@ func (c Color) fgSGR() []string {
 		if c.bright {
 			base = 90 + int(c.named)
 		}
-		return []string{fmt.Sprintf("%d", base)}
+		return []string{strconv.Itoa(base)}
 	case colorIndex256:
-		return []string{"38", "5", fmt.Sprintf("%d", c.index)}
+		return []string{"38", "5", strconv.Itoa(int(c.index))}
 	case colorRGB:
-		return []string{"38", "2", fmt.Sprintf("%d", c.r), fmt.Sprintf("%d", c.g), fmt.Sprintf("%d", c.b)}
+		return []string{"38", "2", strconv.Itoa(int(c.r)), strconv.Itoa(int(c.g)), strconv.Itoa(int(c.b))}
 	default:
 		return nil
 	}
@@ -153,11 +270,11 @@ func (c Color) bgSGR() []string {
 		if c.bright {
 			base = 100 + int(c.named)
 		}
-		return []string{fmt.Sprintf("%d", base)}
+		return []string{strconv.Itoa(base)}
 	case colorIndex256:
-		return []string{"48", "5", fmt.Sprintf("%d", c.index)}
+		return []string{"48", "5", strconv.Itoa(int(c.index))}
 	case colorRGB:
-		return []string{"48", "2", fmt.Sprintf("%d", c.r), fmt.Sprintf("%d", c.g), fmt.Sprintf("%d", c.b)}
+		return []string{"48", "2", strconv.Itoa(int(c.r)), strconv.Itoa(int(c.g)), strconv.Itoa(int(c.b))}
 	default:
 		return nil
 	}
@@ -198,10 +315,24 @@ var (
 	ColorBrightWhite = Ansi16(NamedWhite, true)
 )
 
-
-var reANSISGR = regexp.MustCompile(`\x1b\[[0-9;]*m`)
-
-// StripANSI removes SGR sequences from a string.
+// StripANSI removes escape sequences from a string: styles, cursor
+// movement and other CSI sequences, OSC strings such as hyperlinks and
+// window titles, DCS and the other string sequences, and C1 controls.
 func StripANSI(s string) string {
-	return reANSISGR.ReplaceAllString(s, "")
+	i := escapeIndex(s)
+	if i < 0 {
+		return s
+	}
+	var b strings.Builder
+	b.Grow(len(s))
+	b.WriteString(s[:i])
+	for i < len(s) {
+		if n := ansiLen(s, i); n > 0 {
+			i += n
+			continue
+		}
+		b.WriteByte(s[i])
+		i++
+	}
+	return b.String()
 }
@@ -0,0 +1,196 @@
+package core
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Config holds session settings as plain values, for applications that let
+// their users tune frog the same way as the rest of the program: decode it
+// from a configuration file, or fill it with RegisterFlags or LoadEnv, then
+// pass OptionsFromConfig to Run. The zero Config changes nothing.
+//
+// The feature toggles are pointers so a setting that was not given can be
+// told from one turned off: nil leaves the feature as the application's own
+// options set it, and false turns it off. The numbers and durations have no
+// such third state: zero leaves the application's setting.
+type Config struct {
+	AltScreen      *bool
+	Mouse          *bool
+	BracketedPaste *bool
+	Keypad         *bool
+	Help           *bool
+	DebugOverlay   *bool
+	FrameSkipping  *bool
+	ForceColor     *bool
+
+	// ColorProfile is "auto" (or empty), "none", "16", "256" or
+	// "truecolor".
+	ColorProfile string
+
+	FPS            int
+	MsgBuffer      int
+	EscapeTimeout  time.Duration
+	ResizeDebounce time.Duration
+	Idle           time.Duration
+	MinWidth       int
+	MinHeight      int
+}
+
+// OptionsFromConfig returns the options cfg describes. Pass them after the
+// application's own options so the settings override them. It fails only
+// for an unknown color profile.
+func OptionsFromConfig(cfg Config) ([]Option, error) {
+	var opts []Option
+	add := func(on bool, o Option) {
+		if on {
+			opts = append(opts, o)
+		}
+	}
+	toggle := func(v *bool, field func(*Session) *bool) {
+		if v != nil {
+			on := *v
+			opts = append(opts, func(p *Session) { *field(p) = on })
+		}
+	}
+	toggle(cfg.AltScreen, func(p *Session) *bool { return &p.altScreen })
+	toggle(cfg.Mouse, func(p *Session) *bool { return &p.enableMouse })
+	toggle(cfg.BracketedPaste, func(p *Session) *bool { return &p.enableBracketedPaste })
+	toggle(cfg.Keypad, func(p *Session) *bool { return &p.keypad })
+	toggle(cfg.Help, func(p *Session) *bool { return &p.help })
+	toggle(cfg.DebugOverlay, func(p *Session) *bool { return &p.debugOverlay })
+	toggle(cfg.FrameSkipping, func(p *Session) *bool { return &p.frameSkip })
+	toggle(cfg.ForceColor, func(p *Session) *bool { return &p.forceColor })
+	add(cfg.FPS > 0, WithFPS(cfg.FPS))
+	add(cfg.MsgBuffer > 0, WithMsgBuffer(cfg.MsgBuffer))
+	add(cfg.EscapeTimeout > 0, WithEscapeTimeout(cfg.EscapeTimeout))
+	add(cfg.ResizeDebounce > 0, WithResizeDebounce(cfg.ResizeDebounce))
+	add(cfg.Idle > 0, WithIdle(cfg.Idle))
+	add(cfg.MinWidth > 0 || cfg.MinHeight > 0, WithMinSize(cfg.MinWidth, cfg.MinHeight))
+
+	profile, err := parseColorProfile(cfg.ColorProfile)
+	if err != nil {
+		return nil, err
+	}
+	add(profile != ColorAuto, func(p *Session) { p.colorProfile = profile })
+	return opts, nil
+}
+
+func parseColorProfile(s string) (ColorProfile, error) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "", "auto":
+		return ColorAuto, nil
+	case "none":
+		return ColorNone, nil
+	case "16", "ansi":
+		return ColorANSI16, nil
+	case "256", "ansi256":
+		return ColorANSI256, nil
+	case "truecolor", "24bit":
+		return ColorTrueColor, nil
+	}
+	return ColorAuto, fmt.Errorf("frog: unknown color profile %q", s)
+}
+
+// configField is a Config field by its flag name.
+type configField struct {
+	name  string
+	usage string
+	ptr   any // **bool, *int, *string or *time.Duration
+}
+
+func (c *Config) fields() []configField {
+	return []configField{
+		{"alt-screen", "use the alternate screen", &c.AltScreen},
+		{"mouse", "enable mouse input", &c.Mouse},
+		{"bracketed-paste", "enable bracketed paste", &c.BracketedPaste},
+		{"keypad", "report keypad keys apart from the main keys", &c.Keypad},
+		{"help", "show key help on '?'", &c.Help},
+		{"debug-overlay", "enable the debug overlay", &c.DebugOverlay},
+		{"frame-skipping", "skip frames while the terminal lags", &c.FrameSkipping},
+		{"force-color", "emit color even when output is not a terminal", &c.ForceColor},
+		{"color-profile", "color profile: auto, none, 16, 256 or truecolor", &c.ColorProfile},
+		{"fps", "maximum frames per second (0: the application's setting)", &c.FPS},
+		{"msg-buffer", "message queue size", &c.MsgBuffer},
+		{"escape-timeout", "how long to wait for the rest of an escape sequence", &c.EscapeTimeout},
+		{"resize-debounce", "how long a resize must settle before it is delivered", &c.ResizeDebounce},
+		{"idle", "go idle after this long without activity", &c.Idle},
+		{"min-width", "minimum terminal width", &c.MinWidth},
+		{"min-height", "minimum terminal height", &c.MinHeight},
+	}
+}
+
+// RegisterFlags defines a flag on fs for each setting, named after it with
+// prefix in front: with prefix "ui-", -ui-alt-screen and -ui-msg-buffer.
+// The current values are the defaults; toggle flags that are not given stay
+// nil.
+func (c *Config) RegisterFlags(fs *flag.FlagSet, prefix string) {
+	for _, f := range c.fields() {
+		name := prefix + f.name
+		switch p := f.ptr.(type) {
+		case **bool:
+			fs.Var(toggleFlag{p}, name, f.usage)
+		case *int:
+			fs.IntVar(p, name, *p, f.usage)
+		case *string:
+			fs.StringVar(p, name, *p, f.usage)
+		case *time.Duration:
+			fs.DurationVar(p, name, *p, f.usage)
+		}
+	}
+}
+
+// LoadEnv sets the settings given in environment variables named after
+// them in upper case with prefix in front: with prefix "APP_FROG_",
+// APP_FROG_ALT_SCREEN=true and APP_FROG_IDLE=5m. Unset variables leave
+// their settings alone.
+func (c *Config) LoadEnv(prefix string) error {
+	for _, f := range c.fields() {
+		name := prefix + strings.ToUpper(strings.ReplaceAll(f.name, "-", "_"))
+		v, ok := os.LookupEnv(name)
+		if !ok {
+			continue
+		}
+		var err error
+		switch p := f.ptr.(type) {
+		case **bool:
+			err = toggleFlag{p}.Set(v)
+		case *int:
+			*p, err = strconv.Atoi(v)
+		case *string:
+			*p = v
+		case *time.Duration:
+			*p, err = time.ParseDuration(v)
+		}
+		if err != nil {
+			return fmt.Errorf("frog: %s: %w", name, err)
+		}
+	}
+	return nil
+}
+
+// toggleFlag is a boolean flag.Value setting a *bool, left nil until the
+// flag is given.
+type toggleFlag struct{ p **bool }
+
+func (f toggleFlag) IsBoolFlag() bool { return true }
+
+func (f toggleFlag) String() string {
+	if f.p == nil || *f.p == nil {
+		return ""
+	}
+	return strconv.FormatBool(**f.p)
+}
+
+func (f toggleFlag) Set(s string) error {
+	on, err := strconv.ParseBool(s)
+	if err != nil {
+		return err
+	}
+	*f.p = &on
+	return nil
+}
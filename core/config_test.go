@@ -0,0 +1,82 @@
+package core
+
+import (
+	"flag"
+	"testing"
+)
+
+func TestConfigToggles(t *testing.T) {
+	on, off := true, false
+	tests := []struct {
+		name  string
+		opts  []Option
+		mouse *bool
+		want  bool
+	}{
+		{"unset keeps default", nil, nil, false},
+		{"unset keeps app option", []Option{WithMouse()}, nil, true},
+		{"on", nil, &on, true},
+		{"off overrides app option", []Option{WithMouse()}, &off, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfgOpts, err := OptionsFromConfig(Config{Mouse: tt.mouse})
+			if err != nil {
+				t.Fatal(err)
+			}
+			p := NewSession(funcModel{}, append(tt.opts, cfgOpts...)...)
+			if p.enableMouse != tt.want {
+				t.Errorf("enableMouse = %v, want %v", p.enableMouse, tt.want)
+			}
+		})
+	}
+}
+
+func TestConfigFlagsAndEnv(t *testing.T) {
+	var cfg Config
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	cfg.RegisterFlags(fs, "ui-")
+	if err := fs.Parse([]string{"-ui-mouse", "-ui-alt-screen=false", "-ui-fps", "30"}); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("APP_KEYPAD", "0")
+	if err := cfg.LoadEnv("APP_"); err != nil {
+		t.Fatal(err)
+	}
+	check := func(name string, got *bool, want any) {
+		t.Helper()
+		if want == nil {
+			if got != nil {
+				t.Errorf("%s = %v, want nil", name, *got)
+			}
+			return
+		}
+		if got == nil || *got != want {
+			t.Errorf("%s = %v, want %v", name, got, want)
+		}
+	}
+	check("Mouse", cfg.Mouse, true)
+	check("AltScreen", cfg.AltScreen, false)
+	check("Keypad", cfg.Keypad, false)
+	check("Help", cfg.Help, nil)
+	if cfg.FPS != 30 {
+		t.Errorf("FPS = %d, want 30", cfg.FPS)
+	}
+
+	t.Setenv("APP_HELP", "maybe")
+	if err := cfg.LoadEnv("APP_"); err == nil {
+		t.Error("LoadEnv accepted APP_HELP=maybe")
+	}
+}
+
+// Zero numbers keep what the application's options set.
+func TestConfigZeroKeepsAppSetting(t *testing.T) {
+	cfgOpts, err := OptionsFromConfig(Config{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	p := NewSession(funcModel{}, append([]Option{WithFPS(20)}, cfgOpts...)...)
+	if p.fps != 20 {
+		t.Errorf("fps = %d, want 20", p.fps)
+	}
+}
@@ -0,0 +1,141 @@
+package core
+
+import "math"
+
+// MinReadableContrast is the WCAG AA contrast ratio for normal text.
+const MinReadableContrast = 4.5
+
+// ContrastRatio returns the WCAG contrast ratio between two colors, from 1
+// (identical luminance) to 21 (black on white).
+func ContrastRatio(fg, bg Color) float64 {
+	l1, l2 := fg.luminance(), bg.luminance()
+	if l1 < l2 {
+		l1, l2 = l2, l1
+	}
+	return (l1 + 0.05) / (l2 + 0.05)
+}
+
+// EnsureReadable adjusts the foreground so it reaches MinReadableContrast
+// against bg. See EnsureContrast.
+func (s Style) EnsureReadable(bg Color) Style { return s.EnsureContrast(bg, MinReadableContrast) }
+
+// EnsureContrast adjusts the foreground lightness until its contrast against
+// bg reaches min. Styles without a foreground are returned unchanged. RGB
+// colors keep their hue; palette colors are replaced by the closest 256-color
+// entry that qualifies, so the style still works on 256-color terminals.
+func (s Style) EnsureContrast(bg Color, min float64) Style {
+	if s.fg == nil || ContrastRatio(*s.fg, bg) >= min {
+		return s
+	}
+	if s.fg.kind == colorRGB {
+		return s.Fg(adjustLightness(*s.fg, bg, min))
+	}
+	return s.Fg(closestReadable256(*s.fg, bg, min))
+}
+
+// adjustLightness moves c's HSL lightness away from bg until the contrast
+// target is met (or the extreme is reached).
+func adjustLightness(c, bg Color, min float64) Color {
+	r, g, b := c.RGB()
+	h, sat, l := rgbToHSL(r, g, b)
+	step := 0.02
+	if bg.IsDark() {
+		step = -step
+	}
+	// Lighten on dark backgrounds, darken on light ones.
+	for i := 0; i < 50; i++ {
+		l -= step
+		l = math.Max(0, math.Min(1, l))
+		cand := RGB(hslToRGB(h, sat, l))
+		if ContrastRatio(cand, bg) >= min || l == 0 || l == 1 {
+			return cand
+		}
+	}
+	return c
+}
+
+func closestReadable256(c, bg Color, min float64) Color {
+	r0, g0, b0 := c.RGB()
+	best, bestDist := -1, math.MaxFloat64
+	for i := 0; i < 256; i++ {
+		cand := ANSI256(uint8(i))
+		if ContrastRatio(cand, bg) < min {
+			continue
+		}
+		r, g, b := cand.RGB()
+		dr, dg, db := float64(r)-float64(r0), float64(g)-float64(g0), float64(b)-float64(b0)
+		if d := dr*dr + dg*dg + db*db; d < bestDist {
+			best, bestDist = i, d
+		}
+	}
+	if best < 0 {
+		if bg.IsDark() {
+			return ANSI256(231) // white
+		}
+		return ANSI256(16) // black
+	}
+	return ANSI256(uint8(best))
+}
+
+func rgbToHSL(r8, g8, b8 uint8) (h, s, l float64) {
+	r, g, b := float64(r8)/255, float64(g8)/255, float64(b8)/255
+	max := math.Max(r, math.Max(g, b))
+	min := math.Min(r, math.Min(g, b))
+	l = (max + min) / 2
+	if max == min {
+		return 0, 0, l
+	}
+	d := max - min
+	if l > 0.5 {
+		s = d / (2 - max - min)
+	} else {
+		s = d / (max + min)
+	}
+	switch max {
+	case r:
+		h = (g - b) / d
+		if g < b {
+			h += 6
+		}
+	case g:
+		h = (b-r)/d + 2
+	default:
+		h = (r-g)/d + 4
+	}
+	return h / 6, s, l
+}
+
+func hslToRGB(h, s, l float64) (r, g, b uint8) {
+	if s == 0 {
+		v := uint8(math.Round(l * 255))
+		return v, v, v
+	}
+	var q float64
+	if l < 0.5 {
+		q = l * (1 + s)
+	} else {
+		q = l + s - l*s
+	}
+	p := 2*l - q
+	hue := func(t float64) uint8 {
+		if t < 0 {
+			t++
+		}
+		if t > 1 {
+			t--
+		}
+		var v float64
+		switch {
+		case t < 1.0/6:
+			v = p + (q-p)*6*t
+		case t < 0.5:
+			v = q
+		case t < 2.0/3:
+			v = p + (q-p)*(2.0/3-t)*6
+		default:
+			v = p
+		}
+		return uint8(math.Round(v * 255))
+	}
+	return hue(h + 1.0/3), hue(h), hue(h - 1.0/3)
+}
@@ -0,0 +1,83 @@
+package core
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"net"
+)
+
+// Control requests beyond plain message envelopes.
+const (
+	ControlScreenshot = "screenshot" // reply carries the current view
+)
+
+// ControlReply answers one control request.
+type ControlReply struct {
+	OK     bool   `json:"ok"`
+	Error  string `json:"error,omitempty"`
+	Screen string `json:"screen,omitempty"`
+}
+
+// ServeControl accepts connections on l and lets each peer drive the
+// session with newline-delimited JSON: every line is an Envelope (see
+// EncodeMsg) delivered to the model, or {"type":"screenshot"} to read the
+// current view. Each line gets one ControlReply line back. It returns when
+// the session stops or l fails; l is closed either way.
+func (p *Session) ServeControl(l net.Listener) error {
+	go func() {
+		<-p.ctx.Done()
+		l.Close()
+	}()
+	defer l.Close()
+	for {
+		conn, err := l.Accept()
+		if err != nil {
+			if p.ctx.Err() != nil || errors.Is(err, net.ErrClosed) {
+				return nil
+			}
+			return err
+		}
+		go p.serveControlConn(conn)
+	}
+}
+
+func (p *Session) serveControlConn(conn net.Conn) {
+	defer conn.Close()
+	go func() {
+		<-p.ctx.Done()
+		conn.Close()
+	}()
+	sc := bufio.NewScanner(conn)
+	sc.Buffer(nil, maxPaste+4096)
+	enc := json.NewEncoder(conn)
+	for sc.Scan() {
+		if len(sc.Bytes()) == 0 {
+			continue
+		}
+		if err := enc.Encode(p.control(sc.Bytes())); err != nil {
+			return
+		}
+	}
+}
+
+// control handles one request line.
+func (p *Session) control(line []byte) ControlReply {
+	var env Envelope
+	if err := json.Unmarshal(line, &env); err != nil {
+		return ControlReply{Error: err.Error()}
+	}
+	if env.Type == ControlScreenshot {
+		return ControlReply{OK: true, Screen: p.Screen()}
+	}
+	msg, err := env.Msg()
+	if err != nil {
+		return ControlReply{Error: err.Error()}
+	}
+	select {
+	case p.msgCh <- msg:
+		return ControlReply{OK: true}
+	case <-p.done:
+		return ControlReply{Error: "session stopped"}
+	}
+}
@@ -0,0 +1,150 @@
+package core
+
+import (
+	"fmt"
+	"os"
+	"runtime/debug"
+	"strings"
+)
+
+// PanicError is returned by Run when Init, Update, View or a command
+// panics. Stack holds the trace of the panicking goroutine.
+type PanicError struct {
+	Value any
+	Stack []byte
+}
+
+func (e *PanicError) Error() string { return fmt.Sprintf("panic: %v", e.Value) }
+
+func newPanicError(v any) *PanicError { return &PanicError{Value: v, Stack: debug.Stack()} }
+
+// cmdPanicMsg carries a panic recovered in a command goroutine to the loop.
+type cmdPanicMsg struct{ err *PanicError }
+
+// WithoutCrashScreen skips the crash screen: a panic restores the terminal
+// and Run returns the *PanicError straight away.
+func WithoutCrashScreen() Option { return func(p *Session) { p.noCrashScreen = true } }
+
+var (
+	crashTitleStyle = NewStyle().Fg(ColorBrightRed).Bolded()
+	crashHintStyle  = NewStyle().Reversed()
+)
+
+// crashScreen is the view shown after a panic: the panic value and a
+// scrollable stack trace.
+type crashScreen struct {
+	title  string
+	lines  []string
+	offset int
+	height int
+	copied bool
+}
+
+func newCrashScreen(err *PanicError, height int) *crashScreen {
+	trace := strings.TrimRight(strings.ReplaceAll(string(err.Stack), "\t", "    "), "\n")
+	return &crashScreen{
+		title:  err.Error(),
+		lines:  strings.Split(trace, "\n"),
+		height: height,
+	}
+}
+
+// page is the number of trace rows that fit between the title and the
+// key hints.
+func (c *crashScreen) page() int {
+	h := c.height
+	if h <= 0 {
+		h = 24
+	}
+	return max(h-3, 1)
+}
+
+func (c *crashScreen) scroll(n int) {
+	c.offset = max(min(c.offset+n, len(c.lines)-c.page()), 0)
+}
+
+// key applies a key press and reports whether the screen should close.
+func (c *crashScreen) key(k KeyMsg) (quit bool) {
+	switch {
+	case k.Type == KeyQ, k.Type == KeyEsc, k.Type == KeyEnter, k.Type == KeyCtrlC:
+		return true
+	case k.Type == KeyUp, k.String == "k":
+		c.scroll(-1)
+	case k.Type == KeyDown, k.String == "j":
+		c.scroll(1)
+	case k.Type == KeyPgUp:
+		c.scroll(-c.page())
+	case k.Type == KeyPgDn, k.Type == KeySpace:
+		c.scroll(c.page())
+	case k.Type == KeyHome, k.String == "g":
+		c.offset = 0
+	case k.Type == KeyEnd, k.String == "G":
+		c.scroll(len(c.lines))
+	}
+	return false
+}
+
+func (c *crashScreen) text() string {
+	return c.title + "\n\n" + strings.Join(c.lines, "\n") + "\n"
+}
+
+func (c *crashScreen) view() string {
+	rows := make([]string, 0, c.page()+3)
+	rows = append(rows, crashTitleStyle.Render(c.title), "")
+	end := min(c.offset+c.page(), len(c.lines))
+	rows = append(rows, c.lines[c.offset:end]...)
+	for len(rows) < c.page()+2 {
+		rows = append(rows, "")
+	}
+	hint := fmt.Sprintf(" %d-%d/%d  ↑/↓ scroll  c copy  q quit ", c.offset+1, end, len(c.lines))
+	if c.copied {
+		hint += " copied "
+	}
+	return strings.Join(append(rows, crashHintStyle.Render(hint)), "\n")
+}
+
+// showCrash replaces the model's view with the crash screen and blocks
+// until the user dismisses it or the session is stopped.
+func (p *Session) showCrash(err *PanicError, sigCh <-chan os.Signal) {
+	c := newCrashScreen(err, p.height)
+	p.invalidate()
+	p.renderer.Render(c.view())
+	for {
+		select {
+		case <-p.ctx.Done():
+			return
+		case <-sigCh:
+			return
+		case msg := <-p.msgCh:
+			if _, ok := msg.(resizeReadyMsg); ok {
+				msg = p.takeSize()
+			}
+			switch msg := msg.(type) {
+			case KeyMsg:
+				if c.key(msg) {
+					return
+				}
+				if msg.String == "c" {
+					p.writeRaw(osc52(c.text()))
+					c.copied = true
+				}
+			case MouseMsg:
+				switch msg.Button {
+				case MouseWheelUp:
+					c.scroll(-3)
+				case MouseWheelDown:
+					c.scroll(3)
+				}
+			case ResizeMsg:
+				c.height = msg.Height
+				if sz, ok := p.renderer.(interface{ SetSize(w, h int) }); ok {
+					sz.SetSize(msg.Width, msg.Height)
+				}
+				c.scroll(0)
+			default:
+				continue
+			}
+			p.renderer.Render(c.view())
+		}
+	}
+}
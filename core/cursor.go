@@ -0,0 +1,43 @@
+package core
+
+import "strconv"
+
+// CursorShape is a DECSCUSR cursor style.
+type CursorShape int
+
+const (
+	CursorDefault CursorShape = iota // terminal default
+	CursorBlinkingBlock
+	CursorSteadyBlock
+	CursorBlinkingUnderline
+	CursorSteadyUnderline
+	CursorBlinkingBar
+	CursorSteadyBar
+)
+
+func (c CursorShape) sequence() string {
+	if c < CursorDefault || c > CursorSteadyBar {
+		c = CursorDefault
+	}
+	return "\x1b[" + strconv.Itoa(int(c)) + " q"
+}
+
+// cursorShapeMsg asks the session to change the cursor shape.
+type cursorShapeMsg struct{ shape CursorShape }
+
+// SetCursorShape returns a command that changes the cursor shape. The
+// terminal default is restored when the session exits.
+func SetCursorShape(shape CursorShape) Cmd {
+	return func() Msg { return cursorShapeMsg{shape: shape} }
+}
+
+// WithCursorShape sets the cursor shape for the whole session.
+func WithCursorShape(shape CursorShape) Option {
+	return func(p *Session) { p.cursorShape = shape }
+}
+
+// setCursorShape writes the shape and remembers to restore it on exit.
+func (p *Session) setCursorShape(shape CursorShape) {
+	p.writeRaw(shape.sequence())
+	p.cursorShapeSet = shape != CursorDefault || p.cursorShapeSet
+}
@@ -0,0 +1,97 @@
+package core
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+// debugToggleKey toggles the debug overlay (Ctrl+G).
+const debugToggleKey = "\x07"
+
+// renderStats accumulates timing and throughput figures for the debug HUD.
+type renderStats struct {
+	fps        float64
+	frames     int
+	windowAt   time.Time
+	lastBytes  int64
+	updateTime time.Duration
+	viewTime   time.Duration
+}
+
+// frame records a rendered frame that wrote n bytes.
+func (s *renderStats) frame(now time.Time, n int64) {
+	s.lastBytes = n
+	s.frames++
+	if s.windowAt.IsZero() {
+		s.windowAt = now
+		return
+	}
+	if el := now.Sub(s.windowAt); el >= time.Second {
+		s.fps = float64(s.frames) / el.Seconds()
+		s.frames = 0
+		s.windowAt = now
+	}
+}
+
+var hudStyle = NewStyle().Reversed()
+
+// hud renders the stats block shown in the top-right corner.
+func (p *Session) hud() string {
+	rows := []string{
+		" frog debug      ",
+		fmt.Sprintf(" fps    %8.1f ", p.stats.fps),
+		fmt.Sprintf(" bytes  %8d ", p.stats.lastBytes),
+		fmt.Sprintf(" queue  %4d/%-3d ", len(p.msgCh), cap(p.msgCh)),
+		fmt.Sprintf(" update %8s ", shortDuration(p.stats.updateTime)),
+		fmt.Sprintf(" view   %8s ", shortDuration(p.stats.viewTime)),
+	}
+	w := 0
+	for _, r := range rows {
+		if n := displayWidth(r); n > w {
+			w = n
+		}
+	}
+	for i, r := range rows {
+		rows[i] = hudStyle.Render(r + strings.Repeat(" ", w-displayWidth(r)))
+	}
+	return strings.Join(rows, "\n")
+}
+
+// withHUD composites the debug HUD over view.
+func (p *Session) withHUD(view string) string {
+	hud := p.hud()
+	width := p.width
+	if width <= 0 {
+		width = 80
+	}
+	x := width - displayWidth(strings.SplitN(hud, "\n", 2)[0])
+	return Overlay(view, hud, x, 0)
+}
+
+func shortDuration(d time.Duration) string {
+	switch {
+	case d >= time.Second:
+		return fmt.Sprintf("%.2fs", d.Seconds())
+	case d >= time.Millisecond:
+		return fmt.Sprintf("%.1fms", float64(d)/float64(time.Millisecond))
+	default:
+		return fmt.Sprintf("%dµs", d.Microseconds())
+	}
+}
+
+// countingWriter counts bytes written through it.
+type countingWriter struct {
+	w io.Writer
+	n int64
+}
+
+func (c *countingWriter) Write(p []byte) (int, error) {
+	n, err := c.w.Write(p)
+	c.n += int64(n)
+	return n, err
+}
+
+// Unwrap returns the writer c counts for.
+func (c *countingWriter) Unwrap() io.Writer { return c.w }
@@ -0,0 +1,45 @@
+package core
+
+// Deps holds services shared by a session's models and commands (HTTP
+// clients, database handles, loggers) so they don't need globals. It is
+// delivered to the model in a DepsMsg at startup.
+type Deps struct {
+	values map[any]any
+}
+
+// DepsMsg is delivered once at startup when the session has dependencies.
+type DepsMsg struct {
+	Deps Deps
+}
+
+// depKey is the key Provide stores a value of type T under.
+type depKey[T any] struct{}
+
+// Value returns the dependency stored under key, or nil.
+func (d Deps) Value(key any) any { return d.values[key] }
+
+// Lookup returns the dependency of type T registered with Provide.
+func Lookup[T any](d Deps) (T, bool) {
+	v, ok := d.values[depKey[T]{}].(T)
+	return v, ok
+}
+
+// WithValue registers a dependency under key (compare with context.WithValue).
+func WithValue(key, val any) Option {
+	return func(p *Session) { p.deps.set(key, val) }
+}
+
+// Provide registers v as the session's dependency of type T.
+func Provide[T any](v T) Option {
+	return func(p *Session) { p.deps.set(depKey[T]{}, v) }
+}
+
+// Deps returns the session's dependencies.
+func (p *Session) Deps() Deps { return p.deps }
+
+func (d *Deps) set(key, val any) {
+	if d.values == nil {
+		d.values = map[any]any{}
+	}
+	d.values[key] = val
+}
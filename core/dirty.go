@@ -0,0 +1,59 @@
+package core
+
+// Rect is a region of the screen in cells; X and Y are zero-based.
+type Rect struct {
+	X, Y          int
+	Width, Height int
+}
+
+// DirtyViewer is implemented by models that know which parts of their view
+// changed, such as large dashboards that update one panel at a time. The
+// session calls DirtyRects before each View; the renderer then compares
+// only the rows the rectangles cover and takes every other row to be the
+// same as in the previous frame, without diffing it. Rows are repainted
+// whole, so X and Width only document intent for now.
+//
+// A nil result means the model cannot tell, and the whole view is diffed
+// as usual; an empty non-nil result means nothing changed, and the frame is
+// skipped without calling View while the screen is up to date. Reporting too
+// little leaves stale rows on screen until they are next marked dirty.
+// Hints are ignored for frames the session alters, such as with the help
+// overlay open, and when soft wrapping moves rows around.
+type DirtyViewer interface {
+	DirtyRects() []Rect
+}
+
+// dirtyRows marks the rows rects cover on a screen height rows tall.
+func dirtyRows(rects []Rect, height int) map[int]bool {
+	rows := make(map[int]bool)
+	for _, r := range rects {
+		for y := max(r.Y, 0); y < height && y-r.Y < r.Height; y++ {
+			rows[y] = true
+		}
+	}
+	return rows
+}
+
+// dirtyHint returns the model's dirty rows for the frame being rendered, or
+// nil when the whole frame must be diffed. The hint describes the model's
+// view, so it only holds when both this frame and the previous one show
+// that view unaltered.
+func (p *Session) dirtyHint() map[int]bool {
+	dv, ok := p.m.(DirtyViewer)
+	if !ok {
+		return nil
+	}
+	plain := !p.tooSmall() && !p.helpVisible && !p.debugVisible
+	for _, h := range p.renderHooks {
+		if h.before != nil {
+			plain = false
+		}
+	}
+	prev := p.plainFrame
+	p.plainFrame = plain
+	rects := dv.DirtyRects()
+	if !plain || !prev || rects == nil {
+		return nil
+	}
+	return dirtyRows(rects, p.height)
+}
@@ -0,0 +1,39 @@
+package core
+
+import (
+	"maps"
+	"math"
+	"testing"
+)
+
+// dirtyModel reports its view unchanged and counts View calls.
+type dirtyModel struct{ views *int }
+
+func (m dirtyModel) Init() Cmd { return nil }
+func (m dirtyModel) Update(msg Msg) (Model, Cmd) {
+	if k, ok := msg.(KeyMsg); ok && k.String == "q" {
+		return m, Quit()
+	}
+	return m, nil
+}
+func (m dirtyModel) View() string       { *m.views++; return "static" }
+func (m dirtyModel) DirtyRects() []Rect { return []Rect{} }
+
+// Frames the model reports unchanged are skipped before View is called:
+// only the first frame and the repaint for the terminal size call it, not
+// the keys after them.
+func TestDirtyViewerSkipsView(t *testing.T) {
+	var views int
+	runSession(t, dirtyModel{views: &views}, "abcdefghijklmnopq")
+	if views != 2 {
+		t.Fatalf("View called %d times, want 2", views)
+	}
+}
+
+func TestDirtyRowsClampsToScreen(t *testing.T) {
+	rows := dirtyRows([]Rect{{Y: -2, Height: 4}, {Y: 22, Height: math.MaxInt}}, 24)
+	want := map[int]bool{0: true, 1: true, 22: true, 23: true}
+	if !maps.Equal(rows, want) {
+		t.Errorf("dirtyRows = %v, want %v", rows, want)
+	}
+}
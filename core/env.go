@@ -0,0 +1,44 @@
+package core
+
+import (
+	"os"
+	"strconv"
+	"strings"
+)
+
+// Environment variables that override the options a program was built
+// with, so users can work around an incompatible terminal without changes
+// to the program:
+//
+//	FROG_ALTSCREEN=0       stay on the main screen (1 forces the alternate one)
+//	FROG_MOUSE=0           leave mouse reporting off (1 turns it on)
+//	FROG_FPS=30            cap the frame rate (0 removes any cap); see WithFPS
+//	FROG_NONINTERACTIVE=1  render once and exit; see WithNonInteractive
+//
+// Unset or unrecognized values leave the program's choice alone.
+const (
+	AltScreenEnv      = "FROG_ALTSCREEN"
+	MouseEnv          = "FROG_MOUSE"
+	FPSEnv            = "FROG_FPS"
+	NonInteractiveEnv = "FROG_NONINTERACTIVE"
+)
+
+// applyEnv applies the environment overrides.
+func (p *Session) applyEnv() {
+	p.altScreen = boolFromEnv(os.Getenv(AltScreenEnv), p.altScreen)
+	p.enableMouse = boolFromEnv(os.Getenv(MouseEnv), p.enableMouse)
+	p.nonInteractive = boolFromEnv(os.Getenv(NonInteractiveEnv), p.nonInteractive)
+	if n, err := strconv.Atoi(strings.TrimSpace(os.Getenv(FPSEnv))); err == nil && n >= 0 {
+		p.fps = n
+	}
+}
+
+func boolFromEnv(v string, def bool) bool {
+	switch strings.ToLower(strings.TrimSpace(v)) {
+	case "0", "false", "off", "no":
+		return false
+	case "1", "true", "on", "yes":
+		return true
+	}
+	return def
+}
@@ -0,0 +1,62 @@
+package core
+
+import (
+	"sync"
+	"time"
+)
+
+// finallyMsg registers cleanup commands with the session.
+type finallyMsg struct{ cmds []Cmd }
+
+// Finally returns a command that registers cleanup commands. They run once
+// the session stops (after QuitMsg and OnQuit, before the terminal is
+// restored), concurrently and within the shutdown timeout. Their messages
+// are discarded.
+func Finally(cmds ...Cmd) Cmd {
+	return func() Msg { return finallyMsg{cmds: cmds} }
+}
+
+// WithCleanup registers cleanup commands at construction; see Finally.
+func WithCleanup(cmds ...Cmd) Option {
+	return func(p *Session) { p.cleanup = append(p.cleanup, cmds...) }
+}
+
+// WithShutdownTimeout bounds how long cleanup commands may run (default 2s).
+func WithShutdownTimeout(d time.Duration) Option {
+	return func(p *Session) {
+		if d > 0 {
+			p.shutdownTimeout = d
+		}
+	}
+}
+
+// runCleanup runs the registered cleanup commands and waits for them up to
+// the shutdown timeout.
+func (p *Session) runCleanup() {
+	if len(p.cleanup) == 0 {
+		return
+	}
+	var wg sync.WaitGroup
+	for _, c := range p.cleanup {
+		if c == nil {
+			continue
+		}
+		wg.Add(1)
+		go func(c Cmd) {
+			defer wg.Done()
+			defer func() {
+				if r := recover(); r != nil {
+					p.logger.Errorf("cleanup panic: %v", r)
+				}
+			}()
+			c()
+		}(c)
+	}
+	done := make(chan struct{})
+	go func() { wg.Wait(); close(done) }()
+	select {
+	case <-done:
+	case <-time.After(p.shutdownTimeout):
+		p.logger.Warnf("cleanup did not finish within %v", p.shutdownTimeout)
+	}
+}
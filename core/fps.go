@@ -0,0 +1,32 @@
+package core
+
+import "time"
+
+// WithFPS caps the frames the session draws after messages at n per
+// second, for terminals that struggle with fast animations or output sent
+// over slow links. A frame that comes due too soon is deferred and the
+// latest state drawn when the next one is allowed. 0, the default, draws a
+// frame for every message.
+func WithFPS(n int) Option { return func(p *Session) { p.fps = n } }
+
+// fpsMsg is sent when a frame deferred by the FPS cap may be drawn.
+type fpsMsg struct{}
+
+// fpsWait returns how long until the FPS cap allows the next frame.
+func (p *Session) fpsWait() time.Duration {
+	if p.fps <= 0 {
+		return 0
+	}
+	return p.lastFrame.Add(time.Second / time.Duration(p.fps)).Sub(p.clock.Now())
+}
+
+// armFPS arranges for the loop to wake when the next frame is allowed.
+func (p *Session) armFPS() {
+	if p.fpsArmed {
+		return
+	}
+	if d := p.fpsWait(); d > 0 {
+		p.fpsArmed = true
+		p.deliverAfter(d, nil, func() Msg { return fpsMsg{} })
+	}
+}
@@ -0,0 +1,92 @@
+package core
+
+import "strings"
+
+// Binding describes one key (or group of keys) for the help overlay.
+type Binding struct {
+	Keys string // as shown to the user, e.g. "ctrl+s" or "↑/↓"
+	Help string
+}
+
+// KeyHelper is implemented by models that describe their keys. The help
+// overlay lists them above the session's own keys.
+type KeyHelper interface {
+	KeyBindings() []Binding
+}
+
+// helpKey opens and closes the help overlay; Esc also closes it.
+const helpKey = "?"
+
+// WithHelp shows a key binding overlay when the user presses '?', built
+// from the model's KeyBindings (see KeyHelper) and the session's own keys.
+// Esc or '?' dismisses it; other keys are swallowed while it is open,
+// except Ctrl+C. Models that take free text input should leave this off or
+// bind help elsewhere.
+func WithHelp() Option { return func(p *Session) { p.help = true } }
+
+var helpStyle = NewStyle().Reversed()
+
+// helpKeyPress handles k for the help overlay and reports whether it was
+// consumed.
+func (p *Session) helpKeyPress(k KeyMsg) bool {
+	if !p.helpVisible {
+		if k.String != helpKey {
+			return false
+		}
+		p.helpVisible = true
+		return true
+	}
+	if k.Type == KeyCtrlC {
+		return false
+	}
+	if k.Type == KeyEsc || k.String == helpKey {
+		p.helpVisible = false
+	}
+	return true
+}
+
+// bindings lists the model's keys followed by the session's.
+func (p *Session) bindings() []Binding {
+	var b []Binding
+	if kh, ok := p.m.(KeyHelper); ok {
+		b = append(b, kh.KeyBindings()...)
+	}
+	b = append(b, Binding{Keys: "?", Help: "toggle this help"})
+	if p.debugOverlay {
+		b = append(b, Binding{Keys: "ctrl+g", Help: "toggle debug overlay"})
+	}
+	return append(b, Binding{Keys: "ctrl+c", Help: "quit"})
+}
+
+// withHelp draws the help box centered over view.
+func (p *Session) withHelp(view string) string {
+	b := p.bindings()
+	keyW := 0
+	for _, kb := range b {
+		keyW = max(keyW, displayWidth(kb.Keys))
+	}
+	rows := make([]string, 0, len(b)+4)
+	rows = append(rows, " Keys", "")
+	for _, kb := range b {
+		rows = append(rows, " "+kb.Keys+strings.Repeat(" ", keyW-displayWidth(kb.Keys))+"  "+kb.Help)
+	}
+	rows = append(rows, "", " esc to close")
+	w := 0
+	for _, r := range rows {
+		w = max(w, displayWidth(r)+1)
+	}
+	for i, r := range rows {
+		rows[i] = helpStyle.Render(r + strings.Repeat(" ", w-displayWidth(r)))
+	}
+
+	width, height := p.width, p.height
+	if width <= 0 {
+		width = 80
+	}
+	if height <= 0 {
+		height = 24
+	}
+	x := max((width-w)/2, 0)
+	y := max((height-len(rows))/2, 0)
+	return Overlay(view, strings.Join(rows, "\n"), x, y)
+}
@@ -0,0 +1,158 @@
+package core
+
+// UndoMsg asks a History wrapper to restore the previous snapshot.
+type UndoMsg struct{}
+
+// RedoMsg asks a History wrapper to re-apply an undone snapshot.
+type RedoMsg struct{}
+
+// Undo returns a command that emits UndoMsg.
+func Undo() Cmd { return func() Msg { return UndoMsg{} } }
+
+// Redo returns a command that emits RedoMsg.
+func Redo() Cmd { return func() Msg { return RedoMsg{} } }
+
+// Snapshotter lets models holding reference types (slices, maps, pointers)
+// provide a deep copy for History. Value-only models don't need it.
+type Snapshotter interface {
+	Snapshot() Model
+}
+
+// HistoryOption configures WithHistory.
+type HistoryOption func(*History)
+
+// HistoryLimit caps the number of snapshots kept (default 100).
+func HistoryLimit(n int) HistoryOption {
+	return func(h *History) {
+		if n > 0 {
+			h.limit = n
+		}
+	}
+}
+
+// HistoryKeys sets the undo/redo keys, compared with KeyMsg.String
+// (default Ctrl+Z / Ctrl+Y).
+func HistoryKeys(undo, redo string) HistoryOption {
+	return func(h *History) { h.undoKey, h.redoKey = undo, redo }
+}
+
+// HistoryFilter selects which messages record a snapshot (default: keys,
+// pastes and mouse presses — user edits, not ticks or resizes).
+func HistoryFilter(fn func(Msg) bool) HistoryOption {
+	return func(h *History) {
+		if fn != nil {
+			h.record = fn
+		}
+	}
+}
+
+// History wraps a model and snapshots it before each recorded Update,
+// bound to undo/redo keys. It is itself a Model.
+type History struct {
+	model   Model
+	past    []Model
+	future  []Model
+	limit   int
+	undoKey string
+	redoKey string
+	record  func(Msg) bool
+}
+
+// WithHistory wraps m with undo/redo support.
+func WithHistory(m Model, opts ...HistoryOption) *History {
+	h := &History{
+		model:   m,
+		limit:   100,
+		undoKey: "\x1a",
+		redoKey: "\x19",
+		record:  defaultHistoryFilter,
+	}
+	for _, o := range opts {
+		o(h)
+	}
+	return h
+}
+
+func defaultHistoryFilter(msg Msg) bool {
+	switch msg := msg.(type) {
+	case KeyMsg, PasteMsg:
+		return true
+	case MouseMsg:
+		return msg.Action == MousePress
+	}
+	return false
+}
+
+// Model returns the wrapped model.
+func (h *History) Model() Model { return h.model }
+
+// CanUndo reports whether a previous snapshot exists.
+func (h *History) CanUndo() bool { return len(h.past) > 0 }
+
+// CanRedo reports whether an undone snapshot can be re-applied.
+func (h *History) CanRedo() bool { return len(h.future) > 0 }
+
+func (h *History) Init() Cmd    { return h.model.Init() }
+func (h *History) View() string { return h.model.View() }
+
+func (h *History) Update(msg Msg) (Model, Cmd) {
+	switch msg := msg.(type) {
+	case UndoMsg:
+		h.undo()
+		return h, nil
+	case RedoMsg:
+		h.redo()
+		return h, nil
+	case KeyMsg:
+		switch msg.String {
+		case h.undoKey:
+			h.undo()
+			return h, nil
+		case h.redoKey:
+			h.redo()
+			return h, nil
+		}
+	}
+
+	if !h.record(msg) {
+		var cmd Cmd
+		h.model, cmd = h.model.Update(msg)
+		return h, cmd
+	}
+
+	prev := snapshot(h.model)
+	var cmd Cmd
+	h.model, cmd = h.model.Update(msg)
+	h.past = append(h.past, prev)
+	if len(h.past) > h.limit {
+		h.past[0] = nil
+		h.past = h.past[1:]
+	}
+	h.future = h.future[:0]
+	return h, cmd
+}
+
+func (h *History) undo() {
+	if len(h.past) == 0 {
+		return
+	}
+	h.future = append(h.future, snapshot(h.model))
+	h.model = h.past[len(h.past)-1]
+	h.past = h.past[:len(h.past)-1]
+}
+
+func (h *History) redo() {
+	if len(h.future) == 0 {
+		return
+	}
+	h.past = append(h.past, snapshot(h.model))
+	h.model = h.future[len(h.future)-1]
+	h.future = h.future[:len(h.future)-1]
+}
+
+func snapshot(m Model) Model {
+	if s, ok := m.(Snapshotter); ok {
+		return s.Snapshot()
+	}
+	return m
+}
@@ -0,0 +1,110 @@
+package core
+
+import "time"
+
+// IdleMsg is sent when the session goes idle; see WithIdle.
+type IdleMsg struct{}
+
+// ActiveMsg is sent when an idle session becomes active again: before the
+// input that woke it, or after a message that changed the view.
+type ActiveMsg struct{}
+
+// WithIdle puts the session to sleep after d without activity, for
+// dashboards left open for hours: the model gets an IdleMsg, and TickMsg
+// and TimerMsg deliveries are held back, which pauses animations driven by
+// Tick. Activity is input (keys, mouse, pastes, resizes), or any other
+// message, such as the result of a fetch, after which the view changed;
+// ticks and timers never count, so an animation alone does not keep the
+// session awake. On the next activity the model gets an ActiveMsg and then
+// the held messages, one per tick and timer, timestamped on delivery.
+func WithIdle(d time.Duration) Option { return func(p *Session) { p.idleAfter = d } }
+
+// idleCheckMsg is sent when the session may have gone idle.
+type idleCheckMsg struct{}
+
+// startIdle begins tracking activity.
+func (p *Session) startIdle() {
+	if p.idleAfter <= 0 {
+		return
+	}
+	p.lastActive = p.clock.Now()
+	p.armIdleCheck(p.idleAfter)
+}
+
+func (p *Session) armIdleCheck(d time.Duration) {
+	p.idleArmed = true
+	p.deliverAfter(d, nil, func() Msg { return idleCheckMsg{} })
+}
+
+// idleCheck puts the session to sleep if it has been inactive long enough,
+// and otherwise checks again when it might have.
+func (p *Session) idleCheck() {
+	p.idleArmed = false
+	if p.idle {
+		return
+	}
+	if rest := p.idleAfter - p.clock.Now().Sub(p.lastActive); rest > 0 {
+		p.armIdleCheck(rest)
+		return
+	}
+	p.idle = true
+	cmd := p.update(IdleMsg{})
+	p.render()
+	p.exec(cmd)
+}
+
+// isInput reports whether msg comes from the user or the terminal.
+func isInput(msg Msg) bool {
+	switch msg.(type) {
+	case KeyMsg, MouseMsg, PasteMsg, PasteStartMsg, PasteChunkMsg, PasteEndMsg,
+		CompositionMsg, ResizeMsg:
+		return true
+	}
+	return false
+}
+
+// isTimed reports whether msg is a tick or timer.
+func isTimed(msg Msg) bool {
+	switch msg.(type) {
+	case TickMsg, TimerMsg:
+		return true
+	}
+	return false
+}
+
+// holdIdle keeps msg back if it is a tick or timer arriving while the
+// session is idle, and reports whether it did.
+func (p *Session) holdIdle(msg Msg) bool {
+	if !p.idle || !isTimed(msg) {
+		return false
+	}
+	p.idleHeld = append(p.idleHeld, msg)
+	return true
+}
+
+// active records activity, waking the session if it is idle.
+func (p *Session) active() {
+	if p.idleAfter <= 0 {
+		return
+	}
+	p.lastActive = p.clock.Now()
+	if !p.idleArmed {
+		p.armIdleCheck(p.idleAfter)
+	}
+	if !p.idle {
+		return
+	}
+	p.idle = false
+	cmd := p.update(ActiveMsg{})
+	p.render()
+	p.exec(cmd)
+	held := p.idleHeld
+	p.idleHeld = nil
+	for _, msg := range held {
+		if _, ok := msg.(TickMsg); ok {
+			p.exec(func() Msg { return TickMsg{At: p.clock.Now()} })
+			continue
+		}
+		p.exec(func() Msg { return msg })
+	}
+}
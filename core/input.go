@@ -1,323 +1,254 @@
 package core
 
 import (
-	"bufio"
 	"bytes"
 	"context"
 	"io"
-	"os"
-	"strconv"
-	"unicode"
+	"time"
 	"unicode/utf8"
-
-	"golang.org/x/term"
 )
 
 type input struct {
-	oldState *term.State
-	inFile   *os.File // raw mode only if non-nil
-	reader   io.Reader
+	unraw      func() error // restores the mode raw changed; nil if none
+	reader     io.Reader
+	escTimeout time.Duration
+	pasteChunk int
+	keys       KeyTranslations
+	keypad     bool // report KeyKP* keys rather than the characters
 }
 
 func newInput(r io.Reader) *input {
-	var f *os.File
-	if rf, ok := r.(*os.File); ok {
-		f = rf
-	}
-	return &input{inFile: f, reader: r}
+	return &input{reader: r, escTimeout: escapeTimeout}
 }
 
-func (i *input) raw() error {
-	if i.inFile == nil {
-		// cannot enter raw mode (non-tty reader)
-		return nil
-	}
-	fd := int(i.inFile.Fd())
-	state, err := term.MakeRaw(fd)
+// raw puts t into raw mode until restore.
+func (i *input) raw(t Terminal) error {
+	unraw, err := t.MakeRaw()
 	if err != nil {
 		return err
 	}
-	i.oldState = state
-	enableVirtualTerminal()
+	i.unraw = unraw
 	return nil
 }
 
 func (i *input) restore() {
-	if i.oldState != nil && i.inFile != nil {
-		_ = term.Restore(int(i.inFile.Fd()), i.oldState)
+	if i.unraw != nil {
+		_ = i.unraw()
+		i.unraw = nil
 	}
 }
 
-func (i *input) readKeys(ctx context.Context, ch chan<- Msg) {
-	r := bufio.NewReader(i.reader)
-	for {
-		select {
-		case <-ctx.Done():
-			return
-		default:
-			b, err := r.ReadByte()
-			if err != nil {
-				return
-			}
+// Default waits for the rest of a sequence split across reads.
+const (
+	composeTimeout = 100 * time.Millisecond // UTF-8 characters
+	escapeTimeout  = 50 * time.Millisecond  // escape sequences; also the Esc key delay
+)
 
-			switch b {
-			case 3:
-				ch <- KeyMsg{Type: KeyCtrlC, String: "\x03", Ctrl: true}
-				continue
-			case '\r', '\n':
-				ch <- KeyMsg{Type: KeyEnter, String: "\r"}
-				continue
-			case 8, 127:
-				ch <- KeyMsg{Type: KeyBackspace, String: string(b)}
-				continue
-			case 9:
-				ch <- KeyMsg{Type: KeyTab, String: "\t"}
-				continue
-			case ' ':
-				ch <- KeyMsg{Type: KeySpace, Rune: ' ', String: " "}
-				continue
-			case 'q', 'Q':
-				ch <- KeyMsg{Type: KeyQ, Rune: rune(b), String: string(b)}
-				continue
-			case 27: // ESC: CSI, Alt+key, SGR mouse, bracketed paste
-				if m := i.readEscape(r); m != nil {
-					ch <- m
-				}
-				continue
-			}
+// decoder is the input state machine. Bytes are fed as they arrive and
+// complete events are taken out; bytes of an incomplete sequence stay
+// pending until more input completes them or the caller gives up waiting
+// (deadline) and flushes.
+type decoder struct {
+	buf        []byte
+	composing  bool // an incomplete UTF-8 character is pending
+	escWait    time.Duration
+	pasteChunk int  // stream pastes in chunks of this size; 0 = one PasteMsg
+	pasting    bool // between PasteStartMsg and PasteEndMsg
+	keys       KeyTranslations
+	keypad     bool
+}
 
-			// Other control bytes: ignore
-			if b < 0x20 || b == 0x7f {
-				continue
-			}
+func (d *decoder) feed(chunk []byte) { d.buf = trimPaste(append(d.buf, chunk...)) }
 
-			// UTF-8 rune
-			buf := []byte{b}
-			if !utf8.FullRune(buf) {
-				for r.Buffered() > 0 && !utf8.FullRune(buf) {
-					nb, _ := r.ReadByte()
-					buf = append(buf, nb)
-				}
-			}
-			if ru, _ := utf8.DecodeRune(buf); ru != utf8.RuneError && !unicode.IsControl(ru) {
-				ch <- KeyMsg{Type: KeyRune, Rune: ru, String: string(ru)}
-			}
+// next returns the next complete event. ok is false when nothing complete
+// is pending; msg may be nil for input that decodes to nothing.
+func (d *decoder) next() (msg Msg, ok bool) {
+	if d.pasteChunk > 0 {
+		if d.pasting {
+			return d.nextPaste(false)
+		}
+		if bytes.HasPrefix(d.buf, pasteStart) {
+			d.buf = d.buf[len(pasteStart):]
+			d.pasting = true
+			return PasteStartMsg{}, true
 		}
 	}
+	if d.keys != nil {
+		msg, n, wait := d.keys.translate(d.buf)
+		if wait {
+			return nil, false
+		}
+		if n > 0 {
+			d.buf = d.buf[n:]
+			return msg, true
+		}
+	}
+	msg, n := parseInput(d.buf, false)
+	if n == 0 {
+		return nil, false
+	}
+	d.buf = d.buf[n:]
+	return d.keypadKey(msg), true
 }
 
-// readEscape decodes sequences after ESC. It can return KeyMsg, MouseMsg, PasteMsg.
-func (i *input) readEscape(r *bufio.Reader) Msg {
-	if r.Buffered() == 0 {
-		return KeyMsg{Type: KeyEsc, String: "\x1b"}
+// flush resolves the pending bytes as they are. ok is false if they
+// cannot be resolved yet (a paste without its end marker).
+func (d *decoder) flush() (msg Msg, ok bool) {
+	if d.pasting {
+		return d.nextPaste(true)
+	}
+	if msg, n, _ := d.keys.translate(d.buf); n > 0 {
+		d.buf = d.buf[n:]
+		return msg, true
 	}
+	msg, n := parseInput(d.buf, true)
+	if n == 0 {
+		return nil, false
+	}
+	d.buf = d.buf[n:]
+	return d.keypadKey(msg), true
+}
 
-	nb, _ := r.ReadByte()
-	switch nb {
-	case '[':
-		// Check for bracketed paste start: ESC [ 200 ~
-		if i.peekSeq(r, "200~") {
-			_, _ = r.Discard(len("200~")) // FIX: discard returns (int, error)
-			return i.readBracketedPaste(r)
-		}
-		// SGR mouse starts with '<'
-		if i.peekByte(r, '<') {
-			_, _ = r.ReadByte() // consume '<'
-			return i.readMouseSGR(r)
-		}
-		// Otherwise parse normal CSI keys
-		return i.readCSI(r)
-	default:
-		// Likely Alt+key (Meta). Decode a rune from nb + more bytes if needed.
-		buf := []byte{nb}
-		for r.Buffered() > 0 && !utf8.FullRune(buf) {
-			b, _ := r.ReadByte()
-			buf = append(buf, b)
-		}
-		if ru, _ := utf8.DecodeRune(buf); ru != utf8.RuneError && !unicode.IsControl(ru) {
-			return KeyMsg{Type: KeyRune, Rune: ru, String: string(ru), Alt: true}
-		}
-		return KeyMsg{Type: KeyEsc, String: "\x1b"}
+func (d *decoder) keypadKey(msg Msg) Msg {
+	if d.keypad {
+		return msg
 	}
+	return keypadAsText(msg)
 }
 
-// readCSI parses a limited set of CSI codes (arrows, home/end, pgup/pgdn, delete).
-func (i *input) readCSI(r *bufio.Reader) Msg {
-	params := []byte{}
-	for {
-		if r.Buffered() == 0 {
-			return KeyMsg{Type: KeyEsc, String: "\x1b"}
+// nextPaste takes the next part of a streamed paste: a full chunk, the end
+// marker, or with partial set whatever is safe to deliver so far.
+func (d *decoder) nextPaste(partial bool) (Msg, bool) {
+	end := bytes.Index(d.buf, pasteEnd)
+	if end == 0 {
+		d.buf = d.buf[len(pasteEnd):]
+		d.pasting = false
+		return PasteEndMsg{}, true
+	}
+	avail := end
+	if end < 0 {
+		// Hold back what could be the start of the end marker.
+		avail = max(len(d.buf)-(len(pasteEnd)-1), 0)
+	}
+	n := min(avail, d.pasteChunk)
+	if n == 0 || (n < d.pasteChunk && end < 0 && !partial) {
+		return nil, false
+	}
+	if n < len(d.buf) {
+		// Don't split a UTF-8 character across chunks.
+		cut := n
+		for cut > 0 && !utf8.RuneStart(d.buf[cut]) {
+			cut--
 		}
-		b, _ := r.ReadByte()
-		switch b {
-		case 'A':
-			return KeyMsg{Type: KeyUp, String: "\x1b[A"}
-		case 'B':
-			return KeyMsg{Type: KeyDown, String: "\x1b[B"}
-		case 'C':
-			return KeyMsg{Type: KeyRight, String: "\x1b[C"}
-		case 'D':
-			return KeyMsg{Type: KeyLeft, String: "\x1b[D"}
-		case 'H':
-			return KeyMsg{Type: KeyHome, String: "\x1b[H"}
-		case 'F':
-			return KeyMsg{Type: KeyEnd, String: "\x1b[F"}
-		case '~':
-			switch string(params) {
-			case "3":
-				return KeyMsg{Type: KeyDelete, String: "\x1b[3~"}
-			case "5":
-				return KeyMsg{Type: KeyPgUp, String: "\x1b[5~"}
-			case "6":
-				return KeyMsg{Type: KeyPgDn, String: "\x1b[6~"}
-			case "2":
-				return KeyMsg{Type: KeyEsc, String: "\x1b[2~"}
-			default:
-				return KeyMsg{Type: KeyEsc, String: "\x1b[" + string(params) + "~"}
-			}
-		default:
-			if (b >= '0' && b <= '9') || b == ';' {
-				params = append(params, b)
-				continue
-			}
-			return KeyMsg{Type: KeyEsc, String: "\x1b[" + string(params) + string(b)}
+		if cut > 0 {
+			n = cut
 		}
 	}
+	text := string(d.buf[:n])
+	d.buf = d.buf[n:]
+	return PasteChunkMsg{Text: text}, true
 }
 
-// readMouseSGR parses SGR mouse events after "<" in the sequence ESC[<b;x;y(M|m)
-func (i *input) readMouseSGR(r *bufio.Reader) Msg {
-	readNum := func() (int, bool) {
-		var buf bytes.Buffer
-		for {
-			if r.Buffered() == 0 {
-				break
-			}
-			b, _ := r.ReadByte()
-			if b >= '0' && b <= '9' {
-				buf.WriteByte(b)
-				continue
-			}
-			_ = r.UnreadByte()
-			break
-		}
-		if buf.Len() == 0 {
-			return 0, false
-		}
-		v, err := strconv.Atoi(buf.String())
-		return v, err == nil
+// wait returns how long to wait for the rest of the pending sequence, or
+// -1 to wait indefinitely.
+func (d *decoder) wait() time.Duration {
+	switch {
+	case len(d.buf) == 0:
+		return -1
+	case d.pasting:
+		if len(d.buf) >= len(pasteEnd) {
+			return 0 // deliver what we have unless more is already queued
+		}
+		return -1
+	case d.buf[0] >= 0x80:
+		return composeTimeout
+	case bytes.HasPrefix(d.buf, pasteStart):
+		return -1
 	}
+	return d.escWait
+}
 
-	// <b ; x ; y (M|m)
-	if b, ok := readNum(); ok {
-		if c, _ := r.ReadByte(); c != ';' {
-			return KeyMsg{Type: KeyEsc, String: "\x1b"}
-		}
-		x, okx := readNum()
-		if !okx {
-			return KeyMsg{Type: KeyEsc, String: "\x1b"}
+// readKeys drives a decoder from the input stream. It emits
+// CompositionMsg around waits for a split UTF-8 character.
+func (i *input) readKeys(ctx context.Context, ch chan<- Msg) {
+	src := newByteSource(ctx, i.reader)
+	defer src.stop()
+	d := &decoder{escWait: i.escTimeout, pasteChunk: i.pasteChunk, keys: i.keys, keypad: i.keypad}
+	send := func(msg Msg) {
+		select {
+		case ch <- msg:
+		case <-ctx.Done():
 		}
-		if c, _ := r.ReadByte(); c != ';' {
-			return KeyMsg{Type: KeyEsc, String: "\x1b"}
+	}
+	emit := func(msg Msg) {
+		if d.composing {
+			d.composing = false
+			send(CompositionMsg{Active: false})
 		}
-		y, oky := readNum()
-		if !oky {
-			return KeyMsg{Type: KeyEsc, String: "\x1b"}
+		if msg != nil {
+			send(msg)
 		}
-		final, _ := r.ReadByte() // 'M' press/drag, 'm' release
-
-		shift := (b & 4) != 0
-		alt := (b & 8) != 0
-		ctrl := (b & 16) != 0
-
-		btn := MouseUnknown
-		act := MousePress
+	}
 
-		// Wheel
-		if (b & 64) != 0 {
-			act = MouseWheel
-			if (b & 1) == 1 {
-				btn = MouseWheelDown
-			} else {
-				btn = MouseWheelUp
-			}
-		} else {
-			switch b & 3 {
-			case 0:
-				btn = MouseLeft
-			case 1:
-				btn = MouseMiddle
-			case 2:
-				btn = MouseRight
-			default:
-				btn = MouseUnknown
-			}
-			if final == 'm' {
-				act = MouseRelease
-			} else if (b & 32) != 0 {
-				act = MouseDrag
-			} else {
-				act = MousePress
-			}
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		default:
 		}
 
-		return MouseMsg{
-			Button: btn,
-			Action: act,
-			X:      x,
-			Y:      y,
-			Alt:    alt,
-			Ctrl:   ctrl,
-			Shift:  shift,
+		for {
+			msg, ok := d.next()
+			if !ok {
+				break
+			}
+			emit(msg)
 		}
-	}
 
-	return KeyMsg{Type: KeyEsc, String: "\x1b"}
-}
-
-// readBracketedPaste reads until ESC[201~ and returns the pasted payload.
-
-const maxPaste = 1 << 20 // 1 MiB
-func (i *input) readBracketedPaste(r *bufio.Reader) Msg {
-	var buf bytes.Buffer
-	for {
-		b, err := r.ReadByte()
-		if err != nil { break }
-		if buf.Len() >= maxPaste {
-			if b == 27 && i.peekSeq(r, "[201~") {
-				_, _ = r.Discard(len("[201~"))
-				break
+		wait := d.wait()
+		if wait < 0 {
+			chunk, ok := src.next()
+			if !ok {
+				return
 			}
+			d.feed(chunk)
 			continue
 		}
-		if b == 27 { // ESC
-			if i.peekSeq(r, "[201~") {
-				_, _ = r.Discard(len("[201~")) // FIX: discard returns (int, error)
-				break
+		if !d.pasting && d.buf[0] >= 0x80 && !d.composing {
+			d.composing = true
+			send(CompositionMsg{Active: true})
+		}
+		chunk, ok, closed := src.nextTimeout(wait)
+		switch {
+		case ok:
+			d.feed(chunk)
+		case closed:
+			for len(d.buf) > 0 {
+				msg, ok := d.flush()
+				if !ok {
+					return
+				}
+				emit(msg)
+			}
+			return
+		default: // timed out
+			if msg, ok := d.flush(); ok {
+				emit(msg)
 			}
-			// Not the end sequence: include ESC and continue
-			buf.WriteByte(b)
-			continue
 		}
-		buf.WriteByte(b)
 	}
-	return PasteMsg{Text: buf.String()}
 }
 
-// helpers
-
-func (i *input) peekSeq(r *bufio.Reader, s string) bool {
-	if r.Buffered() < len(s) {
-		return false
+// trimPaste bounds the memory an unterminated paste can hold: bytes past
+// maxPaste are dropped, keeping the tail so the end marker is still found.
+func trimPaste(buf []byte) []byte {
+	limit := len(pasteStart) + maxPaste
+	keep := len(pasteEnd) - 1
+	if len(buf) <= limit+keep || !bytes.HasPrefix(buf, pasteStart) {
+		return buf
 	}
-	bs, err := r.Peek(len(s))
-	return err == nil && string(bs) == s
-}
-
-func (i *input) peekByte(r *bufio.Reader, b byte) bool {
-	if r.Buffered() < 1 {
-		return false
+	if end := bytes.Index(buf[limit:], pasteEnd); end >= 0 {
+		return append(buf[:limit], buf[limit+end:]...)
 	}
-	bs, err := r.Peek(1)
-	return err == nil && bs[0] == b
+	return append(buf[:limit], buf[len(buf)-keep:]...)
 }
@@ -0,0 +1,373 @@
+package core
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"net"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// InspectEnv, when set, enables the inspector without code changes: "1"
+// serves it on the default socket, anything else names the socket path.
+// It lets a deployed program be inspected by restarting it with the
+// variable set.
+const InspectEnv = "FROG_INSPECT"
+
+// Inspector requests. Each request is a line {"type": ...} and gets one
+// InspectReply line back; an empty type asks for everything.
+const (
+	InspectModel    = "model"    // the model's state
+	InspectMessages = "messages" // recently processed messages, oldest first
+	InspectStats    = "stats"    // render and queue figures
+)
+
+// Debuggable lets a model choose what the inspector shows as its state.
+// DebugState runs on the session loop; its result must encode as JSON.
+// Models without it are shown by reflection, unexported fields included.
+type Debuggable interface {
+	DebugState() any
+}
+
+// InputHider lets a model that reads secrets, such as a password prompt,
+// keep them out of the inspector: while HidingInput reports true, key
+// runes and pasted text are redacted from the recorded messages. Such a
+// model should also implement Debuggable so its state is not shown by
+// reflection.
+type InputHider interface {
+	HidingInput() bool
+}
+
+// InspectReply answers one inspector request.
+type InspectReply struct {
+	Error    string          `json:"error,omitempty"`
+	Model    json.RawMessage `json:"model,omitempty"`
+	Messages []InspectedMsg  `json:"messages,omitempty"`
+	Stats    *InspectedStats `json:"stats,omitempty"`
+}
+
+// InspectedMsg is a message the session processed.
+type InspectedMsg struct {
+	Time time.Time `json:"time"`
+	Type string    `json:"type"`
+	Text string    `json:"text"` // the message printed with %+v, shortened
+}
+
+// InspectedStats are the session's figures as of the last frame.
+type InspectedStats struct {
+	FPS        float64       `json:"fps"`
+	Frames     int64         `json:"frames"`
+	LastBytes  int64         `json:"last_bytes"`
+	UpdateTime time.Duration `json:"update_ns"`
+	ViewTime   time.Duration `json:"view_ns"`
+	Queue      int           `json:"queue"`
+	QueueCap   int           `json:"queue_cap"`
+	Width      int           `json:"width"`
+	Height     int           `json:"height"`
+	LastFrame  time.Time     `json:"last_frame"`
+}
+
+// WithInspector serves the inspector on a unix socket at path while the
+// session runs, for `frog inspect` to query the model state, recent
+// messages and render stats, even over SSH on a stuck program. An empty
+// path uses DefaultInspectPath. The socket is only accessible to the
+// user running the program and is removed on exit. An existing file at
+// path is only replaced if it is a socket.
+func WithInspector(path string) Option {
+	return func(p *Session) {
+		if path == "" {
+			path = DefaultInspectPath()
+		}
+		p.inspectPath = path
+	}
+}
+
+// DefaultInspectPath is the inspector socket used when no path is given:
+// frog-<pid>.sock in the temporary directory.
+func DefaultInspectPath() string {
+	return filepath.Join(os.TempDir(), "frog-"+strconv.Itoa(os.Getpid())+".sock")
+}
+
+// WithoutInspector keeps the inspector off, even when InspectEnv is set,
+// for programs whose state must not be readable from outside.
+func WithoutInspector() Option { return func(p *Session) { p.noInspector = true } }
+
+func inspectPathFromEnv(v, def string) string {
+	switch v {
+	case "":
+		return def
+	case "1":
+		return DefaultInspectPath()
+	}
+	return v
+}
+
+// inspectTimeout bounds how long a model request waits for a busy loop.
+const inspectTimeout = 2 * time.Second
+
+// inspectHistory is how many processed messages the inspector keeps.
+const inspectHistory = 64
+
+// inspector holds what can be read without the session loop, so a stuck
+// loop can still be diagnosed.
+type inspector struct {
+	mu    sync.Mutex
+	msgs  []InspectedMsg // ring of the last inspectHistory messages
+	next  int
+	stats InspectedStats
+}
+
+// record adds msg to the ring, redacting typed and pasted text if hidden.
+func (in *inspector) record(now time.Time, msg Msg, hidden bool) {
+	if hidden {
+		msg = redact(msg)
+	}
+	text := fmt.Sprintf("%+v", msg)
+	if len(text) > 200 {
+		text = text[:200] + "…"
+	}
+	m := InspectedMsg{Time: now, Type: fmt.Sprintf("%T", msg), Text: text}
+	in.mu.Lock()
+	defer in.mu.Unlock()
+	if len(in.msgs) < inspectHistory {
+		in.msgs = append(in.msgs, m)
+		return
+	}
+	in.msgs[in.next] = m
+	in.next = (in.next + 1) % inspectHistory
+}
+
+// redact blanks the text a key or paste message carries.
+func redact(msg Msg) Msg {
+	switch m := msg.(type) {
+	case KeyMsg:
+		if m.Rune != 0 {
+			m.Rune, m.String = '*', "*"
+		}
+		return m
+	case PasteMsg:
+		return PasteMsg{Text: "<redacted>"}
+	case PasteChunkMsg:
+		return PasteChunkMsg{Text: "<redacted>"}
+	}
+	return msg
+}
+
+func (in *inspector) messages() []InspectedMsg {
+	in.mu.Lock()
+	defer in.mu.Unlock()
+	return append(append([]InspectedMsg(nil), in.msgs[in.next:]...), in.msgs[:in.next]...)
+}
+
+// frame records the figures of a rendered frame.
+func (in *inspector) frame(s InspectedStats) {
+	in.mu.Lock()
+	s.Frames = in.stats.Frames + 1
+	in.stats = s
+	in.mu.Unlock()
+}
+
+func (in *inspector) getStats() InspectedStats {
+	in.mu.Lock()
+	defer in.mu.Unlock()
+	return in.stats
+}
+
+// inspectMsg asks the loop for the model's state, encoded as JSON.
+type inspectMsg struct{ reply chan<- json.RawMessage }
+
+// startInspector listens on the inspector socket until the session stops.
+func (p *Session) startInspector() error {
+	if fi, err := os.Lstat(p.inspectPath); err == nil {
+		if fi.Mode().Type() != fs.ModeSocket {
+			return fmt.Errorf("%s exists and is not a socket", p.inspectPath)
+		}
+		_ = os.Remove(p.inspectPath) // a stale socket from a crashed run
+	}
+	l, err := net.Listen("unix", p.inspectPath)
+	if err != nil {
+		return err
+	}
+	if err := os.Chmod(p.inspectPath, 0o600); err != nil {
+		l.Close()
+		return err
+	}
+	p.wg.Add(1)
+	go func() {
+		defer p.wg.Done()
+		<-p.ctx.Done()
+		l.Close()
+		os.Remove(p.inspectPath)
+	}()
+	go func() {
+		for {
+			conn, err := l.Accept()
+			if err != nil {
+				if !errors.Is(err, net.ErrClosed) {
+					p.logger.Errorf("inspector: %v", err)
+				}
+				return
+			}
+			go p.serveInspectConn(conn)
+		}
+	}()
+	return nil
+}
+
+func (p *Session) serveInspectConn(conn net.Conn) {
+	defer conn.Close()
+	sc := bufio.NewScanner(conn)
+	enc := json.NewEncoder(conn)
+	for sc.Scan() {
+		var req struct{ Type string }
+		if err := json.Unmarshal(sc.Bytes(), &req); err != nil {
+			enc.Encode(InspectReply{Error: err.Error()})
+			continue
+		}
+		if err := enc.Encode(p.inspect(req.Type)); err != nil {
+			return
+		}
+	}
+}
+
+func (p *Session) inspect(what string) InspectReply {
+	switch what {
+	case "", InspectModel, InspectMessages, InspectStats:
+	default:
+		return InspectReply{Error: fmt.Sprintf("unknown request %q", what)}
+	}
+	var rep InspectReply
+	all := what == ""
+	if all || what == InspectStats {
+		st := p.inspector.getStats()
+		st.Queue, st.QueueCap = len(p.msgCh), cap(p.msgCh)
+		rep.Stats = &st
+	}
+	if all || what == InspectMessages {
+		rep.Messages = p.inspector.messages()
+	}
+	if all || what == InspectModel {
+		rep.Model, rep.Error = p.inspectModel()
+	}
+	return rep
+}
+
+// inspectModel asks the loop for the model's state, giving up if the loop
+// does not answer in time.
+func (p *Session) inspectModel() (json.RawMessage, string) {
+	reply := make(chan json.RawMessage, 1)
+	timeout := time.NewTimer(inspectTimeout)
+	defer timeout.Stop()
+	select {
+	case p.msgCh <- inspectMsg{reply: reply}:
+	case <-p.done:
+		return nil, "session stopped"
+	case <-timeout.C:
+		return nil, "message queue full: the session loop is not reading messages"
+	}
+	select {
+	case m := <-reply:
+		return m, ""
+	case <-p.done:
+		return nil, "session stopped"
+	case <-timeout.C:
+		return nil, "the session loop did not answer within " + inspectTimeout.String() + "; it may be stuck in Update or View"
+	}
+}
+
+// debugState encodes m's state as JSON.
+func debugState(m Model) json.RawMessage {
+	var v any
+	if d, ok := m.(Debuggable); ok {
+		v = d.DebugState()
+	} else {
+		v = dump(reflect.ValueOf(m), 0, map[uintptr]bool{})
+	}
+	b, err := json.Marshal(v)
+	if err != nil {
+		b, _ = json.Marshal(map[string]string{"error": err.Error()})
+	}
+	return b
+}
+
+// dump converts v to plain values for JSON, including unexported fields,
+// which encoding/json would skip. Deep, cyclic and long values are cut.
+func dump(v reflect.Value, depth int, seen map[uintptr]bool) any {
+	if !v.IsValid() {
+		return nil
+	}
+	if depth > 8 {
+		return "…"
+	}
+	if v.CanInterface() {
+		switch x := v.Interface().(type) {
+		case json.Marshaler, time.Time, time.Duration:
+			return x
+		}
+	}
+	switch v.Kind() {
+	case reflect.Bool:
+		return v.Bool()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return v.Int()
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return v.Uint()
+	case reflect.Float32, reflect.Float64:
+		return v.Float()
+	case reflect.String:
+		return v.String()
+	case reflect.Pointer, reflect.Interface:
+		if v.IsNil() {
+			return nil
+		}
+		if v.Kind() == reflect.Pointer {
+			if seen[v.Pointer()] {
+				return "<cycle>"
+			}
+			seen[v.Pointer()] = true
+			defer delete(seen, v.Pointer())
+		}
+		return dump(v.Elem(), depth+1, seen)
+	case reflect.Struct:
+		out := make(map[string]any, v.NumField())
+		for i := 0; i < v.NumField(); i++ {
+			out[v.Type().Field(i).Name] = dump(v.Field(i), depth+1, seen)
+		}
+		return out
+	case reflect.Slice, reflect.Array:
+		if v.Kind() == reflect.Slice && v.IsNil() {
+			return nil
+		}
+		n := min(v.Len(), 100)
+		out := make([]any, 0, n+1)
+		for i := 0; i < n; i++ {
+			out = append(out, dump(v.Index(i), depth+1, seen))
+		}
+		if v.Len() > n {
+			out = append(out, fmt.Sprintf("… %d more", v.Len()-n))
+		}
+		return out
+	case reflect.Map:
+		if v.IsNil() {
+			return nil
+		}
+		out := make(map[string]any, v.Len())
+		iter := v.MapRange()
+		for i := 0; iter.Next(); i++ {
+			if i == 100 {
+				out["…"] = fmt.Sprintf("%d more", v.Len()-i)
+				break
+			}
+			out[fmt.Sprint(dump(iter.Key(), depth+1, seen))] = dump(iter.Value(), depth+1, seen)
+		}
+		return out
+	}
+	return "<" + v.Type().String() + ">"
+}
@@ -0,0 +1,73 @@
+package core
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// shortTempDir returns a directory whose paths fit in a unix socket name.
+func shortTempDir(t *testing.T) string {
+	t.Helper()
+	dir, err := os.MkdirTemp("", "frog")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { os.RemoveAll(dir) })
+	return dir
+}
+
+func TestInspectorKeepsOtherFiles(t *testing.T) {
+	path := filepath.Join(shortTempDir(t), "notes.txt")
+	if err := os.WriteFile(path, []byte("keep"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	var log recordLogger
+	runSession(t, funcModel{init: Quit}, "", WithInspector(path), WithLogger(&log))
+	if b, err := os.ReadFile(path); err != nil || string(b) != "keep" {
+		t.Fatalf("file = %q, %v", b, err)
+	}
+	if len(log.lines) == 0 || !strings.Contains(log.lines[0], "not a socket") {
+		t.Fatalf("logged %q", log.lines)
+	}
+}
+
+func TestWithoutInspector(t *testing.T) {
+	t.Setenv(InspectEnv, "1")
+	if p := NewSession(funcModel{}, WithoutInspector()); p.inspector != nil || p.inspectPath != "" {
+		t.Fatalf("inspector enabled at %q", p.inspectPath)
+	}
+}
+
+// hidingModel reads a secret until Enter.
+type hidingModel struct{}
+
+func (m hidingModel) Init() Cmd         { return nil }
+func (m hidingModel) View() string      { return "" }
+func (m hidingModel) HidingInput() bool { return true }
+
+func (m hidingModel) Update(msg Msg) (Model, Cmd) {
+	if k, ok := msg.(KeyMsg); ok && k.Type == KeyEnter {
+		return m, Quit()
+	}
+	return m, nil
+}
+
+func TestInspectorRedactsHiddenInput(t *testing.T) {
+	path := filepath.Join(shortTempDir(t), "s")
+	p := runSession(t, hidingModel{}, "hunter2\x1b[200~pasted\x1b[201~\r", WithInspector(path))
+	keys := 0
+	for _, msg := range p.inspector.messages() {
+		if strings.Contains(msg.Text, "hunter") || strings.Contains(msg.Text, "pasted") ||
+			strings.Contains(msg.Text, "String:h") || strings.Contains(msg.Text, "Rune:104") {
+			t.Errorf("secret recorded: %s %s", msg.Type, msg.Text)
+		}
+		if msg.Type == "core.KeyMsg" {
+			keys++
+		}
+	}
+	if keys < 7 {
+		t.Fatalf("recorded %d key messages, want at least 7", keys)
+	}
+}
@@ -0,0 +1,40 @@
+package core
+
+import "testing"
+
+// twiceModel quits on the second interrupt.
+type twiceModel struct{ interrupts *int }
+
+func (m twiceModel) Init() Cmd               { return nil }
+func (m twiceModel) Update(Msg) (Model, Cmd) { return m, nil }
+func (m twiceModel) View() string            { return "" }
+func (m twiceModel) OnInterrupt(InterruptMsg) (Model, Cmd) {
+	if *m.interrupts++; *m.interrupts == 2 {
+		return m, Quit()
+	}
+	return m, nil
+}
+
+func TestCtrlCIsAKeyWithoutInterrupter(t *testing.T) {
+	var keys int
+	m := funcModel{update: func(msg Msg) Cmd {
+		if k, ok := msg.(KeyMsg); ok && k.Type == KeyCtrlC {
+			if keys++; keys == 2 {
+				return Quit()
+			}
+		}
+		return nil
+	}}
+	runSession(t, m, "\x03\x03")
+	if keys != 2 {
+		t.Fatalf("got %d Ctrl+C keys, want 2", keys)
+	}
+}
+
+func TestInterrupterKeepsRunning(t *testing.T) {
+	var n int
+	runSession(t, twiceModel{&n}, "\x03\x03")
+	if n != 2 {
+		t.Fatalf("got %d interrupts, want 2", n)
+	}
+}
@@ -0,0 +1,185 @@
+package core
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+// keyNames are the canonical names of the non-character keys.
+var keyNames = map[KeyType]string{
+	KeyEnter:      "enter",
+	KeyBackspace:  "backspace",
+	KeyEsc:        "esc",
+	KeyUp:         "up",
+	KeyDown:       "down",
+	KeyLeft:       "left",
+	KeyRight:      "right",
+	KeyTab:        "tab",
+	KeySpace:      "space",
+	KeyDelete:     "delete",
+	KeyHome:       "home",
+	KeyEnd:        "end",
+	KeyPgUp:       "pgup",
+	KeyPgDn:       "pgdown",
+	KeyF1:         "f1",
+	KeyF2:         "f2",
+	KeyF3:         "f3",
+	KeyF4:         "f4",
+	KeyF5:         "f5",
+	KeyF6:         "f6",
+	KeyF7:         "f7",
+	KeyF8:         "f8",
+	KeyF9:         "f9",
+	KeyF10:        "f10",
+	KeyF11:        "f11",
+	KeyF12:        "f12",
+	KeyKP0:        "kp0",
+	KeyKP1:        "kp1",
+	KeyKP2:        "kp2",
+	KeyKP3:        "kp3",
+	KeyKP4:        "kp4",
+	KeyKP5:        "kp5",
+	KeyKP6:        "kp6",
+	KeyKP7:        "kp7",
+	KeyKP8:        "kp8",
+	KeyKP9:        "kp9",
+	KeyKPEnter:    "kpenter",
+	KeyKPPlus:     "kpplus",
+	KeyKPMinus:    "kpminus",
+	KeyKPMultiply: "kpmultiply",
+	KeyKPDivide:   "kpdivide",
+	KeyKPDecimal:  "kpdecimal",
+	KeyKPEqual:    "kpequal",
+	KeyKPComma:    "kpcomma",
+}
+
+// keyTypes maps names to key types: the canonical names and a few common
+// spellings.
+var keyTypes = func() map[string]KeyType {
+	m := map[string]KeyType{
+		"escape": KeyEsc, "return": KeyEnter, "pgdn": KeyPgDn,
+		"pageup": KeyPgUp, "pagedown": KeyPgDn, "del": KeyDelete,
+	}
+	for t, name := range keyNames {
+		m[name] = t
+	}
+	return m
+}()
+
+// Canonical returns the key's stable name for keybindings: modifiers in
+// the order ctrl, alt, shift, then the key, as in "ctrl+shift+left",
+// "alt+enter", "f5" or "ctrl+x". Characters are named by themselves, case
+// included ("A", "?", "alt++"), except the space bar, "space". ParseKey
+// reads every name back. Input frog cannot identify, such as unrecognized
+// escape sequences, has the empty name.
+func (k KeyMsg) Canonical() string {
+	var name string
+	switch k.Type {
+	case KeyRune, KeyQ:
+		if k.Rune == 0 {
+			return ""
+		}
+		name = string(k.Rune)
+	case KeyCtrlC:
+		name = "c"
+	case KeyEsc:
+		if len(k.String) > 1 {
+			return "" // an escape sequence the parser did not know
+		}
+		name = "esc"
+	default:
+		var ok bool
+		if name, ok = keyNames[k.Type]; !ok {
+			return ""
+		}
+	}
+	var b strings.Builder
+	if k.Ctrl || k.Type == KeyCtrlC {
+		b.WriteString("ctrl+")
+	}
+	if k.Alt {
+		b.WriteString("alt+")
+	}
+	if k.Shift {
+		b.WriteString("shift+")
+	}
+	b.WriteString(name)
+	return b.String()
+}
+
+// ctrlAliases names the keys Ctrl+H, Ctrl+I, Ctrl+J and Ctrl+M arrive as.
+var ctrlAliases = map[rune]string{'h': "backspace", 'i': "tab", 'j': "enter", 'm': "enter"}
+
+// ParseKey parses a key name such as "ctrl+x", "alt+enter", "shift+tab" or
+// "f5", as written in configuration files, into the KeyMsg the terminal
+// produces for it. It accepts every name Canonical returns, so
+// ParseKey(k.Canonical()) has the same Canonical as k. Modifiers and key
+// names are case-insensitive; a single character is taken as is, and
+// "shift+" on a letter gives the capital. String is set only for
+// characters, so compare parsed keys with received ones by Canonical.
+// "ctrl+h", "ctrl+i", "ctrl+j" and "ctrl+m" are rejected: terminals send
+// them as Backspace, Tab and Enter, so no key press could match them.
+func ParseKey(s string) (KeyMsg, error) {
+	var k KeyMsg
+	rest := s
+	for {
+		mod, after, ok := strings.Cut(rest, "+")
+		if !ok || after == "" {
+			break // the last part; "+" alone or "ctrl++" name the plus key
+		}
+		switch strings.ToLower(mod) {
+		case "ctrl":
+			k.Ctrl = true
+		case "alt":
+			k.Alt = true
+		case "shift":
+			k.Shift = true
+		default:
+			return KeyMsg{}, fmt.Errorf("frog: key %q: unknown modifier %q", s, mod)
+		}
+		rest = after
+	}
+	if rest == "" {
+		return KeyMsg{}, fmt.Errorf("frog: empty key name %q", s)
+	}
+	if utf8.RuneCountInString(rest) == 1 {
+		r, _ := utf8.DecodeRuneInString(rest)
+		if k.Shift && r >= 'a' && r <= 'z' {
+			r, k.Shift = r-'a'+'A', false
+		}
+		if k.Ctrl && r >= 'A' && r <= 'Z' {
+			r += 'a' - 'A'
+		}
+		if k.Ctrl && !k.Alt && !k.Shift && strings.ContainsRune("hijm", r) {
+			return KeyMsg{}, fmt.Errorf("frog: key %q: terminals send it as %s", s, ctrlAliases[r])
+		}
+		switch {
+		case r == ' ':
+			k.Type = KeySpace
+		case r == 'c' && k.Ctrl && !k.Alt && !k.Shift:
+			k.Type = KeyCtrlC
+		case (r == 'q' || r == 'Q') && !k.Ctrl && !k.Alt:
+			k.Type = KeyQ
+		default:
+			k.Type = KeyRune
+		}
+		k.Rune, k.String = r, string(r)
+		if k.Ctrl && !k.Alt && r >= 'a' && r <= 'z' {
+			k.String = string(r - 'a' + 1)
+		}
+		if k.Type == KeyCtrlC {
+			k.Rune = 0
+		}
+		return k, nil
+	}
+	t, ok := keyTypes[strings.ToLower(rest)]
+	if !ok {
+		return KeyMsg{}, fmt.Errorf("frog: key %q: unknown key %q", s, rest)
+	}
+	k.Type = t
+	if t == KeySpace {
+		k.Rune, k.String = ' ', " "
+	}
+	return k, nil
+}
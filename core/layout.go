@@ -1,6 +1,10 @@
 package core
 
-import "strings"
+import (
+	"strings"
+
+	"github.com/pondworks-lib/frog/core/text"
+)
 
 type AlignH int
 type AlignV int
@@ -21,12 +25,71 @@ func Center(block string, boxW, boxH int) string {
 	return PlaceBlock(block, boxW, boxH, AlignCenter, AlignMiddle)
 }
 
+// Overflow is what PlaceBlock does with lines wider than the box.
+type Overflow int
+
+const (
+	OverflowNone     Overflow = iota // leave them as they are (default)
+	OverflowClip                     // cut them at the box edge
+	OverflowEllipsis                 // cut them, ending with "…"
+	OverflowWrap                     // break them onto extra rows
+)
+
+// PlaceOption configures PlaceBlock.
+type PlaceOption func(*placement)
+
+type placement struct {
+	overflow Overflow
+	fill     bool
+	exact    bool
+	style    string // SGR prefix for padding; "" for plain spaces
+}
+
+// WithOverflow sets how lines wider than the box are handled.
+func WithOverflow(o Overflow) PlaceOption { return func(p *placement) { p.overflow = o } }
+
+// WithBoxFill pads every row with spaces to the full box width and adds
+// rows down to its height, so the box covers what was underneath and a
+// background style applied around it stays steady as the block changes.
+func WithBoxFill() PlaceOption { return func(p *placement) { p.fill = true } }
+
+// WithFillStyle draws the padding PlaceBlock adds, the spaces left of
+// aligned lines and, with WithBoxFill, to their right and the blank rows,
+// in style, such as a background color for a panel. The block's own text
+// keeps its styles.
+func WithFillStyle(style Style) PlaceOption {
+	return func(p *placement) { p.style = style.Prefix() }
+}
+
+// WithExactHeight makes PlaceBlock return exactly boxH lines: empty lines
+// are added below the block, and a block taller than the box is cut to it,
+// keeping the rows the vertical alignment favors. A full-screen view placed
+// this way covers every row, so no stale lines are left below it.
+func WithExactHeight() PlaceOption { return func(p *placement) { p.exact = true } }
 
-func PlaceBlock(block string, boxW, boxH int, h AlignH, v AlignV) string {
-	if boxW <= 0 || boxH <= 0 || block == "" {
+// PlaceBlock aligns block within a box of boxW x boxH cells. Lines are
+// padded on the left to align them; by default lines wider than the box
+// are left as they are, and rows are not added after the block (see
+// WithBoxFill and WithExactHeight).
+func PlaceBlock(block string, boxW, boxH int, h AlignH, v AlignV, opts ...PlaceOption) string {
+	var pl placement
+	for _, o := range opts {
+		o(&pl)
+	}
+	if boxW <= 0 || boxH <= 0 || (block == "" && !pl.fill && !pl.exact) {
 		return block
 	}
-	lines := strings.Split(block, "\n")
+	lines := fitLines(strings.Split(block, "\n"), boxW, pl.overflow)
+	if pl.exact && len(lines) > boxH {
+		cut := 0
+		switch v {
+		case AlignMiddle:
+			cut = (len(lines) - boxH) / 2
+		case AlignBottom:
+			cut = len(lines) - boxH
+		}
+		lines = lines[cut : cut+boxH]
+	}
 	_, bh := blockSize(lines)
 
 	topPad := 0
@@ -43,9 +106,15 @@ func PlaceBlock(block string, boxW, boxH int, h AlignH, v AlignV) string {
 		}
 	}
 
+	spaces := func(n int) string { return Styled(pl.style, strings.Repeat(" ", n)) }
+	blank := ""
+	if pl.fill {
+		blank = spaces(boxW)
+	}
 	var b strings.Builder
-	if topPad > 0 {
-		b.WriteString(strings.Repeat("\n", topPad))
+	for i := 0; i < topPad; i++ {
+		b.WriteString(blank)
+		b.WriteByte('\n')
 	}
 
 	for i, line := range lines {
@@ -64,16 +133,82 @@ func PlaceBlock(block string, boxW, boxH int, h AlignH, v AlignV) string {
 			}
 		}
 		if leftPad > 0 {
-			b.WriteString(strings.Repeat(" ", leftPad))
+			b.WriteString(spaces(leftPad))
 		}
 		b.WriteString(line)
+		if pad := boxW - leftPad - lw; pl.fill && pad > 0 {
+			b.WriteString(spaces(pad))
+		}
 		if i < len(lines)-1 {
 			b.WriteByte('\n')
 		}
 	}
+	if pl.fill || pl.exact {
+		for i := topPad + bh; i < boxH; i++ {
+			b.WriteByte('\n')
+			b.WriteString(blank)
+		}
+	}
 	return b.String()
 }
 
+// Fill paints style over a box of boxW x boxH cells holding block at its
+// top-left corner, typically to give a full-screen view a background:
+//
+//	return frog.Fill(view, m.width, m.height, frog.NewStyle().Bg(frog.ANSI256(235)))
+//
+// Lines are padded to the box width and rows added to its height, and
+// anything outside the box is cut. The style is reopened after every reset
+// inside block, so styled text keeps the background around it.
+func Fill(block string, boxW, boxH int, style Style) string {
+	if boxW <= 0 || boxH <= 0 {
+		return ""
+	}
+	placed := PlaceBlock(block, boxW, boxH, AlignLeft, AlignTop,
+		WithOverflow(OverflowClip), WithBoxFill(), WithExactHeight())
+	p := style.Prefix()
+	if p == "" {
+		return placed
+	}
+	lines := strings.Split(placed, "\n")
+	for i, l := range lines {
+		lines[i] = p + reopen(l, p) + sgrReset
+	}
+	return strings.Join(lines, "\n")
+}
+
+// reopen writes prefix after every SGR reset in s ("ESC[0m" or "ESC[m").
+func reopen(s, prefix string) string {
+	if !strings.Contains(s, "\x1b[") {
+		return s
+	}
+	s = strings.ReplaceAll(s, sgrReset, sgrReset+prefix)
+	return strings.ReplaceAll(s, "\x1b[m", "\x1b[m"+prefix)
+}
+
+// fitLines applies the overflow policy o to lines for a box w columns wide.
+func fitLines(lines []string, w int, o Overflow) []string {
+	if o == OverflowNone {
+		return lines
+	}
+	out := lines[:0:0]
+	for _, line := range lines {
+		if displayWidth(line) <= w {
+			out = append(out, line)
+			continue
+		}
+		switch o {
+		case OverflowClip:
+			out = append(out, Truncate(line, w))
+		case OverflowEllipsis:
+			out = append(out, Truncate(line, w-1)+"…")
+		case OverflowWrap:
+			out = append(out, strings.Split(wrapFrame(line, w), "\n")...)
+		}
+	}
+	return out
+}
+
 func blockSize(lines []string) (w, h int) {
 	h = len(lines)
 	for _, ln := range lines {
@@ -84,17 +219,202 @@ func blockSize(lines []string) (w, h int) {
 	return
 }
 
+// DisplayWidth returns the number of terminal columns s occupies, ignoring
+// ANSI escape sequences.
+func DisplayWidth(s string) int { return displayWidth(s) }
 
 func displayWidth(s string) int {
 	plain := StripANSI(s)
 	w := 0
-	for _, r := range plain {
-		if r == '\t' {
-			next := 4 - (w % 4)
-			w += next
+	for i := 0; i < len(plain); {
+		size, cw := nextCell(plain[i:], w)
+		w += cw
+		i += size
+	}
+	return w
+}
+
+// nextCell measures the grapheme cluster at the start of s, which must not
+// begin with an escape sequence: its length in bytes and the columns it
+// takes at column col (tabs advance to the next multiple of 4).
+func nextCell(s string, col int) (size, width int) {
+	if s[0] == '\t' {
+		return 1, 4 - (col % 4)
+	}
+	size = text.NextGrapheme(s, 0)
+	return size, text.GraphemeWidth(s[:size])
+}
+
+// Truncate cuts s to at most w columns, keeping escape sequences intact. If
+// any SGR sequence was kept, a reset is appended so styles don't leak; OSC
+// strings past the cut are kept too, so hyperlinks are closed.
+func Truncate(s string, w int) string {
+	if w <= 0 {
+		return ""
+	}
+	if displayWidth(s) <= w {
+		return s
+	}
+	var b strings.Builder
+	col, styled, cut := 0, false, false
+	for i := 0; i < len(s); {
+		if n := ansiLen(s, i); n > 0 {
+			if !cut || isOSC(s[i:]) {
+				b.WriteString(s[i : i+n])
+				styled = true
+			}
+			i += n
+			continue
+		}
+		size, rw := nextCell(s[i:], col)
+		if cut || col+rw > w {
+			cut = true
+			i += size
 			continue
 		}
-		w++
+		b.WriteString(s[i : i+size])
+		col += rw
+		i += size
 	}
-	return w
+	if styled {
+		b.WriteString(sgrReset)
+	}
+	return b.String()
+}
+
+// isOSC reports whether s starts with an OSC string.
+func isOSC(s string) bool {
+	return strings.HasPrefix(s, "\x1b]") || strings.HasPrefix(s, "\u009d")
+}
+
+// Overlay composites block over base with its top-left corner at column x,
+// row y (both 0-based). Base content under the block is replaced; content to
+// the right of the block keeps its styling.
+func Overlay(base, block string, x, y int) string {
+	if block == "" {
+		return base
+	}
+	if x < 0 {
+		x = 0
+	}
+	if y < 0 {
+		y = 0
+	}
+	baseLines := strings.Split(base, "\n")
+	blockLines := strings.Split(block, "\n")
+	bw, _ := blockSize(blockLines)
+	for len(baseLines) < y+len(blockLines) {
+		baseLines = append(baseLines, "")
+	}
+	for i, bl := range blockLines {
+		line := baseLines[y+i]
+		var b strings.Builder
+		left := Truncate(line, x)
+		b.WriteString(left)
+		if pad := x - displayWidth(left); pad > 0 {
+			b.WriteString(strings.Repeat(" ", pad))
+		}
+		b.WriteString(bl)
+		if pad := bw - displayWidth(bl); pad > 0 {
+			b.WriteString(strings.Repeat(" ", pad))
+		}
+		if rest, state := skipColumns(line, x+bw); rest != "" {
+			b.WriteString(sgrReset)
+			b.WriteString(state)
+			b.WriteString(rest)
+		}
+		baseLines[y+i] = b.String()
+	}
+	return strings.Join(baseLines, "\n")
+}
+
+// skipColumns drops the first w columns of s. It returns the remainder and
+// the escape sequences that were skipped, so the caller can restore styling.
+func skipColumns(s string, w int) (rest, state string) {
+	var st strings.Builder
+	col := 0
+	for i := 0; i < len(s); {
+		if n := ansiLen(s, i); n > 0 {
+			st.WriteString(s[i : i+n])
+			i += n
+			continue
+		}
+		if col >= w {
+			return s[i:], st.String()
+		}
+		size, cw := nextCell(s[i:], col)
+		col += cw
+		i += size
+	}
+	return "", st.String()
+}
+
+// escapeIndex returns the index of the first byte in s that may start an
+// escape sequence (see ansiLen), or -1.
+func escapeIndex(s string) int {
+	i := strings.IndexByte(s, 0x1b)
+	if c1 := strings.IndexByte(s, 0xc2); c1 >= 0 && (i < 0 || c1 < i) {
+		i = c1
+	}
+	return i
+}
+
+// ansiLen returns the length of the escape sequence starting at s[i], or 0
+// if there is none. It recognizes CSI sequences with any final byte; OSC,
+// DCS, SOS, PM and APC strings ended by ST (or, for OSC, BEL); other ESC
+// sequences such as ESC 7 or ESC ( B; and C1 controls in their UTF-8 form,
+// which may start CSI and string sequences themselves. An unterminated
+// sequence runs to the end of s.
+func ansiLen(s string, i int) int {
+	if i >= len(s) {
+		return 0
+	}
+	var j int // index just past the introducer
+	var kind byte
+	switch {
+	case s[i] == 0x1b:
+		if i+1 == len(s) {
+			return 1
+		}
+		j, kind = i+2, s[i+1]
+	case s[i] == 0xc2 && i+1 < len(s) && s[i+1] >= 0x80 && s[i+1] <= 0x9f:
+		// U+0080..U+009F; the 7-bit equivalent of C1 c is ESC c-0x40.
+		j, kind = i+2, s[i+1]-0x40
+		switch kind {
+		case '[', ']', 'P', 'X', '^', '_':
+		default:
+			return 2 // a lone control
+		}
+	default:
+		return 0
+	}
+	switch kind {
+	case '[': // CSI: parameters and intermediates, then a final byte
+		for ; j < len(s); j++ {
+			if c := s[j]; c >= 0x40 && c <= 0x7e {
+				return j + 1 - i
+			}
+		}
+		return len(s) - i
+	case ']', 'P', 'X', '^', '_': // strings up to ST
+		for ; j < len(s); j++ {
+			switch {
+			case s[j] == 0x07 && kind == ']':
+				return j + 1 - i
+			case s[j] == 0x1b && j+1 < len(s) && s[j+1] == '\\',
+				s[j] == 0xc2 && j+1 < len(s) && s[j+1] == 0x9c:
+				return j + 2 - i
+			}
+		}
+		return len(s) - i
+	}
+	// Other ESC sequences: intermediates, then a final byte.
+	for j = i + 1; j < len(s); j++ {
+		if c := s[j]; c >= 0x30 && c <= 0x7e {
+			return j + 1 - i
+		} else if c < 0x20 || c > 0x2f {
+			return 1 // not a sequence; drop the bare ESC
+		}
+	}
+	return len(s) - i
 }
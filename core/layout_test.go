@@ -0,0 +1,67 @@
+package core
+
+import "testing"
+
+func TestPlaceBlock(t *testing.T) {
+	tests := []struct {
+		name  string
+		block string
+		w, h  int
+		ah    AlignH
+		av    AlignV
+		opts  []PlaceOption
+		want  string
+	}{
+		{"top left", "ab", 4, 2, AlignLeft, AlignTop, nil, "ab"},
+		{"center middle", "ab", 4, 3, AlignCenter, AlignMiddle, nil, "\n ab"},
+		{"bottom right", "ab\nc", 4, 3, AlignRight, AlignBottom, nil, "\n  ab\n   c"},
+		{"wide chars", "日本", 6, 1, AlignRight, AlignTop, nil, "  日本"},
+		{"empty block", "", 4, 2, AlignLeft, AlignTop, nil, ""},
+		{"zero box", "ab", 0, 2, AlignCenter, AlignTop, nil, "ab"},
+		{"overflow none", "abcdef", 3, 1, AlignLeft, AlignTop, nil, "abcdef"},
+		{"overflow clip", "abcdef", 3, 1, AlignLeft, AlignTop, []PlaceOption{WithOverflow(OverflowClip)}, "abc"},
+		{"overflow ellipsis", "abcdef", 3, 1, AlignLeft, AlignTop, []PlaceOption{WithOverflow(OverflowEllipsis)}, "ab…"},
+		{"overflow wrap", "abcdef", 3, 2, AlignLeft, AlignTop, []PlaceOption{WithOverflow(OverflowWrap)}, "abc\ndef"},
+		{"box fill", "ab", 3, 2, AlignCenter, AlignTop, []PlaceOption{WithBoxFill()}, "ab \n   "},
+		{"exact height pads", "ab", 2, 3, AlignLeft, AlignTop, []PlaceOption{WithExactHeight()}, "ab\n\n"},
+		{"exact height cuts top", "a\nb\nc", 1, 2, AlignLeft, AlignTop, []PlaceOption{WithExactHeight()}, "a\nb"},
+		{"exact height cuts bottom", "a\nb\nc", 1, 2, AlignLeft, AlignBottom, []PlaceOption{WithExactHeight()}, "b\nc"},
+		{
+			"fill style", "a", 3, 1, AlignCenter, AlignTop,
+			[]PlaceOption{WithFillStyle(NewStyle().Reversed())},
+			"\x1b[7m \x1b[0ma",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := PlaceBlock(tt.block, tt.w, tt.h, tt.ah, tt.av, tt.opts...); got != tt.want {
+				t.Errorf("PlaceBlock = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFill(t *testing.T) {
+	bg := NewStyle().Bg(ANSI256(235))
+	p := bg.Prefix()
+	tests := []struct {
+		name  string
+		block string
+		w, h  int
+		style Style
+		want  string
+	}{
+		{"plain style pads", "a", 2, 2, NewStyle(), "a \n  "},
+		{"zero box", "a", 0, 2, bg, ""},
+		{"clips", "abc\nd\ne", 2, 2, NewStyle(), "ab\nd "},
+		{"background", "a", 2, 1, bg, p + "a " + sgrReset},
+		{"reopened after reset", "\x1b[1ma\x1b[0mb", 3, 1, bg, p + "\x1b[1ma" + sgrReset + p + "b " + sgrReset},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Fill(tt.block, tt.w, tt.h, tt.style); got != tt.want {
+				t.Errorf("Fill = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,39 @@
+package core
+
+// On calls fn when msg is a T and reports whether it did.
+//
+//	if m2, cmd, ok := frog.On(msg, func(k frog.KeyMsg) (frog.Model, frog.Cmd) { ... }); ok {
+//		return m2, cmd
+//	}
+func On[T Msg](msg Msg, fn func(T) (Model, Cmd)) (Model, Cmd, bool) {
+	t, ok := msg.(T)
+	if !ok {
+		return nil, nil, false
+	}
+	m, cmd := fn(t)
+	return m, cmd, true
+}
+
+// Case is one branch of Match, built with Handle.
+type Case func(Msg) (Model, Cmd, bool)
+
+// Handle builds a Case that runs fn for messages of type T.
+func Handle[T Msg](fn func(T) (Model, Cmd)) Case {
+	return func(msg Msg) (Model, Cmd, bool) { return On(msg, fn) }
+}
+
+// Match runs the first case matching msg's type. Unmatched messages leave
+// the model unchanged. It replaces long type switches in Update:
+//
+//	return frog.Match(m, msg,
+//		frog.Handle(m.onKey),
+//		frog.Handle(m.onTick),
+//	)
+func Match(m Model, msg Msg, cases ...Case) (Model, Cmd) {
+	for _, c := range cases {
+		if next, cmd, ok := c(msg); ok {
+			return next, cmd
+		}
+	}
+	return m, nil
+}
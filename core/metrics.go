@@ -0,0 +1,21 @@
+package core
+
+import "time"
+
+// Metrics receives session counters. Implementations must be safe for
+// concurrent use: commands finish and Send is called from other goroutines.
+// The metrics/expvar package publishes them with expvar; adapters for
+// other systems (Prometheus, StatsD) implement this interface too.
+type Metrics interface {
+	MsgProcessed()
+	MsgDropped()
+	Rendered(bytes int64, d time.Duration)
+	CmdFinished(d time.Duration)
+}
+
+type noopMetrics struct{}
+
+func (noopMetrics) MsgProcessed()                 {}
+func (noopMetrics) MsgDropped()                   {}
+func (noopMetrics) Rendered(int64, time.Duration) {}
+func (noopMetrics) CmdFinished(time.Duration)     {}
@@ -0,0 +1,37 @@
+package core
+
+import (
+	"fmt"
+	"strings"
+)
+
+// WithMinSize sets the smallest terminal the view is drawn in. While the
+// terminal is smaller, the session shows a centered notice such as
+// "terminal too small (need 80x24)" instead of a layout that would wrap
+// and overlap, and draws the view again once the terminal is large
+// enough. Update still gets every message meanwhile, ResizeMsg included.
+func WithMinSize(width, height int) Option {
+	return func(p *Session) { p.minWidth, p.minHeight = width, height }
+}
+
+// tooSmall reports whether the terminal is below the minimum size. An
+// unknown size is not.
+func (p *Session) tooSmall() bool {
+	if p.width <= 0 || p.height <= 0 {
+		return false
+	}
+	return p.width < p.minWidth || p.height < p.minHeight
+}
+
+// tooSmallView is the notice shown in place of the view.
+func (p *Session) tooSmallView() string {
+	need := fmt.Sprintf("terminal too small (need %dx%d)", p.minWidth, p.minHeight)
+	if displayWidth(need) > p.width {
+		need = fmt.Sprintf("need %dx%d", p.minWidth, p.minHeight)
+	}
+	block := strings.Join([]string{
+		Truncate(need, p.width),
+		Truncate(fmt.Sprintf("now %dx%d", p.width, p.height), p.width),
+	}, "\n")
+	return Center(block, p.width, p.height)
+}
@@ -0,0 +1,91 @@
+package core
+
+import (
+	"fmt"
+	"os"
+)
+
+// Mode is how a session runs, given which of its input and output are
+// terminals.
+type Mode int
+
+const (
+	// ModeInteractive: input and output are terminals.
+	ModeInteractive Mode = iota
+	// ModePipedInput: the output is a terminal but the input is not, as
+	// in "cat file | app". The session reads keys from the controlling
+	// terminal, leaving the piped input to the program; without one, it
+	// runs interactively and reads the piped input as keys.
+	ModePipedInput
+	// ModePipedOutput: the input is a terminal but the output is not, as
+	// in "app > file". The view is rendered once, like ModeNonInteractive,
+	// so the output holds a result rather than escape sequences.
+	ModePipedOutput
+	// ModeNonInteractive: neither is a terminal, or WithNonInteractive was
+	// given. The view is rendered once.
+	ModeNonInteractive
+)
+
+func (m Mode) String() string {
+	switch m {
+	case ModeInteractive:
+		return "interactive"
+	case ModePipedInput:
+		return "piped input"
+	case ModePipedOutput:
+		return "piped output"
+	case ModeNonInteractive:
+		return "non-interactive"
+	}
+	return fmt.Sprintf("Mode(%d)", int(m))
+}
+
+// DetectedModeMsg tells the model how the session runs. It is sent once as
+// the session starts; in the render-once modes it arrives after Init, just
+// before the only View. TTYInput reports that keys come from the controlling
+// terminal because the input is piped.
+type DetectedModeMsg struct {
+	Mode     Mode
+	TTYInput bool
+}
+
+// detectMode works out the session's mode. Custom terminals (WithTerminal,
+// WithTerminalFd) decide for input and output together.
+func (p *Session) detectMode() Mode {
+	outTTY := p.terminal.IsTerminal()
+	ft, isFile := p.terminal.(fileTerminal)
+	inTTY := outTTY
+	if isFile && ft.in != nil {
+		inTTY = IsTerminal(ft.in)
+	}
+	switch {
+	case p.nonInteractive || (!outTTY && !inTTY):
+		return ModeNonInteractive
+	case !outTTY:
+		return ModePipedOutput
+	case !inTTY:
+		return ModePipedInput
+	}
+	return ModeInteractive
+}
+
+// ttyInput switches the session's input to the controlling terminal when
+// the input is piped, and reports whether it did. The caller closes the
+// returned file when the session ends.
+func (p *Session) ttyInput() (*os.File, bool) {
+	ft, ok := p.terminal.(fileTerminal)
+	if !ok {
+		return nil, false
+	}
+	tty, err := openTTY()
+	if err != nil {
+		p.logger.Debugf("input is piped and no terminal to read keys from: %v", err)
+		ft.in = nil // read the piped input as it is
+		p.terminal = ft
+		return nil, false
+	}
+	ft.in = tty
+	p.terminal = ft
+	p.input.reader = tty
+	return tty, true
+}
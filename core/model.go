@@ -5,3 +5,30 @@ type Model interface {
 	Update(Msg) (Model, Cmd)
 	View() string
 }
+
+// Optional lifecycle hooks. The session detects them with type assertions.
+
+// Starter is called once the session is running, after Init and the first
+// render.
+type Starter interface {
+	OnStart() (Model, Cmd)
+}
+
+// Resizer receives terminal size changes. Models implementing it get
+// OnResize instead of a ResizeMsg in Update.
+type Resizer interface {
+	OnResize(width, height int) (Model, Cmd)
+}
+
+// Interrupter handles Ctrl+C and SIGINT itself. Models implementing it get
+// OnInterrupt for both and keep running unless they return Quit, which
+// allows "press Ctrl+C again to exit". Other models get Ctrl+C as a KeyMsg
+// of type KeyCtrlC, and SIGINT quits them.
+type Interrupter interface {
+	OnInterrupt(InterruptMsg) (Model, Cmd)
+}
+
+// Quitter is notified when the session stops, before the terminal is restored.
+type Quitter interface {
+	OnQuit()
+}
@@ -28,14 +28,55 @@ const (
 	KeyPgUp
 	KeyPgDn
 	KeyQ
+	KeyF1
+	KeyF2
+	KeyF3
+	KeyF4
+	KeyF5
+	KeyF6
+	KeyF7
+	KeyF8
+	KeyF9
+	KeyF10
+	KeyF11
+	KeyF12
+
+	// Numeric keypad keys, reported with WithKeypad
+	KeyKP0
+	KeyKP1
+	KeyKP2
+	KeyKP3
+	KeyKP4
+	KeyKP5
+	KeyKP6
+	KeyKP7
+	KeyKP8
+	KeyKP9
+	KeyKPEnter
+	KeyKPPlus
+	KeyKPMinus
+	KeyKPMultiply
+	KeyKPDivide
+	KeyKPDecimal
+	KeyKPEqual
+	KeyKPComma
 )
 
+// KeyMsg is a key press. String holds the raw input. The modifier flags
+// are set for Alt+rune and Ctrl+letter, and for arrows, Home/End,
+// PgUp/PgDn, Delete, Tab and function keys when the terminal reports them.
+//
+// Ctrl+letter arrives as a KeyRune with Ctrl set and Rune the letter, so
+// code inserting or matching typed characters must check Ctrl (and Alt).
+// Ctrl+C is KeyCtrlC; Ctrl+H, Ctrl+I, Ctrl+J and Ctrl+M send the same bytes
+// as Backspace, Tab and Enter and arrive as those keys.
 type KeyMsg struct {
 	Type   KeyType
 	Rune   rune
 	String string
 	Alt    bool
 	Ctrl   bool
+	Shift  bool
 }
 
 // ---------- Time / Quit / Resize ----------
@@ -44,16 +85,38 @@ type TickMsg struct{ At time.Time }
 
 type QuitMsg struct{}
 
+// InterruptMsg is passed to Interrupter models for Ctrl+C and SIGINT
+// (Signal is true for the latter).
+type InterruptMsg struct{ Signal bool }
+
 type ResizeMsg struct {
 	Width, Height int
 }
 
+// ---------- Composition ----------
+
+// CompositionMsg reports that multi-byte input is being received: Active is
+// true while the decoder waits for the rest of a character (as with input
+// methods that send composed text slowly) and false once it arrives or the
+// wait times out. Text inputs can use it to show a composing state.
+type CompositionMsg struct {
+	Active bool
+}
+
 // ---------- Bracketed Paste ----------
 
 type PasteMsg struct {
 	Text string
 }
 
+// With WithPasteChunks, a paste arrives as PasteStartMsg, any number of
+// PasteChunkMsg as the data comes in, then PasteEndMsg.
+type (
+	PasteStartMsg struct{}
+	PasteChunkMsg struct{ Text string }
+	PasteEndMsg   struct{}
+)
+
 // ---------- Mouse (SGR) ----------
 
 type MouseButton int
@@ -0,0 +1,105 @@
+package core
+
+import (
+	"encoding/base64"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// Multiplexer identifies a terminal multiplexer between Frog and the
+// terminal emulator.
+type Multiplexer int
+
+const (
+	MuxNone Multiplexer = iota
+	MuxTmux
+	MuxScreen
+)
+
+// DetectMultiplexer reports whether the program runs inside tmux or screen.
+func DetectMultiplexer() Multiplexer {
+	switch {
+	case os.Getenv("TMUX") != "" || strings.HasPrefix(os.Getenv("TERM"), "tmux"):
+		return MuxTmux
+	case os.Getenv("STY") != "" || strings.HasPrefix(os.Getenv("TERM"), "screen"):
+		return MuxScreen
+	}
+	return MuxNone
+}
+
+// Passthrough wraps an escape sequence so the surrounding multiplexer
+// forwards it to the outer terminal instead of interpreting (or printing) it.
+// Outside tmux/screen it returns seq unchanged. tmux needs
+// "set -g allow-passthrough on".
+func Passthrough(seq string) string { return passthrough(DetectMultiplexer(), seq) }
+
+func passthrough(m Multiplexer, seq string) string {
+	switch m {
+	case MuxTmux:
+		return "\x1bPtmux;" + strings.ReplaceAll(seq, "\x1b", "\x1b\x1b") + "\x1b\\"
+	case MuxScreen:
+		// screen limits DCS strings to 768 bytes: split into chunks.
+		const chunk = 760
+		var b strings.Builder
+		for len(seq) > chunk {
+			b.WriteString("\x1bP" + seq[:chunk] + "\x1b\\")
+			seq = seq[chunk:]
+		}
+		b.WriteString("\x1bP" + seq + "\x1b\\")
+		return b.String()
+	}
+	return seq
+}
+
+// Hyperlink returns text as an OSC 8 hyperlink. tmux 3.4 and later track
+// links in their own screen and get plain OSC 8; older tmux and screen
+// cannot carry links, so plain text is returned. (Passing the link through
+// would not work: the multiplexer redraws the text without it.)
+func Hyperlink(url, text string) string {
+	return hyperlink(DetectMultiplexer(), os.Getenv("TERM_PROGRAM_VERSION"), url, text)
+}
+
+func hyperlink(m Multiplexer, version, url, text string) string {
+	switch m {
+	case MuxScreen:
+		return text
+	case MuxTmux:
+		if !versionAtLeast(version, 3, 4) {
+			return text
+		}
+	}
+	return "\x1b]8;;" + url + "\x1b\\" + text + "\x1b]8;;\x1b\\"
+}
+
+// versionAtLeast reports whether a "major.minor[suffix]" version such as
+// tmux's "3.4" or "3.3a" is at least major.minor.
+func versionAtLeast(v string, major, minor int) bool {
+	maj, rest, _ := strings.Cut(v, ".")
+	n, err := strconv.Atoi(maj)
+	if err != nil {
+		return false
+	}
+	if n != major {
+		return n > major
+	}
+	i := 0
+	for i < len(rest) && rest[i] >= '0' && rest[i] <= '9' {
+		i++
+	}
+	m, err := strconv.Atoi(rest[:i])
+	return err == nil && m >= minor
+}
+
+// clipboardMsg asks the session to copy text via OSC 52.
+type clipboardMsg struct{ text string }
+
+// SetClipboard returns a command that copies text to the system clipboard
+// using OSC 52 (wrapped for tmux/screen).
+func SetClipboard(text string) Cmd {
+	return func() Msg { return clipboardMsg{text: text} }
+}
+
+func osc52(text string) string {
+	return passthrough(DetectMultiplexer(), "\x1b]52;c;"+base64.StdEncoding.EncodeToString([]byte(text))+"\x07")
+}
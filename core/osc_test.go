@@ -0,0 +1,29 @@
+package core
+
+import "testing"
+
+func TestHyperlink(t *testing.T) {
+	const link = "\x1b]8;;https://example.com\x1b\\go\x1b]8;;\x1b\\"
+	tests := []struct {
+		name    string
+		mux     Multiplexer
+		version string
+		want    string
+	}{
+		{"no multiplexer", MuxNone, "", link},
+		{"screen", MuxScreen, "", "go"},
+		{"tmux 3.4", MuxTmux, "3.4", link},
+		{"tmux 3.5a", MuxTmux, "3.5a", link},
+		{"tmux 4.0", MuxTmux, "4.0", link},
+		{"tmux 3.3a", MuxTmux, "3.3a", "go"},
+		{"tmux unknown version", MuxTmux, "", "go"},
+		{"tmux next", MuxTmux, "next-3.5", "go"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := hyperlink(tt.mux, tt.version, "https://example.com", "go"); got != tt.want {
+				t.Errorf("hyperlink = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,377 @@
+package core
+
+import (
+	"bytes"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+const (
+	maxPaste = 1 << 20 // 1 MiB
+	maxOSC   = 4096
+)
+
+var (
+	pasteStart = []byte("\x1b[200~")
+	pasteEnd   = []byte("\x1b[201~")
+)
+
+// ParseSequence decodes the first input event in b: a key, mouse event,
+// paste or terminal reply. Keypad keys in application mode decode to the
+// KeyKP* types. It returns the message and the number of bytes
+// consumed. A consumed count of 0 means b is an incomplete sequence and more
+// bytes are needed. Input that decodes to nothing (unknown control bytes or
+// replies) is consumed with a nil message. ParseSequence has no state and
+// never blocks.
+func ParseSequence(b []byte) (Msg, int) { return parseInput(b, false) }
+
+// parseInput is ParseSequence with a flush mode: when flush is true no more
+// bytes are expected soon, so an incomplete sequence is resolved as well as
+// possible (a lone ESC is the Esc key). Incomplete pastes are never flushed.
+func parseInput(b []byte, flush bool) (Msg, int) {
+	if len(b) == 0 {
+		return nil, 0
+	}
+	switch c := b[0]; c {
+	case 3:
+		return KeyMsg{Type: KeyCtrlC, String: "\x03", Ctrl: true}, 1
+	case '\r', '\n':
+		return KeyMsg{Type: KeyEnter, String: "\r"}, 1
+	case 8, 127:
+		return KeyMsg{Type: KeyBackspace, String: string(c)}, 1
+	case 9:
+		return KeyMsg{Type: KeyTab, String: "\t"}, 1
+	case ' ':
+		return KeyMsg{Type: KeySpace, Rune: ' ', String: " "}, 1
+	case 'q', 'Q':
+		return KeyMsg{Type: KeyQ, Rune: rune(c), String: string(c)}, 1
+	case 27:
+		return parseEscape(b, flush)
+	}
+
+	// The remaining Ctrl+letter bytes, such as Ctrl+X, decode to the KeyMsg
+	// ParseKey gives for "ctrl+x". Ctrl+C, Ctrl+H, Ctrl+I, Ctrl+J and
+	// Ctrl+M keep their key types above.
+	if c := b[0]; c >= 1 && c <= 26 {
+		return KeyMsg{Type: KeyRune, Rune: rune('a' + c - 1), String: string(c), Ctrl: true}, 1
+	}
+	// Other control bytes: ignore
+	if b[0] < 0x20 {
+		return nil, 1
+	}
+	return parseRune(b, flush)
+}
+
+// parseRune decodes a UTF-8 character, waiting for the rest of it if b ends
+// mid-sequence.
+func parseRune(b []byte, flush bool) (Msg, int) {
+	if !utf8.FullRune(b) {
+		if flush {
+			return nil, len(b)
+		}
+		return nil, 0
+	}
+	ru, size := utf8.DecodeRune(b)
+	if ru == utf8.RuneError || unicode.IsControl(ru) {
+		return nil, size
+	}
+	return KeyMsg{Type: KeyRune, Rune: ru, String: string(ru)}, size
+}
+
+// parseEscape decodes sequences starting with ESC: CSI keys, SGR mouse,
+// bracketed paste, OSC replies and Alt+key.
+func parseEscape(b []byte, flush bool) (Msg, int) {
+	if len(b) == 1 {
+		if flush {
+			return KeyMsg{Type: KeyEsc, String: "\x1b"}, 1
+		}
+		return nil, 0
+	}
+	switch b[1] {
+	case '[':
+		if bytes.HasPrefix(b, pasteStart) {
+			return parsePaste(b)
+		}
+		if len(b) < len(pasteStart) && bytes.HasPrefix(pasteStart, b) && !flush {
+			return nil, 0
+		}
+		if len(b) > 2 && b[2] == '<' {
+			return parseMouseSGR(b, flush)
+		}
+		return parseCSI(b, flush)
+	case ']':
+		// Replies to color queries look like OSC 11;rgb:... ; anything
+		// else after ESC ] is Alt+].
+		switch oscIntro(b[2:]) {
+		case oscYes:
+			return parseOSCSeq(b, flush)
+		case oscMore:
+			if !flush {
+				return nil, 0
+			}
+		}
+	case 'O':
+		if len(b) == 2 && !flush {
+			return nil, 0
+		}
+		if len(b) > 2 {
+			if t, ok := ss3Keys[b[2]]; ok {
+				return KeyMsg{Type: t, String: string(b[:3])}, 3
+			}
+			if k, ok := ss3Keypad[b[2]]; ok {
+				return KeyMsg{Type: k.t, Rune: k.r, String: string(b[:3])}, 3
+			}
+		}
+	case 27:
+		// Alt+special key sent as ESC before the key's own sequence (rxvt)
+		if len(b) > 2 && (b[2] == '[' || b[2] == 'O') {
+			msg, n := parseEscape(b[1:], flush)
+			if n == 0 {
+				return nil, 0
+			}
+			if k, ok := msg.(KeyMsg); ok && k.Type != KeyEsc && k.Type != KeyRune {
+				k.Alt = true
+				k.String = "\x1b" + k.String
+				return k, 1 + n
+			}
+		}
+	}
+
+	// Alt+key (Meta)
+	if b[1] < 0x20 || b[1] == 0x7f {
+		return KeyMsg{Type: KeyEsc, String: "\x1b"}, 1
+	}
+	if !utf8.FullRune(b[1:]) {
+		if flush {
+			return KeyMsg{Type: KeyEsc, String: "\x1b"}, len(b)
+		}
+		return nil, 0
+	}
+	ru, size := utf8.DecodeRune(b[1:])
+	if ru == utf8.RuneError || unicode.IsControl(ru) {
+		return KeyMsg{Type: KeyEsc, String: "\x1b"}, 1 + size
+	}
+	return KeyMsg{Type: KeyRune, Rune: ru, String: string(ru), Alt: true}, 1 + size
+}
+
+// ss3Keys are the keys sent as ESC O final: arrows and Home/End in
+// application cursor mode, and F1-F4.
+var ss3Keys = map[byte]KeyType{
+	'A': KeyUp, 'B': KeyDown, 'C': KeyRight, 'D': KeyLeft,
+	'H': KeyHome, 'F': KeyEnd,
+	'P': KeyF1, 'Q': KeyF2, 'R': KeyF3, 'S': KeyF4,
+}
+
+// ss3Keypad are the numeric keypad keys in application keypad mode, sent as
+// ESC O final, with the character each stands for.
+var ss3Keypad = map[byte]struct {
+	t KeyType
+	r rune
+}{
+	'p': {KeyKP0, '0'}, 'q': {KeyKP1, '1'}, 'r': {KeyKP2, '2'}, 's': {KeyKP3, '3'},
+	't': {KeyKP4, '4'}, 'u': {KeyKP5, '5'}, 'v': {KeyKP6, '6'}, 'w': {KeyKP7, '7'},
+	'x': {KeyKP8, '8'}, 'y': {KeyKP9, '9'}, 'M': {KeyKPEnter, '\r'},
+	'k': {KeyKPPlus, '+'}, 'm': {KeyKPMinus, '-'}, 'j': {KeyKPMultiply, '*'},
+	'o': {KeyKPDivide, '/'}, 'n': {KeyKPDecimal, '.'}, 'X': {KeyKPEqual, '='},
+	'l': {KeyKPComma, ','},
+}
+
+// keypadAsText turns a keypad key into the key of the character it stands
+// for, as a terminal outside application keypad mode would send it.
+func keypadAsText(msg Msg) Msg {
+	k, ok := msg.(KeyMsg)
+	if !ok || k.Type < KeyKP0 || k.Type > KeyKPComma {
+		return msg
+	}
+	if k.Type == KeyKPEnter {
+		return KeyMsg{Type: KeyEnter, String: "\r"}
+	}
+	return KeyMsg{Type: KeyRune, Rune: k.Rune, String: string(k.Rune)}
+}
+
+// csiTildeKeys are the keys sent as ESC [ code ~.
+var csiTildeKeys = map[string]KeyType{
+	"3": KeyDelete, "5": KeyPgUp, "6": KeyPgDn,
+	"11": KeyF1, "12": KeyF2, "13": KeyF3, "14": KeyF4,
+	"15": KeyF5, "17": KeyF6, "18": KeyF7, "19": KeyF8,
+	"20": KeyF9, "21": KeyF10, "23": KeyF11, "24": KeyF12,
+}
+
+// parseCSI decodes ESC [ params final. xterm modifier parameters (ESC [ 1;3A
+// is Alt+Up, ESC [ 5;5~ Ctrl+PgUp) set Alt, Ctrl and Shift on the key;
+// String holds the raw sequence.
+func parseCSI(b []byte, flush bool) (Msg, int) {
+	i := 2
+	for i < len(b) && b[i] >= 0x20 && b[i] <= 0x3f {
+		i++
+	}
+	if i == len(b) {
+		if flush {
+			return KeyMsg{Type: KeyEsc, String: string(b)}, len(b)
+		}
+		return nil, 0
+	}
+	if b[i] < 0x40 || b[i] > 0x7e {
+		// Malformed: drop the introducer and let the rest decode on its own.
+		return KeyMsg{Type: KeyEsc, String: string(b[:i])}, i
+	}
+	n := i + 1
+	seq := string(b[:n])
+	code, mod, _ := strings.Cut(string(b[2:i]), ";")
+	k := KeyMsg{String: seq}
+	switch f := b[i]; f {
+	case 'A', 'B', 'C', 'D', 'H', 'F', 'P', 'Q', 'R', 'S':
+		k.Type = ss3Keys[f]
+	case 'Z':
+		k.Type, k.Shift = KeyTab, true // back-tab
+	case '~':
+		k.Type = csiTildeKeys[code]
+	}
+	if k.Type == KeyUnknown {
+		return KeyMsg{Type: KeyEsc, String: seq}, n
+	}
+	return withModifiers(k, mod), n
+}
+
+// withModifiers applies an xterm modifier parameter, 1 plus a bitmask of
+// Shift (1), Alt (2), Ctrl (4) and Meta (8), to k. Meta counts as Alt.
+func withModifiers(k KeyMsg, param string) KeyMsg {
+	m, err := strconv.Atoi(param)
+	if err != nil || m < 2 {
+		return k
+	}
+	m--
+	k.Shift = k.Shift || m&1 != 0
+	k.Alt = m&(2|8) != 0
+	k.Ctrl = m&4 != 0
+	return k
+}
+
+// parseMouseSGR decodes ESC [ < b ; x ; y (M|m).
+func parseMouseSGR(b []byte, flush bool) (Msg, int) {
+	var nums [3]int
+	i := 3
+	for k := 0; k < 3; k++ {
+		start := i
+		for i < len(b) && b[i] >= '0' && b[i] <= '9' {
+			i++
+		}
+		if i == len(b) {
+			if flush {
+				return KeyMsg{Type: KeyEsc, String: "\x1b"}, len(b)
+			}
+			return nil, 0
+		}
+		v, err := strconv.Atoi(string(b[start:i]))
+		want := byte(';')
+		if k == 2 {
+			want = 0
+		}
+		if err != nil || (want != 0 && b[i] != want) || (want == 0 && b[i] != 'M' && b[i] != 'm') {
+			return KeyMsg{Type: KeyEsc, String: "\x1b"}, i
+		}
+		nums[k] = v
+		i++
+	}
+	code, final := nums[0], b[i-1]
+
+	btn := MouseUnknown
+	act := MousePress
+	if code&64 != 0 {
+		act = MouseWheel
+		btn = MouseWheelUp
+		if code&1 == 1 {
+			btn = MouseWheelDown
+		}
+	} else {
+		switch code & 3 {
+		case 0:
+			btn = MouseLeft
+		case 1:
+			btn = MouseMiddle
+		case 2:
+			btn = MouseRight
+		}
+		if final == 'm' {
+			act = MouseRelease
+		} else if code&32 != 0 {
+			act = MouseDrag
+		}
+	}
+	return MouseMsg{
+		Button: btn,
+		Action: act,
+		X:      nums[1],
+		Y:      nums[2],
+		Alt:    code&8 != 0,
+		Ctrl:   code&16 != 0,
+		Shift:  code&4 != 0,
+	}, i
+}
+
+// oscIntro results.
+const (
+	oscNo   = iota // not an OSC reply
+	oscYes         // an OSC reply
+	oscMore        // too short to tell
+)
+
+// oscIntro reports whether body, the bytes after ESC ], starts like an OSC
+// reply: a number then ';'.
+func oscIntro(body []byte) int {
+	for i, c := range body {
+		switch {
+		case c >= '0' && c <= '9':
+		case c == ';' && i > 0:
+			return oscYes
+		default:
+			return oscNo
+		}
+	}
+	return oscMore
+}
+
+// parseOSCSeq decodes ESC ] payload (BEL | ESC \). Payloads longer than
+// maxOSC are cut off and the remaining bytes decode as ordinary input.
+func parseOSCSeq(b []byte, flush bool) (Msg, int) {
+	body := b[2:]
+	for i, c := range body {
+		if i >= maxOSC {
+			return parseOSC(string(body[:i])), 2 + i
+		}
+		switch c {
+		case 7:
+			return parseOSC(string(body[:i])), 2 + i + 1
+		case 27:
+			if i+1 == len(body) && !flush {
+				return nil, 0
+			}
+			n := 2 + i + 1
+			if i+1 < len(body) && body[i+1] == '\\' {
+				n++
+			}
+			return parseOSC(string(body[:i])), n
+		}
+	}
+	if flush || len(body) >= maxOSC {
+		return parseOSC(string(body)), len(b)
+	}
+	return nil, 0
+}
+
+// parsePaste decodes a complete bracketed paste. Text beyond maxPaste is
+// dropped.
+func parsePaste(b []byte) (Msg, int) {
+	body := b[len(pasteStart):]
+	end := bytes.Index(body, pasteEnd)
+	if end < 0 {
+		return nil, 0
+	}
+	text := body[:end]
+	if len(text) > maxPaste {
+		text = text[:maxPaste]
+	}
+	return PasteMsg{Text: string(text)}, len(pasteStart) + end + len(pasteEnd)
+}
@@ -0,0 +1,128 @@
+package core
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+)
+
+func TestParseSequence(t *testing.T) {
+	tests := []struct {
+		name  string
+		in    string
+		flush bool
+		want  Msg
+		n     int
+	}{
+		{"rune", "a", false, KeyMsg{Type: KeyRune, Rune: 'a', String: "a"}, 1},
+		{"enter", "\r", false, KeyMsg{Type: KeyEnter, String: "\r"}, 1},
+		{"ctrl+c", "\x03", false, KeyMsg{Type: KeyCtrlC, String: "\x03", Ctrl: true}, 1},
+		{"ctrl+h is backspace", "\x08", false, KeyMsg{Type: KeyBackspace, String: "\x08"}, 1},
+		{"ctrl+i is tab", "\t", false, KeyMsg{Type: KeyTab, String: "\t"}, 1},
+		{"ctrl+j is enter", "\n", false, KeyMsg{Type: KeyEnter, String: "\r"}, 1},
+		{"ctrl+letter", "\x07", false, KeyMsg{Type: KeyRune, Rune: 'g', String: "\x07", Ctrl: true}, 1},
+		{"ctrl+z", "\x1a", false, KeyMsg{Type: KeyRune, Rune: 'z', String: "\x1a", Ctrl: true}, 1},
+		{"other control bytes ignored", "\x1c", false, nil, 1},
+		{"arrow", "\x1b[A", false, KeyMsg{Type: KeyUp, String: "\x1b[A"}, 3},
+		{"partial csi", "\x1b[", false, nil, 0},
+		{"lone esc waits", "\x1b", false, nil, 0},
+		{"lone esc flushed", "\x1b", true, KeyMsg{Type: KeyEsc, String: "\x1b"}, 1},
+		{"alt+rune", "\x1bx", false, KeyMsg{Type: KeyRune, Rune: 'x', String: "x", Alt: true}, 2},
+		{"paste", "\x1b[200~hi\x1b[201~", false, PasteMsg{Text: "hi"}, 14},
+		{"partial paste", "\x1b[200~hi", false, nil, 0},
+
+		// OSC replies and Alt+]
+		{"osc bel", "\x1b]11;rgb:ffff/0000/0000\x07", false, BackgroundColorMsg{Color: RGB(255, 0, 0)}, 24},
+		{"osc st", "\x1b]10;rgb:00/00/00\x1b\\", false, ForegroundColorMsg{Color: RGB(0, 0, 0)}, 19},
+		{"osc partial", "\x1b]11;rgb:ff", false, nil, 0},
+		{"osc number partial", "\x1b]11", false, nil, 0},
+		{"alt+]", "\x1b]", true, KeyMsg{Type: KeyRune, Rune: ']', String: "]", Alt: true}, 2},
+		{"alt+] then key", "\x1b]a", false, KeyMsg{Type: KeyRune, Rune: ']', String: "]", Alt: true}, 2},
+		{"alt+] then digits", "\x1b]12a", false, KeyMsg{Type: KeyRune, Rune: ']', String: "]", Alt: true}, 2},
+		{"alt+] then ;", "\x1b];", false, KeyMsg{Type: KeyRune, Rune: ']', String: "]", Alt: true}, 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, n := parseInput([]byte(tt.in), tt.flush)
+			if n != tt.n || !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseInput(%q, %v) = %#v, %d; want %#v, %d", tt.in, tt.flush, got, n, tt.want, tt.n)
+			}
+		})
+	}
+}
+
+// Control bytes decode to the key ParseKey gives for their name, so
+// configured bindings match.
+func TestParseControlBytesMatchParseKey(t *testing.T) {
+	for c := byte(1); c <= 26; c++ {
+		got, _ := parseInput([]byte{c}, false)
+		k, ok := got.(KeyMsg)
+		if !ok {
+			t.Fatalf("parseInput(%q) = %#v, want a KeyMsg", c, got)
+		}
+		switch c {
+		case 8, 9, 10, 13:
+			continue // Backspace, Tab and Enter
+		}
+		name := "ctrl+" + string(rune('a'+c-1))
+		want, err := ParseKey(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if k != want {
+			t.Errorf("parseInput(%q) = %#v, want ParseKey(%q) = %#v", c, k, name, want)
+		}
+	}
+}
+
+// Ctrl+H, Ctrl+I, Ctrl+J and Ctrl+M arrive as other keys, so ParseKey
+// refuses their names rather than give a key nothing can match.
+func TestParseKeyRejectsControlAliases(t *testing.T) {
+	for _, name := range []string{"ctrl+h", "ctrl+I", "ctrl+j", "ctrl+m"} {
+		if k, err := ParseKey(name); err == nil {
+			t.Errorf("ParseKey(%q) = %#v, want an error", name, k)
+		}
+	}
+	for _, name := range []string{"ctrl+g", "ctrl+alt+h", "alt+m", "h"} {
+		if _, err := ParseKey(name); err != nil {
+			t.Errorf("ParseKey(%q): %v", name, err)
+		}
+	}
+}
+
+// FuzzParseSequence checks that ParseSequence either consumes bytes or asks
+// for more, and that flushing resolves every incomplete sequence except an
+// unterminated paste, so malformed input can never wedge the decoder.
+func FuzzParseSequence(f *testing.F) {
+	for _, seed := range []string{
+		"a", "héllo", "\x03\r\t\x7f",
+		"\x1b", "\x1b[", "\x1b[A", "\x1b[1;5C", "\x1b[3~", "\x1bOP", "\x1bOj",
+		"\x1b[<0;10;5M", "\x1b[<0;10;5m", "\x1b[M !!",
+		"\x1b[200~pasted\x1b[201~", "\x1b[200~unterminated",
+		"\x1b]11;rgb:0000/0000/0000\x07", "\x1b]11;rgb:ffff/ffff/ffff\x1b\\", "\x1b]x",
+		"\x1b[?62;4c", "\x1b[12;40R", "\x1bP>|xterm\x1b\\",
+		"\xff\xfe", "\xe2\x82",
+	} {
+		f.Add([]byte(seed))
+	}
+	f.Fuzz(func(t *testing.T, data []byte) {
+		for b := data; len(b) > 0; {
+			msg, n := ParseSequence(b)
+			if n < 0 || n > len(b) {
+				t.Fatalf("ParseSequence(%q) consumed %d", b, n)
+			}
+			if n == 0 {
+				if msg != nil {
+					t.Fatalf("ParseSequence(%q) = %#v for incomplete input", b, msg)
+				}
+				if _, n = parseInput(b, true); n == 0 {
+					if !bytes.HasPrefix(b, pasteStart) {
+						t.Fatalf("flushing %q made no progress", b)
+					}
+					return
+				}
+			}
+			b = b[n:]
+		}
+	})
+}
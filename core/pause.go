@@ -0,0 +1,100 @@
+package core
+
+// renderPauseMsg asks the session to stop or resume painting. done, when
+// set, is closed once the loop has done so.
+type renderPauseMsg struct {
+	on   bool
+	done chan struct{}
+}
+
+// PauseRendering returns a command that stops the session painting frames,
+// so the program can write to the terminal itself, say to stream a
+// subprocess's output. Messages are still processed. The cursor is shown
+// while painting is paused.
+func PauseRendering() Cmd {
+	return func() Msg { return renderPauseMsg{on: true} }
+}
+
+// ResumeRendering returns a command that resumes painting after
+// PauseRendering. The screen is cleared and the view repainted in full, as
+// whatever was written meanwhile is unknown to the renderer.
+func ResumeRendering() Cmd {
+	return func() Msg { return renderPauseMsg{on: false} }
+}
+
+// PauseRendering stops painting like the PauseRendering command, from any
+// goroutine. It returns once the session has stopped, so the caller can
+// write to the terminal straight away; it does nothing if the session is
+// not running. While the session is in Update or View, such as when called
+// from Update, it cannot wait: painting stops as soon as that call returns.
+func (p *Session) PauseRendering() { p.setRenderPaused(true) }
+
+// ResumeRendering resumes painting like the ResumeRendering command, from
+// any goroutine, returning once the view has been repainted. Like
+// PauseRendering it does not wait while the session is in Update or View.
+func (p *Session) ResumeRendering() { p.setRenderPaused(false) }
+
+// Pause requests left by setRenderPaused for the loop.
+const (
+	pauseNone int32 = iota
+	pauseOn
+	pauseOff
+)
+
+// pauseReqMsg wakes the loop to apply a pending pause request.
+type pauseReqMsg struct{}
+
+func (p *Session) setRenderPaused(on bool) {
+	if p.inModel.Load() {
+		// Waiting for the loop from Update would deadlock, so leave the
+		// request to be applied when the model call returns, and wake the
+		// loop in case it already has.
+		req := pauseOff
+		if on {
+			req = pauseOn
+		}
+		p.pauseReq.Store(req)
+		select {
+		case p.msgCh <- pauseReqMsg{}:
+		default:
+		}
+		return
+	}
+	done := make(chan struct{})
+	select {
+	case p.msgCh <- renderPauseMsg{on: on, done: done}:
+	case <-p.done:
+		return
+	}
+	select {
+	case <-done:
+	case <-p.done:
+	}
+}
+
+// applyPauseReq applies the request setRenderPaused left, if any.
+func (p *Session) applyPauseReq() {
+	switch p.pauseReq.Swap(pauseNone) {
+	case pauseOn:
+		p.pauseRendering(renderPauseMsg{on: true})
+	case pauseOff:
+		p.pauseRendering(renderPauseMsg{on: false})
+	}
+}
+
+// pauseRendering applies msg.
+func (p *Session) pauseRendering(msg renderPauseMsg) {
+	if msg.done != nil {
+		defer close(msg.done)
+	}
+	if msg.on == p.renderPaused {
+		return
+	}
+	p.renderPaused = msg.on
+	if msg.on {
+		p.writeRaw(lookupTermCaps(p.caps.Term).showCursor)
+		return
+	}
+	p.invalidate()
+	p.render()
+}
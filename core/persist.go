@@ -0,0 +1,143 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+)
+
+// Saver is implemented by models whose state survives restarts. SaveState is
+// called when the session quits; encode with encoding/json or encoding/gob.
+type Saver interface {
+	SaveState() ([]byte, error)
+}
+
+// Loader restores a model from data previously returned by SaveState.
+type Loader interface {
+	LoadState(data []byte) (Model, error)
+}
+
+// StateRestoredMsg is delivered at startup after a restore attempt. Err is
+// set if the saved state could not be read or decoded.
+type StateRestoredMsg struct {
+	Path string
+	Err  error
+}
+
+// StateFileEnv names an environment variable that, when set, overrides the
+// state file path of a session using WithPersistence. Development tools
+// (frog dev) use it to carry state across restarts; sessions without
+// WithPersistence ignore it.
+const StateFileEnv = "FROG_STATE_FILE"
+
+// WithPersistence saves the model state on quit and restores it on the next
+// start, for models implementing Saver and Loader. State lives under
+// StatePath(app), or at $FROG_STATE_FILE when set.
+func WithPersistence(app string) Option {
+	return func(p *Session) {
+		if v := os.Getenv(StateFileEnv); v != "" {
+			p.statePath, p.stateErr = v, nil
+			return
+		}
+		path, err := StatePath(app)
+		if err != nil {
+			// Logged by Run, once WithLogger has had its say.
+			p.stateErr = fmt.Errorf("persistence disabled: %w", err)
+			return
+		}
+		p.statePath, p.stateErr = path, nil
+	}
+}
+
+// StatePath returns the state file for app: $XDG_STATE_HOME/<app>/state,
+// falling back to ~/.local/state (or the user cache dir on Windows).
+func StatePath(app string) (string, error) {
+	if app == "" {
+		return "", errors.New("empty app name")
+	}
+	dir := os.Getenv("XDG_STATE_HOME")
+	if dir == "" {
+		if runtime.GOOS == "windows" {
+			d, err := os.UserCacheDir()
+			if err != nil {
+				return "", err
+			}
+			dir = d
+		} else {
+			home, err := os.UserHomeDir()
+			if err != nil {
+				return "", err
+			}
+			dir = filepath.Join(home, ".local", "state")
+		}
+	}
+	return filepath.Join(dir, app, "state"), nil
+}
+
+// restoreState replaces the model with the saved state, if any, and
+// returns the StateRestoredMsg to deliver after Init, or nil.
+func (p *Session) restoreState() Msg {
+	if p.statePath == "" {
+		return nil
+	}
+	l, ok := p.m.(Loader)
+	if !ok {
+		return nil
+	}
+	data, err := os.ReadFile(p.statePath)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err == nil {
+		var m Model
+		if m, err = l.LoadState(data); err == nil && m != nil {
+			p.setModel(m)
+		}
+	}
+	if err != nil {
+		err = fmt.Errorf("restore state: %w", err)
+		p.logger.Warnf("%v", err)
+	}
+	return StateRestoredMsg{Path: p.statePath, Err: err}
+}
+
+// saveState writes the model state atomically (temp file + rename).
+func (p *Session) saveState() {
+	if p.statePath == "" {
+		return
+	}
+	s, ok := p.m.(Saver)
+	if !ok {
+		return
+	}
+	data, err := s.SaveState()
+	if err == nil {
+		err = writeFileAtomic(p.statePath, data)
+	}
+	if err != nil {
+		p.logger.Errorf("save state: %v", err)
+	}
+}
+
+func writeFileAtomic(path string, data []byte) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return err
+	}
+	f, err := os.CreateTemp(filepath.Dir(path), ".state-*")
+	if err != nil {
+		return err
+	}
+	tmp := f.Name()
+	if _, err := f.Write(data); err != nil {
+		f.Close()
+		os.Remove(tmp)
+		return err
+	}
+	if err := f.Close(); err != nil {
+		os.Remove(tmp)
+		return err
+	}
+	return os.Rename(tmp, path)
+}
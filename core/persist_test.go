@@ -0,0 +1,91 @@
+package core
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// recordLogger keeps warnings and errors.
+type recordLogger struct {
+	mu    sync.Mutex
+	lines []string
+}
+
+func (l *recordLogger) Debugf(string, ...any) {}
+func (l *recordLogger) Infof(string, ...any)  {}
+func (l *recordLogger) Warnf(f string, args ...any) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.lines = append(l.lines, fmt.Sprintf(f, args...))
+}
+func (l *recordLogger) Errorf(f string, args ...any) { l.Warnf(f, args...) }
+
+// loaderModel restores its count from saved state and quits on
+// StateRestoredMsg.
+type loaderModel struct {
+	count    int
+	restored *StateRestoredMsg
+}
+
+func (m loaderModel) Init() Cmd { time.Sleep(20 * time.Millisecond); return nil }
+func (m loaderModel) Update(msg Msg) (Model, Cmd) {
+	if r, ok := msg.(StateRestoredMsg); ok {
+		m.restored = &r
+		return m, Quit()
+	}
+	return m, nil
+}
+func (m loaderModel) View() string { return "" }
+func (m loaderModel) SaveState() ([]byte, error) {
+	return fmt.Appendf(nil, "%d", m.count), nil
+}
+func (m loaderModel) LoadState(data []byte) (Model, error) {
+	_, err := fmt.Sscan(string(data), &m.count)
+	return m, err
+}
+
+func TestRestoreStateWithFullBuffer(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("XDG_STATE_HOME", dir)
+	path := filepath.Join(dir, "app", "state")
+	if err := writeFileAtomic(path, []byte("7")); err != nil {
+		t.Fatal(err)
+	}
+	p := runSession(t, loaderModel{}, "abcdef", WithMsgBuffer(1), WithPersistence("app"))
+	m := p.Model().(loaderModel)
+	if m.restored == nil || m.restored.Err != nil || m.restored.Path != path {
+		t.Fatalf("restored = %+v", m.restored)
+	}
+	if m.count != 7 {
+		t.Fatalf("count = %d, want 7", m.count)
+	}
+}
+
+func TestPersistenceWarningUsesLaterLogger(t *testing.T) {
+	var log recordLogger
+	runSession(t, funcModel{init: Quit}, "", WithPersistence(""), WithLogger(&log))
+	if len(log.lines) == 0 || !strings.Contains(log.lines[0], "persistence disabled") {
+		t.Fatalf("logged %q", log.lines)
+	}
+}
+
+func TestStateFileEnvNeedsPersistence(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "state")
+	if err := writeFileAtomic(path, []byte("7")); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv(StateFileEnv, path)
+
+	if p := NewSession(loaderModel{}); p.statePath != "" {
+		t.Fatalf("statePath = %q without WithPersistence", p.statePath)
+	}
+
+	got := runSession(t, loaderModel{}, "", WithPersistence("app")).Model().(loaderModel)
+	if got.restored == nil || got.restored.Path != path || got.count != 7 {
+		t.Fatalf("restored = %+v, count = %d", got.restored, got.count)
+	}
+}
@@ -0,0 +1,47 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/pprof"
+	"time"
+)
+
+// WithPprof serves net/http/pprof at addr (e.g. "localhost:6060") while
+// the session runs, so `go tool pprof http://localhost:6060/debug/pprof/profile`
+// can profile a live program. The handlers are served on their own mux
+// and the listener closes when the session stops. To see where frames are
+// spent, fetch /debug/pprof/trace and open it with `go tool trace`: the
+// session marks Update, View and Render as trace regions.
+func WithPprof(addr string) Option { return func(p *Session) { p.pprofAddr = addr } }
+
+// startPprof listens on the pprof address until the session stops.
+func (p *Session) startPprof() error {
+	l, err := net.Listen("tcp", p.pprofAddr)
+	if err != nil {
+		return err
+	}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/debug/pprof/", pprof.Index)
+	mux.HandleFunc("/debug/pprof/cmdline", pprof.Cmdline)
+	mux.HandleFunc("/debug/pprof/profile", pprof.Profile)
+	mux.HandleFunc("/debug/pprof/symbol", pprof.Symbol)
+	mux.HandleFunc("/debug/pprof/trace", pprof.Trace)
+	srv := &http.Server{Handler: mux}
+	go func() {
+		if err := srv.Serve(l); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			p.logger.Errorf("pprof: %v", err)
+		}
+	}()
+	p.wg.Add(1)
+	go func() {
+		defer p.wg.Done()
+		<-p.ctx.Done()
+		ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+		defer cancel()
+		srv.Shutdown(ctx)
+	}()
+	return nil
+}
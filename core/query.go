@@ -0,0 +1,74 @@
+package core
+
+import (
+	"strconv"
+	"strings"
+)
+
+// ForegroundColorMsg reports the terminal's default foreground color, in
+// reply to QueryForegroundColor.
+type ForegroundColorMsg struct{ Color Color }
+
+// BackgroundColorMsg reports the terminal's default background color, in
+// reply to QueryBackgroundColor. Use Color.IsDark to pick a light or dark theme.
+type BackgroundColorMsg struct{ Color Color }
+
+// queryMsg asks the session to write a terminal query.
+type queryMsg struct{ seq string }
+
+// QueryForegroundColor asks the terminal for its foreground color (OSC 10).
+// Terminals that support it answer with a ForegroundColorMsg.
+func QueryForegroundColor() Cmd { return func() Msg { return queryMsg{seq: "\x1b]10;?\x07"} } }
+
+// QueryBackgroundColor asks the terminal for its background color (OSC 11).
+// Terminals that support it answer with a BackgroundColorMsg.
+func QueryBackgroundColor() Cmd { return func() Msg { return queryMsg{seq: "\x1b]11;?\x07"} } }
+
+// WithColorQuery queries the terminal's foreground and background colors at
+// startup.
+func WithColorQuery() Option { return func(p *Session) { p.colorQuery = true } }
+
+// parseOSC decodes an OSC payload (between "ESC ]" and the terminator).
+// Unknown payloads yield nil.
+func parseOSC(payload string) Msg {
+	code, value, ok := strings.Cut(payload, ";")
+	if !ok {
+		return nil
+	}
+	c, ok := parseXColor(value)
+	if !ok {
+		return nil
+	}
+	switch code {
+	case "10":
+		return ForegroundColorMsg{Color: c}
+	case "11":
+		return BackgroundColorMsg{Color: c}
+	}
+	return nil
+}
+
+// parseXColor parses "rgb:R/G/B" with 1-4 hex digits per channel.
+func parseXColor(s string) (Color, bool) {
+	rest, ok := strings.CutPrefix(s, "rgb:")
+	if !ok {
+		return Color{}, false
+	}
+	parts := strings.Split(rest, "/")
+	if len(parts) != 3 {
+		return Color{}, false
+	}
+	var ch [3]uint8
+	for i, p := range parts {
+		if len(p) == 0 || len(p) > 4 {
+			return Color{}, false
+		}
+		v, err := strconv.ParseUint(p, 16, 16)
+		if err != nil {
+			return Color{}, false
+		}
+		max := uint64(1)<<(4*len(p)) - 1
+		ch[i] = uint8(v * 255 / max)
+	}
+	return RGB(ch[0], ch[1], ch[2]), true
+}
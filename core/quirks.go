@@ -0,0 +1,139 @@
+package core
+
+import (
+	"os"
+	"strings"
+	"sync"
+)
+
+// KeyTranslations maps raw input sequences to the keys they stand for,
+// taking precedence over the built-in decoding. An empty String in a
+// KeyMsg is filled with the sequence.
+type KeyTranslations map[string]KeyMsg
+
+// KeyProfile holds the key translations for a family of terminals. It
+// applies when $TERM equals one of Terms or starts with one followed by
+// "-", so "rxvt" covers "rxvt-unicode-256color".
+type KeyProfile struct {
+	Terms []string
+	Keys  KeyTranslations
+}
+
+// keyProfiles are the terminal quirks profiles, consulted in order; every
+// matching profile applies, later ones overriding earlier ones.
+var keyProfiles = []KeyProfile{
+	{
+		// VT220-style Home/End, also sent by the Linux console and inside
+		// screen and tmux.
+		Terms: []string{"linux", "screen", "tmux", "rxvt", "eterm", "putty", "vt220"},
+		Keys: KeyTranslations{
+			"\x1b[1~": {Type: KeyHome},
+			"\x1b[4~": {Type: KeyEnd},
+		},
+	},
+	{
+		Terms: []string{"linux"},
+		Keys: KeyTranslations{
+			"\x1b[[A": {Type: KeyF1},
+			"\x1b[[B": {Type: KeyF2},
+			"\x1b[[C": {Type: KeyF3},
+			"\x1b[[D": {Type: KeyF4},
+			"\x1b[[E": {Type: KeyF5},
+		},
+	},
+	{
+		Terms: []string{"rxvt", "eterm"},
+		Keys: KeyTranslations{
+			"\x1b[7~": {Type: KeyHome},
+			"\x1b[8~": {Type: KeyEnd},
+		},
+	},
+	{
+		// The FreeBSD syscons console sends DEL from the Delete key and
+		// ^H from Backspace.
+		Terms: []string{"cons25", "xterm-sco"},
+		Keys: KeyTranslations{
+			"\x7f": {Type: KeyDelete},
+		},
+	},
+}
+
+// RegisterKeyProfile adds a quirks profile for terminals not covered by
+// the built-in ones, overriding them where both match. Register profiles
+// once, e.g. from init; for a single program WithKeyTranslations is
+// simpler.
+func RegisterKeyProfile(p KeyProfile) {
+	keyProfilesMu.Lock()
+	defer keyProfilesMu.Unlock()
+	keyProfiles = append(keyProfiles, p)
+}
+
+var keyProfilesMu sync.Mutex
+
+// WithKeyTranslations adds key translations on top of the quirks profile
+// for $TERM, for terminals that send nonstandard sequences.
+func WithKeyTranslations(t KeyTranslations) Option {
+	return func(p *Session) {
+		if p.keyTranslations == nil {
+			p.keyTranslations = KeyTranslations{}
+		}
+		for seq, k := range t {
+			p.keyTranslations[seq] = k
+		}
+	}
+}
+
+// keyTranslationsFor merges the profiles matching term with overrides.
+func keyTranslationsFor(term string, overrides KeyTranslations) KeyTranslations {
+	term = strings.ToLower(term)
+	t := KeyTranslations{}
+	keyProfilesMu.Lock()
+	defer keyProfilesMu.Unlock()
+	for _, p := range keyProfiles {
+		for _, name := range p.Terms {
+			if term == name || strings.HasPrefix(term, name+"-") {
+				for seq, k := range p.Keys {
+					t[seq] = k
+				}
+				break
+			}
+		}
+	}
+	for seq, k := range overrides {
+		t[seq] = k
+	}
+	for seq, k := range t {
+		if k.String == "" {
+			k.String = seq
+			t[seq] = k
+		}
+	}
+	if len(t) == 0 {
+		return nil
+	}
+	return t
+}
+
+// sessionKeyTranslations returns the translations for the session's
+// terminal.
+func sessionKeyTranslations(overrides KeyTranslations) KeyTranslations {
+	return keyTranslationsFor(os.Getenv("TERM"), overrides)
+}
+
+// translate matches the start of buf against keys, preferring the longest
+// sequence. n is the length matched, 0 if none. wait reports that buf
+// could still grow into a longer sequence, so the caller should wait for
+// more input unless flushing.
+func (keys KeyTranslations) translate(buf []byte) (msg Msg, n int, wait bool) {
+	for seq, k := range keys {
+		switch {
+		case len(seq) > len(buf):
+			if strings.HasPrefix(seq, string(buf)) {
+				wait = true
+			}
+		case len(seq) > n && string(buf[:len(seq)]) == seq:
+			msg, n = k, len(seq)
+		}
+	}
+	return msg, n, wait
+}
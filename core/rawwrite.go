@@ -0,0 +1,26 @@
+package core
+
+// rawWriteMsg asks the session to write data to the terminal.
+type rawWriteMsg struct{ data []byte }
+
+// RawWrite returns a command that writes data to the terminal as it is,
+// for escape sequences frog does not know, such as terminal-specific
+// features. The session writes it from its loop between frames, after the
+// frame for the update that returned the command, so it never interleaves
+// with the renderer's output. Sequences that change what is on screen
+// leave the renderer's idea of it stale; write those while rendering is
+// paused, as ResumeRendering repaints in full. Nothing is written in
+// script mode.
+func RawWrite(data []byte) Cmd {
+	data = append([]byte(nil), data...)
+	return func() Msg { return rawWriteMsg{data: data} }
+}
+
+// rawWrite writes msg's data, first drawing any frame left owed by frame
+// skipping so the data follows it.
+func (p *Session) rawWrite(msg rawWriteMsg) {
+	if p.frameOwed {
+		p.render()
+	}
+	p.writeRaw(string(msg.data))
+}
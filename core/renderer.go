@@ -1,13 +1,13 @@
 package core
 
 import (
-	"fmt"
+	"bytes"
 	"io"
 	"os"
+	"strconv"
 	"strings"
 	"sync"
-
-	"golang.org/x/term"
+	"time"
 )
 
 type Renderer interface {
@@ -16,6 +16,14 @@ type Renderer interface {
 	Close()
 }
 
+// invalidator is implemented by renderers that cache frame state.
+// Invalidate drops it so the next Render clears the screen and repaints in
+// full; the session calls it after terminal-mode transitions such as
+// switching to or from the alternate screen.
+type invalidator interface {
+	Invalidate()
+}
+
 // ---- Options
 
 type RendererOption func(*ansiRenderer)
@@ -26,13 +34,39 @@ func WithDiff(enabled bool) RendererOption { return func(r *ansiRenderer) { r.us
 // WithColorProfile forces a specific color profile (overrides auto-detection).
 func WithColorProfile(p ColorProfile) RendererOption { return func(r *ansiRenderer) { r.profile = p } }
 
+// WithSyncOutput wraps each frame in synchronized-output markers (DEC mode
+// 2026) so supporting terminals paint it atomically.
+func WithSyncOutput(enabled bool) RendererOption { return func(r *ansiRenderer) { r.sync = enabled } }
+
+// WithClipping hard-clips each line to the terminal width and the frame to
+// its height, so lines never wrap and the line diff stays in step with the
+// screen (default: enabled).
+func WithClipping(enabled bool) RendererOption { return func(r *ansiRenderer) { r.clip = enabled } }
+
+// WithSoftWrap wraps lines longer than the terminal width onto extra rows
+// instead of clipping them. Wrapping happens before diffing, so each
+// physical row is tracked and addressed on its own.
+func WithSoftWrap(enabled bool) RendererOption { return func(r *ansiRenderer) { r.softWrap = enabled } }
+
+// WithBidi reorders right-to-left text (Arabic, Hebrew) into display order
+// before drawing. See Reorder.
+func WithBidi(enabled bool) RendererOption { return func(r *ansiRenderer) { r.bidi = enabled } }
+
+// WithProgressive renders only the rows that fit on screen for views larger
+// than threshold bytes, skipping offscreen lines before any processing.
+func WithProgressive(threshold int) RendererOption {
+	return func(r *ansiRenderer) { r.progressive = threshold }
+}
+
+// WithTruncationIndicator replaces the last visible row with s when a view
+// is cut to the terminal height.
+func WithTruncationIndicator(s string) RendererOption {
+	return func(r *ansiRenderer) { r.truncMark = s }
+}
+
 // NewRenderer builds an ANSI renderer with options.
 func NewRenderer(out io.Writer, opts ...RendererOption) Renderer {
-	r := &ansiRenderer{
-		out:     out,
-		useDiff: true,
-		profile: ColorAuto,
-	}
+	r := newANSIRenderer(out)
 	for _, o := range opts {
 		o(r)
 	}
@@ -46,17 +80,38 @@ type ansiRenderer struct {
 	mu      sync.Mutex
 	last    string
 	lines   []string
+	spare   []string     // previous frame's line slice, reused for the next split
+	buf     bytes.Buffer // frame buffer; flushed with a single Write per frame
 	cleared bool
 	useDiff bool
+	sync    bool // wrap frames in DEC 2026 begin/end
+
+	clip          bool   // clip frames to width x height
+	softWrap      bool   // wrap long lines into physical rows
+	bidi          bool   // reorder RTL text for display
+	width, height int    // terminal size, 0 until known
+	progressive   int    // view size (bytes) above which rows are clipped to height
+	truncMark     string // shown on the last row when the view is clipped
+	resized       bool   // size changed since the last frame
+
+	dirty map[int]bool // rows the next frame changes, from hintDirty; nil diffs all
+
+	frameDrop      bool      // drop frames while the output is saturated
+	saturatedUntil time.Time // frames before this are dropped
+	dropped        bool      // frames were dropped since the last one drawn
+	styled         bool      // the last frame had escape sequences
 
 	profile ColorProfile // ColorAuto by default; lazily resolved on first Clear/Render
+	caps    termCaps     // control strings for $TERM
 }
 
 func newANSIRenderer(out io.Writer) *ansiRenderer {
 	return &ansiRenderer{
 		out:     out,
 		useDiff: true,
+		clip:    true,
 		profile: ColorAuto,
+		caps:    lookupTermCaps(os.Getenv("TERM")),
 	}
 }
 
@@ -71,22 +126,38 @@ func (r *ansiRenderer) Clear() {
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
-	r.ensureColorProfile()
-
-	// Hide cursor + clear screen + cursor home
-	fmt.Fprint(r.out, "\x1b[?25l\x1b[2J\x1b[H")
-	r.cleared = true
-	r.last = ""
-	r.lines = nil
+	r.clearLocked()
+	r.flushLocked()
 }
 
 func (r *ansiRenderer) Render(s string) {
 	r.mu.Lock()
 	defer r.mu.Unlock()
+	if r.dropFrameLocked() {
+		return
+	}
+
+	dirty := r.dirty
+	r.dirty = nil
+	if r.softWrap || r.resized || r.dropped {
+		dirty = nil // the hint does not cover dropped frames
+	}
+	r.resized, r.dropped = false, false
 
 	if !r.cleared {
 		r.clearLocked()
 	}
+	if dirty != nil && len(dirty) == 0 && len(r.lines) > 0 {
+		r.flushLocked()
+		return
+	}
+
+	if r.progressive > 0 && r.height > 0 && len(s) > r.progressive {
+		var clipped bool
+		if s, clipped = clipRows(s, r.height); clipped && r.truncMark != "" {
+			s = replaceLastRow(s, r.truncMark)
+		}
+	}
 
 	// Decide colors: strip if profile says None
 	r.ensureColorProfile()
@@ -94,71 +165,265 @@ func (r *ansiRenderer) Render(s string) {
 	if r.profile == ColorNone {
 		view = StripANSI(view)
 	}
+	if r.bidi {
+		view = Reorder(view)
+	}
+	if r.softWrap && r.width > 0 {
+		view = wrapFrame(view, r.width)
+	}
+	if r.clip {
+		view = clipFrame(view, r.width, r.height)
+	}
 
-	// Short-circuit if identical
-	if view == r.last {
+	// Short-circuit if identical; with a dirty hint the rows are compared
+	// selectively instead.
+	if dirty == nil && view == r.last {
+		r.flushLocked()
 		return
 	}
 
+	newLines := splitInto(r.spare[:0], view)
+	if r.sync {
+		r.buf.WriteString("\x1b[?2026h")
+	}
+
+	// Styles left open by the previous frame, or by earlier lines of this
+	// one, would carry into repainted lines; see diffLocked.
+	styled := escapeIndex(view) >= 0
+	restyle := styled || r.styled
 	if !r.useDiff || len(r.lines) == 0 {
 		// Full repaint
-		fmt.Fprint(r.out, "\x1b[H")
-		fmt.Fprint(r.out, view)
-		fmt.Fprint(r.out, "\x1b[0J")
-		r.last = view
-		r.lines = splitKeep(view)
-		return
+		r.buf.WriteString(r.caps.home)
+		if restyle {
+			r.buf.WriteString(sgrReset)
+		}
+		r.buf.WriteString(view)
+		if restyle {
+			r.buf.WriteString(sgrReset) // erase with the default background
+		}
+		r.buf.WriteString(r.caps.clearEOS)
+	} else {
+		r.diffLocked(newLines, dirty, restyle)
 	}
+	r.styled = styled
 
-	// Diff by lines
-	newLines := splitKeep(view)
+	if r.sync {
+		r.buf.WriteString("\x1b[?2026l")
+	}
+	r.last = view
+	r.spare = r.lines
+	r.lines = newLines
+	r.flushLocked()
+}
+
+// diffLocked writes only the lines that changed since the previous frame.
+// Unchanged lines keep the previous frame's string so later comparisons can
+// short-circuit on identical backing data. When dirty is non-nil, rows it
+// does not mark are taken as unchanged without comparing them.
+//
+// With restyle, each line written starts from a reset plus the styles
+// earlier lines leave open, as it would in a full repaint, rather than
+// whatever the last line written happened to end with.
+func (r *ansiRenderer) diffLocked(newLines []string, dirty map[int]bool, restyle bool) {
 	max := len(newLines)
 	if len(r.lines) > max {
 		max = len(r.lines)
 	}
 
+	var st sgrState
 	for i := 0; i < max; i++ {
-		var oldLine, newLine string
-		if i < len(r.lines) {
-			oldLine = r.lines[i]
+		if i >= len(newLines) {
+			moveCursor(&r.buf, i+1, 1)
+			if restyle {
+				r.buf.WriteString(sgrReset)
+			}
+			r.buf.WriteString(r.caps.clearEOL)
+			continue
 		}
-		if i < len(newLines) {
-			newLine = newLines[i]
+		start := st // copied: later appends to st.extra leave start's part alone
+		if restyle {
+			st.scan(newLines[i])
 		}
-
-		if i >= len(newLines) {
-			moveCursor(r.out, i+1, 1)
-			fmt.Fprint(r.out, "\x1b[2K")
+		if i < len(r.lines) && ((dirty != nil && !dirty[i]) || r.lines[i] == newLines[i]) {
+			newLines[i] = r.lines[i]
 			continue
 		}
-
-		if oldLine != newLine {
-			moveCursor(r.out, i+1, 1)
-			fmt.Fprint(r.out, newLine)
-			fmt.Fprint(r.out, "\x1b[0K")
+		moveCursor(&r.buf, i+1, 1)
+		if restyle {
+			r.buf.WriteString(sgrReset)
+			r.buf.WriteString(start.String())
+		}
+		r.buf.WriteString(newLines[i])
+		if restyle && !st.IsDefault() {
+			// A background left open would fill the erased cells on
+			// terminals with background color erase.
+			r.buf.WriteString(sgrReset)
 		}
+		r.buf.WriteString(r.caps.clearEOL)
 	}
+}
 
-	r.last = view
-	r.lines = newLines
+func (r *ansiRenderer) Invalidate() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.cleared = false
+	r.last = ""
+	r.lines = nil
 }
 
 func (r *ansiRenderer) Close() {
 	r.mu.Lock()
 	defer r.mu.Unlock()
-	fmt.Fprint(r.out, "\x1b[?25h")
+	r.buf.WriteString(r.caps.showCursor)
+	r.flushLocked()
+}
+
+// SetSize tells the renderer the terminal dimensions. The session calls it
+// on every ResizeMsg.
+func (r *ansiRenderer) SetSize(width, height int) {
+	r.mu.Lock()
+	if width != r.width || height != r.height {
+		r.resized = true
+	}
+	r.width, r.height = width, height
+	r.mu.Unlock()
+}
+
+// hintDirty limits the next frame's diff to rows; see DirtyViewer.
+func (r *ansiRenderer) hintDirty(rows map[int]bool) {
+	r.mu.Lock()
+	r.dirty = rows
+	r.mu.Unlock()
+}
+
+// current reports whether the screen shows the last frame as rendered, so
+// a frame the model reports unchanged can be skipped without calling View.
+func (r *ansiRenderer) current() bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.cleared && len(r.lines) > 0 && !r.softWrap && !r.resized && !r.dropped
 }
 
 // ---- Internals
 
+func (r *ansiRenderer) setSync(enabled bool) {
+	r.mu.Lock()
+	r.sync = enabled
+	r.mu.Unlock()
+}
+
 func (r *ansiRenderer) clearLocked() {
 	r.ensureColorProfile()
-	fmt.Fprint(r.out, "\x1b[?25l\x1b[2J\x1b[H")
+	// Hide cursor + clear screen + cursor home
+	r.buf.WriteString(r.caps.hideCursor)
+	r.buf.WriteString(r.caps.clear)
 	r.cleared = true
 	r.last = ""
 	r.lines = nil
 }
 
+// flushLocked writes the frame buffer to out and resets it. A frame that
+// could not be written whole leaves the screen unknown, so the next one
+// clears it and repaints.
+func (r *ansiRenderer) flushLocked() {
+	if r.buf.Len() == 0 {
+		return
+	}
+	waited, err := writeFull(r.out, r.buf.Bytes())
+	r.buf.Reset()
+	if err != nil {
+		r.cleared = false
+		r.last = ""
+		r.lines = nil
+	}
+	if waited > 0 {
+		r.saturatedUntil = time.Now().Add(waited)
+	}
+}
+
+// clipRows keeps the first n rows of s without scanning the rest.
+func clipRows(s string, n int) (string, bool) {
+	off := 0
+	for i := 0; i < n; i++ {
+		j := strings.IndexByte(s[off:], '\n')
+		if j < 0 {
+			return s, false
+		}
+		off += j + 1
+	}
+	return s[:off-1], true
+}
+
+// clipFrame truncates every line of s to w columns and keeps at most h
+// rows. Non-positive dimensions are treated as unknown and left unclipped.
+func clipFrame(s string, w, h int) string {
+	if h > 0 {
+		s, _ = clipRows(s, h)
+	}
+	if w <= 0 {
+		return s
+	}
+	var b strings.Builder
+	copied := false // b holds s up to the current line
+	for off := 0; off <= len(s); {
+		end := strings.IndexByte(s[off:], '\n')
+		if end < 0 {
+			end = len(s)
+		} else {
+			end += off
+		}
+		line := s[off:end]
+		if displayWidth(line) > w {
+			if !copied {
+				b.Grow(len(s))
+				b.WriteString(s[:off])
+				copied = true
+			}
+			line = Truncate(line, w)
+		}
+		if copied {
+			b.WriteString(line)
+			if end < len(s) {
+				b.WriteByte('\n')
+			}
+		}
+		off = end + 1
+	}
+	if !copied {
+		return s
+	}
+	return b.String()
+}
+
+// wrapFrame breaks every line of s wider than w columns into rows of at most
+// w columns. Styling active at a break is closed on the row and reapplied
+// on the next one.
+func wrapFrame(s string, w int) string {
+	lines := strings.Split(s, "\n")
+	wrapped := false
+	out := lines[:0:0]
+	for _, line := range lines {
+		for displayWidth(line) > w {
+			wrapped = true
+			out = append(out, Truncate(line, w))
+			rest, state := skipColumns(line, w)
+			line = state + rest
+		}
+		out = append(out, line)
+	}
+	if !wrapped {
+		return s
+	}
+	return strings.Join(out, "\n")
+}
+
+func replaceLastRow(s, row string) string {
+	if i := strings.LastIndexByte(s, '\n'); i >= 0 {
+		return s[:i+1] + row
+	}
+	return row
+}
+
 // Turn \r\n and \r into \n for stable diffs.
 func normalizeNewlines(s string) string {
 	if !strings.ContainsRune(s, '\r') {
@@ -169,27 +434,77 @@ func normalizeNewlines(s string) string {
 	return s
 }
 
-func splitKeep(s string) []string {
+// splitInto splits s on newlines, appending the substrings to dst.
+func splitInto(dst []string, s string) []string {
 	if s == "" {
-		return nil
+		return dst
+	}
+	for {
+		i := strings.IndexByte(s, '\n')
+		if i < 0 {
+			return append(dst, s)
+		}
+		dst = append(dst, s[:i])
+		s = s[i+1:]
+	}
+}
+
+func moveCursor(b *bytes.Buffer, row, col int) {
+	var num [20]byte
+	b.WriteString("\x1b[")
+	b.Write(strconv.AppendInt(num[:0], int64(row), 10))
+	b.WriteByte(';')
+	b.Write(strconv.AppendInt(num[:0], int64(col), 10))
+	b.WriteByte('H')
+}
+
+// ForceColorEnv, when set to anything but "", "0" or "false", keeps colors
+// in output that is not a terminal, like WithForceColor. NO_COLOR still
+// turns them off.
+const ForceColorEnv = "FROG_FORCE_COLOR"
+
+func forceColorFromEnv() bool {
+	switch strings.ToLower(strings.TrimSpace(os.Getenv(ForceColorEnv))) {
+	case "", "0", "false":
+		return false
 	}
-	return strings.Split(s, "\n")
+	return true
 }
 
-func moveCursor(w io.Writer, row, col int) {
-	fmt.Fprintf(w, "\x1b[%d;%dH", row, col)
+// keepColor reports whether non-interactive output keeps its styling.
+func (p *Session) keepColor() bool {
+	if strings.TrimSpace(os.Getenv("NO_COLOR")) != "" {
+		return false
+	}
+	return p.forceColor || forceColorFromEnv()
+}
+
+// outputFile returns the file out writes to, looking through writers that
+// wrap another and report it with Unwrap, such as the session's byte
+// counter.
+func outputFile(out io.Writer) (*os.File, bool) {
+	for {
+		switch w := out.(type) {
+		case *os.File:
+			return w, true
+		case interface{ Unwrap() io.Writer }:
+			out = w.Unwrap()
+		default:
+			return nil, false
+		}
+	}
 }
 
-// Honors NO_COLOR, checks TTY, then COLORTERM/TERM to choose 24-bit/256/16.
+// Honors NO_COLOR, checks TTY (unless FROG_FORCE_COLOR), then COLORTERM/TERM to choose 24-bit/256/16.
 func detectColorProfile(out io.Writer) ColorProfile {
 	// NO_COLOR -> no colors
 	if v := strings.TrimSpace(os.Getenv("NO_COLOR")); v != "" {
 		return ColorNone
 	}
 
-	// If not a terminal -> no colors
-	if f, ok := out.(*os.File); ok {
-		if !term.IsTerminal(int(f.Fd())) {
+	// If not a terminal -> no colors, unless forced
+	if f, ok := outputFile(out); ok && !forceColorFromEnv() {
+		if !IsTerminal(f) {
 			return ColorNone
 		}
 	}
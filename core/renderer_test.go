@@ -0,0 +1,92 @@
+package core
+
+import (
+	"io"
+	"os"
+	"strings"
+	"testing"
+)
+
+// Color detection sees through the writers the session wraps its output
+// in, so output that is not a terminal stays uncolored.
+func TestDetectColorProfileUnwraps(t *testing.T) {
+	t.Setenv("NO_COLOR", "")
+	t.Setenv(ForceColorEnv, "")
+	t.Setenv("COLORTERM", "truecolor")
+	_, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer w.Close()
+	for name, out := range map[string]io.Writer{
+		"file":    w,
+		"counted": &countingWriter{w: w},
+		"teed":    &countingWriter{w: &teeWriter{out: w, tees: []io.Writer{io.Discard}}},
+	} {
+		if got := detectColorProfile(out); got != ColorNone {
+			t.Errorf("%s: detectColorProfile = %v, want ColorNone for a pipe", name, got)
+		}
+	}
+}
+
+// viewRenderer records views and has no Invalidate.
+type viewRenderer struct{ views []string }
+
+func (r *viewRenderer) Clear()          {}
+func (r *viewRenderer) Render(s string) { r.views = append(r.views, s) }
+func (r *viewRenderer) Close()          {}
+
+func TestRendererWithoutInvalidate(t *testing.T) {
+	r := &viewRenderer{}
+	m := funcModel{
+		init: func() Cmd { return Sequence(EnterAltScreen(), ExitAltScreen(), Quit()) },
+		view: "v",
+	}
+	runSession(t, m, "", WithRenderer(r))
+	if len(r.views) == 0 {
+		t.Fatal("nothing rendered")
+	}
+}
+
+func TestDiffLocked(t *testing.T) {
+	const reset = sgrReset
+	tests := []struct {
+		name     string
+		old, new []string
+		dirty    map[int]bool
+		restyle  bool
+		want     string // with EL standing for the terminal's clear-to-EOL
+	}{
+		{"unchanged", []string{"a", "b"}, []string{"a", "b"}, nil, false, ""},
+		{"changed line", []string{"a", "b"}, []string{"a", "c"}, nil, false, "\x1b[2;1HcEL"},
+		{"shorter", []string{"a", "b"}, []string{"a"}, nil, false, "\x1b[2;1HEL"},
+		{"shorter restyled", []string{"a", "b"}, []string{"a"}, nil, true, "\x1b[2;1H" + reset + "EL"},
+		{"dirty rows only", []string{"a", "b"}, []string{"x", "c"}, map[int]bool{1: true}, false, "\x1b[2;1HcEL"},
+		{
+			"restyle reopens styles left open",
+			[]string{"\x1b[31mred", "x"}, []string{"\x1b[31mred", "y"}, nil, true,
+			"\x1b[2;1H" + reset + "\x1b[31my" + reset + "EL",
+		},
+		{
+			"restyle after closed styles",
+			[]string{"\x1b[1mbold" + reset, "x"}, []string{"\x1b[1mbold" + reset, "y"}, nil, true,
+			"\x1b[2;1H" + reset + "yEL",
+		},
+		{
+			"restyle keeps attributes and colors",
+			[]string{"\x1b[1;44mhead", "x"}, []string{"\x1b[1;44mhead", "y"}, nil, true,
+			"\x1b[2;1H" + reset + "\x1b[1;44my" + reset + "EL",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := newANSIRenderer(io.Discard)
+			r.lines = tt.old
+			r.diffLocked(tt.new, tt.dirty, tt.restyle)
+			want := strings.ReplaceAll(tt.want, "EL", r.caps.clearEOL)
+			if got := r.buf.String(); got != want {
+				t.Errorf("diff = %q, want %q", got, want)
+			}
+		})
+	}
+}
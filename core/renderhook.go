@@ -0,0 +1,27 @@
+package core
+
+import "time"
+
+// RenderStats describes one rendered frame.
+type RenderStats struct {
+	Bytes  int64         // bytes written for the frame (0 with a custom renderer)
+	Update time.Duration // last Update call
+	View   time.Duration // View call, including before hooks
+	Render time.Duration // diffing and writing the frame
+	FPS    float64       // frames per second over the last full second
+}
+
+type renderHook struct {
+	before func(view string) string
+	after  func(stats RenderStats)
+}
+
+// WithRenderHook registers hooks around every frame: before can rewrite the
+// view just before it is drawn (e.g. add a watermark or clamp widths), and
+// after receives the frame's timing. Either may be nil. Hooks run on the
+// session loop in the order they were added and must not block.
+func WithRenderHook(before func(view string) string, after func(stats RenderStats)) Option {
+	return func(p *Session) {
+		p.renderHooks = append(p.renderHooks, renderHook{before: before, after: after})
+	}
+}
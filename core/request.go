@@ -0,0 +1,88 @@
+package core
+
+import "sync/atomic"
+
+// RequestID identifies one command started with WithRequestID.
+type RequestID uint64
+
+// RequestMsg is the result of a command started with WithRequestID: the
+// message it returned and the ID it was started with.
+type RequestMsg struct {
+	ID  RequestID
+	Msg Msg
+}
+
+var lastRequestID atomic.Uint64
+
+// WithRequestID wraps cmd so its result arrives as a RequestMsg carrying a
+// new ID, and returns the ID. Concurrent commands of the same kind, such as
+// several fetches, can then be matched to what started them however their
+// results are ordered:
+//
+//	cmd, id := frog.WithRequestID(fetch(url))
+//	m.loading[id] = row
+//	...
+//	if id, msg, ok := frog.RequestIDFromMsg(msg); ok {
+//		row := m.loading[id]
+//		...
+//	}
+//
+// A nil result is not delivered, and results addressed to the session pass
+// through unwrapped: Quit's, Batch's and Sequence's, whose commands' results
+// are not wrapped either, and Tick's, whose TickMsg reaches the model as is.
+func WithRequestID(cmd Cmd) (Cmd, RequestID) {
+	id := RequestID(lastRequestID.Add(1))
+	if cmd == nil {
+		return nil, id
+	}
+	return func() Msg {
+		msg := cmd()
+		if _, ok := msg.(sessionMsg); msg == nil || ok {
+			return msg
+		}
+		return RequestMsg{ID: id, Msg: msg}
+	}, id
+}
+
+// RequestIDFromMsg returns the ID and the command's own message when msg
+// is a RequestMsg.
+func RequestIDFromMsg(msg Msg) (RequestID, Msg, bool) {
+	rm, ok := msg.(RequestMsg)
+	if !ok {
+		return 0, msg, false
+	}
+	return rm.ID, rm.Msg, true
+}
+
+// sessionMsg marks the messages addressed to the session rather than the
+// model. Every message type the loop handles itself must implement it.
+type sessionMsg interface{ forSession() }
+
+func (QuitMsg) forSession()         {}
+func (altScreenMsg) forSession()    {}
+func (autosaveMsg) forSession()     {}
+func (autosaveDoneMsg) forSession() {}
+func (autosaveDueMsg) forSession()  {}
+func (batchMsg) forSession()        {}
+func (busPublishMsg) forSession()   {}
+func (busSubMsg) forSession()       {}
+func (catchUpMsg) forSession()      {}
+func (clipboardMsg) forSession()    {}
+func (cmdPanicMsg) forSession()     {}
+func (cursorShapeMsg) forSession()  {}
+func (finallyMsg) forSession()      {}
+func (fpsMsg) forSession()          {}
+func (idleCheckMsg) forSession()    {}
+func (inspectMsg) forSession()      {}
+func (modelMsg) forSession()        {}
+func (pauseReqMsg) forSession()     {}
+func (queryMsg) forSession()        {}
+func (rawWriteMsg) forSession()     {}
+func (renderPauseMsg) forSession()  {}
+func (reportMsg) forSession()       {}
+func (resizeReadyMsg) forSession()  {}
+func (screenMsg) forSession()       {}
+func (sequenceMsg) forSession()     {}
+func (tickMsg) forSession()         {}
+func (timerFiredMsg) forSession()   {}
+func (timerMsg) forSession()        {}
@@ -0,0 +1,88 @@
+package core
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// WithResizeDebounce delays resizes until the terminal size has held still
+// for d, so dragging a window edge gives the model one ResizeMsg with the
+// final size instead of one per poll. The first size is delivered at once.
+// Whatever the debounce, resizes never pile up in the message queue: the
+// model gets the latest size when it catches up.
+func WithResizeDebounce(d time.Duration) Option {
+	return func(p *Session) { p.resizeDebounce = max(d, 0) }
+}
+
+// resizeSlot holds the latest size seen by the watcher until the loop
+// collects it, so at most one resize waits in the queue.
+type resizeSlot struct {
+	mu     sync.Mutex
+	size   ResizeMsg
+	queued bool
+}
+
+// resizeReadyMsg tells the loop that the resize slot holds a new size.
+type resizeReadyMsg struct{}
+
+// offerSize stores the size and queues a notification unless one is
+// already waiting.
+func (p *Session) offerSize(ctx context.Context, out chan<- Msg, w, h int) {
+	p.resize.mu.Lock()
+	p.resize.size = ResizeMsg{Width: w, Height: h}
+	queued := p.resize.queued
+	p.resize.queued = true
+	p.resize.mu.Unlock()
+	if queued {
+		return
+	}
+	select {
+	case out <- resizeReadyMsg{}:
+	case <-ctx.Done():
+	}
+}
+
+// takeSize collects the latest size.
+func (p *Session) takeSize() ResizeMsg {
+	p.resize.mu.Lock()
+	defer p.resize.mu.Unlock()
+	p.resize.queued = false
+	return p.resize.size
+}
+
+// watchSize polls the terminal size and offers it on change, once it has
+// settled for the debounce.
+func (p *Session) watchSize(ctx context.Context, out chan<- Msg) {
+	lastW, lastH := 0, 0 // delivered
+	if w, h, err := p.terminal.Size(); err == nil {
+		lastW, lastH = w, h
+		p.offerSize(ctx, out, w, h)
+	}
+	curW, curH := lastW, lastH // latest seen
+	var settled <-chan time.Time
+	ticker := p.clock.NewTicker(p.resizeInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C():
+			w, h, err := p.terminal.Size()
+			if err != nil || (w == curW && h == curH) {
+				continue
+			}
+			curW, curH = w, h
+			if p.resizeDebounce > 0 {
+				settled = p.clock.After(p.resizeDebounce)
+				continue
+			}
+		case <-settled:
+			settled = nil
+		}
+		if settled == nil && (curW != lastW || curH != lastH) {
+			lastW, lastH = curW, curH
+			p.offerSize(ctx, out, curW, curH)
+		}
+	}
+}
@@ -0,0 +1,43 @@
+package core
+
+// altScreenMsg asks the session to enter or leave the alternate screen.
+type altScreenMsg struct{ on bool }
+
+// EnterAltScreen returns a command that switches to the alternate screen.
+// The session repaints the current view there.
+func EnterAltScreen() Cmd {
+	return func() Msg { return altScreenMsg{on: true} }
+}
+
+// ExitAltScreen returns a command that returns to the main screen.
+func ExitAltScreen() Cmd {
+	return func() Msg { return altScreenMsg{on: false} }
+}
+
+// setAltScreen switches screens if needed. The renderer's frame cache
+// describes the screen being left, so it is invalidated; the caller
+// repaints.
+func (p *Session) setAltScreen(on bool) bool {
+	if on == p.inAltScreen {
+		return false
+	}
+	tc := lookupTermCaps(p.caps.Term)
+	seq := tc.exitAlt
+	if on {
+		seq = tc.enterAlt
+	}
+	if seq == "" {
+		return false
+	}
+	p.writeRaw(seq)
+	p.inAltScreen = on
+	p.invalidate()
+	return true
+}
+
+// invalidate makes the renderer repaint in full, if it caches frames.
+func (p *Session) invalidate() {
+	if r, ok := p.renderer.(invalidator); ok {
+		r.Invalidate()
+	}
+}
@@ -0,0 +1,84 @@
+package core
+
+import (
+	"encoding/json"
+	"io"
+	"sync"
+	"time"
+)
+
+// ScriptFormat selects how WithScriptOutput encodes events.
+type ScriptFormat int
+
+const (
+	ScriptJSON ScriptFormat = iota // one ScriptEvent JSON object per line
+)
+
+// ScriptEvent is one line of script output.
+type ScriptEvent struct {
+	Type string    `json:"type"` // "view" or "report"
+	Time time.Time `json:"time"`
+	View string    `json:"view,omitempty"` // the view as plain text, for "view"
+	Name string    `json:"name,omitempty"` // for "report"
+	Data any       `json:"data,omitempty"` // for "report"
+}
+
+// WithScriptOutput replaces frames with line-delimited events on the
+// output, so other programs can follow the app's progress: a "view" event
+// whenever the view's text changes, and a "report" event for each Report
+// command the model runs. The session runs its full loop even when the
+// output is not a terminal, without raw mode or other terminal modes;
+// input is still read from WithIn. WithRenderer takes precedence.
+func WithScriptOutput(format ScriptFormat) Option {
+	return func(p *Session) { p.scriptFormat, p.scriptOutput = format, true }
+}
+
+// reportMsg asks the session to publish a report event.
+type reportMsg struct {
+	name string
+	data any
+}
+
+// Report returns a command that publishes a named state change, such as
+// ("progress", 0.5) or ("done", result), as a "report" event of script
+// output. data must encode as JSON. Without WithScriptOutput it does
+// nothing.
+func Report(name string, data any) Cmd {
+	return func() Msg { return reportMsg{name: name, data: data} }
+}
+
+// scriptRenderer writes script events instead of drawing frames.
+type scriptRenderer struct {
+	mu    sync.Mutex
+	enc   *json.Encoder
+	clock Clock
+	last  string
+	drawn bool
+}
+
+func newScriptRenderer(w io.Writer, clock Clock) *scriptRenderer {
+	return &scriptRenderer{enc: json.NewEncoder(w), clock: clock}
+}
+
+// Render emits a view event when the text differs from the last one.
+func (r *scriptRenderer) Render(s string) {
+	view := StripANSI(normalizeNewlines(s))
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.drawn && view == r.last {
+		return
+	}
+	r.last, r.drawn = view, true
+	_ = r.enc.Encode(ScriptEvent{Type: "view", Time: r.clock.Now(), View: view})
+}
+
+func (r *scriptRenderer) report(name string, data any) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	_ = r.enc.Encode(ScriptEvent{Type: "report", Time: r.clock.Now(), Name: name, Data: data})
+}
+
+// Clear and Invalidate make the next Render emit the view even if unchanged.
+func (r *scriptRenderer) Clear()      { r.Invalidate() }
+func (r *scriptRenderer) Invalidate() { r.mu.Lock(); r.drawn = false; r.mu.Unlock() }
+func (r *scriptRenderer) Close()      {}
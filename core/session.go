@@ -6,11 +6,10 @@ import (
 	"io"
 	"os"
 	"os/signal"
+	"runtime/trace"
 	"sync"
-	"syscall"
+	"sync/atomic"
 	"time"
-
-	"golang.org/x/term"
 )
 
 // Option configures a Session at construction.
@@ -23,29 +22,109 @@ type Session struct {
 	input    *input
 
 	// IO
-	out io.Writer
-	in  io.Reader
+	out  io.Writer
+	in   io.Reader
+	tees []io.Writer
 
 	// control
-	msgCh          chan Msg
-	ctx            context.Context
-	cancel         context.CancelFunc
-	wg             sync.WaitGroup
-	startOnce      sync.Once
-	stopOnce       sync.Once
-	altScreen      bool
-	msgBuf         int
-	resizeInterval time.Duration
-	nonInteractive bool
+	msgCh           chan Msg
+	ctx             context.Context
+	cancel          context.CancelFunc
+	wg              sync.WaitGroup
+	startOnce       sync.Once
+	stopOnce        sync.Once
+	done            chan struct{} // closed when Run returns
+	altScreen       bool
+	inAltScreen     bool
+	msgBuf          int
+	resizeInterval  time.Duration
+	resizeDebounce  time.Duration
+	minWidth        int
+	minHeight       int
+	plainFrame      bool // the last frame was the model's view unaltered; see dirtyHint
+	catchUpArmed    bool // a redraw is scheduled for frames the renderer dropped
+	frameSkip       bool
+	idleAfter       time.Duration
+	idle            bool
+	idleArmed       bool      // an idleCheckMsg is on its way
+	lastActive      time.Time // on the session clock
+	idleView        string    // the last frame drawn, to spot changes
+	idleHeld        []Msg     // ticks and timers held while idle
+	renderPaused    bool
+	detected        DetectedModeMsg
+	frameOwed       bool // a deferred frame awaits an empty queue; see deferFrame
+	fps             int
+	fpsArmed        bool      // an fpsMsg is on its way
+	lastFrame       time.Time // on the session clock
+	resize          resizeSlot
+	escTimeout      time.Duration
+	pasteChunk      int
+	nonInteractive  bool
+	forceColor      bool
+	colorProfile    ColorProfile // from Config; ColorAuto detects
+	scriptOutput    bool
+	scriptFormat    ScriptFormat
+	script          *scriptRenderer // set with WithScriptOutput
+	inspectPath     string
+	noInspector     bool
+	inspector       *inspector // set with WithInspector
+	pprofAddr       string
+	keyTranslations KeyTranslations
+	keypad          bool
+	noSignals       bool
+	noCrashScreen   bool
 
 	// features
-	enableMouse         bool
+	enableMouse          bool
 	enableBracketedPaste bool
-
-	logger Logger
+	debugOverlay         bool
+	debugVisible         bool
+	help                 bool
+	helpVisible          bool
+	colorQuery           bool
+	cursorShape          CursorShape
+	cursorShapeSet       bool // a shape was applied; restore the default on exit
+
+	// runtime state
+	width, height int
+	timers        map[string]*sessionTimer
+	timerGen      uint64
+	subs          []busSub // event bus subscriptions, in order
+
+	autosave         func(Model) Cmd
+	autosaveInterval time.Duration
+	autosaveGen      uint64
+	autosaveStop     chan struct{}   // closed to cancel the pending autosave
+	autosaving       bool            // a save is running
+	written          *countingWriter // counts bytes written by the default renderer
+	stats            renderStats
+	renderHooks      []renderHook
+
+	logger  Logger
+	metrics Metrics
+	clock   Clock
+	deps    Deps
+
+	// inModel is set while the loop calls into the model, so Model and
+	// Screen called from Update or View answer at once instead of waiting
+	// for the loop. mmu orders the loop's writes of m with those reads.
+	inModel  atomic.Bool
+	mmu      sync.Mutex
+	pauseReq atomic.Int32 // PauseRendering called during a model call
+
+	cleanup         []Cmd
+	shutdownTimeout time.Duration
+
+	statePath  string // persistence target; empty when disabled
+	stateErr   error  // why WithPersistence could not be enabled
+	validation ValidationLevel
+	caps       *Caps // terminal capabilities; detected at Run unless provided
+	terminal   Terminal
 }
 
-// WithRenderer sets a custom renderer (useful in tests).
+// WithRenderer sets a custom renderer (useful in tests). A renderer that
+// caches frames can also implement Invalidate(), which the session calls
+// when the terminal needs a full repaint, such as after switching screens.
 func WithRenderer(r Renderer) Option { return func(p *Session) { p.renderer = r } }
 
 // WithAltScreen switches to the terminal alternate screen while the session runs.
@@ -75,9 +154,25 @@ func WithResizeInterval(d time.Duration) Option {
 	}
 }
 
+// WithEscapeTimeout sets how long the input decoder waits for the rest of
+// an escape sequence split across reads before treating it as typed keys
+// (default 50ms). This is also how long a lone Esc press is delayed; a
+// negative d disables waiting.
+func WithEscapeTimeout(d time.Duration) Option { return func(p *Session) { p.escTimeout = d } }
+
 // WithNonInteractive forces non-interactive mode (no raw mode, no input loop).
 func WithNonInteractive() Option { return func(p *Session) { p.nonInteractive = true } }
 
+// WithForceColor keeps colors and styling in non-interactive output, for
+// consumers that render them such as less -R or CI logs, instead of
+// stripping them. Setting FROG_FORCE_COLOR does the same; NO_COLOR still
+// strips them.
+func WithForceColor() Option { return func(p *Session) { p.forceColor = true } }
+
+// WithoutSignalHandler leaves SIGINT/SIGTERM to the host application, which
+// is then responsible for calling Session.Quit.
+func WithoutSignalHandler() Option { return func(p *Session) { p.noSignals = true } }
+
 // WithLogger sets a custom logger (defaults to std logger on stderr).
 func WithLogger(l Logger) Option { return func(p *Session) { p.logger = l } }
 
@@ -87,6 +182,42 @@ func WithMouse() Option { return func(p *Session) { p.enableMouse = true } }
 // WithBracketedPaste enables bracketed paste (ESC[200~ .. ESC[201~]).
 func WithBracketedPaste() Option { return func(p *Session) { p.enableBracketedPaste = true } }
 
+// WithKeypad switches the terminal's numeric keypad to application mode
+// and reports its keys as KeyKP0-KeyKP9, KeyKPEnter and the other KeyKP*
+// types, so apps can tell them from the main keyboard. Without it, keypad
+// keys a terminal sends in application mode anyway arrive as the digits,
+// operators and Enter they stand for.
+func WithKeypad() Option { return func(p *Session) { p.keypad = true } }
+
+// WithPasteChunks delivers bracketed pastes incrementally as PasteStartMsg,
+// PasteChunkMsg (at most size bytes each) and PasteEndMsg instead of one
+// PasteMsg, so large pastes can show progress and aren't size-limited.
+func WithPasteChunks(size int) Option {
+	return func(p *Session) {
+		if size > 0 {
+			p.pasteChunk = size
+		}
+	}
+}
+
+// WithDebugOverlay enables a debug HUD (fps, bytes per frame, queue depth,
+// Update/View durations) in the top-right corner, toggled with Ctrl+G.
+func WithDebugOverlay() Option { return func(p *Session) { p.debugOverlay = true } }
+
+// WithMetrics reports session counters (messages, renders, bytes, command
+// durations) to m.
+func WithMetrics(m Metrics) Option {
+	return func(p *Session) {
+		if m != nil {
+			p.metrics = m
+		}
+	}
+}
+
+// WithCapabilities overrides terminal capability detection, e.g. with the
+// result of ProbeCapabilities.
+func WithCapabilities(c Caps) Option { return func(p *Session) { p.caps = &c } }
+
 // NewSession creates a session for a given Model.
 func NewSession(m Model, opts ...Option) *Session {
 	return NewSessionWithContext(context.Background(), m, opts...)
@@ -100,24 +231,63 @@ func NewSessionWithContext(ctx context.Context, m Model, opts ...Option) *Sessio
 	cctx, cancel := context.WithCancel(ctx)
 
 	p := &Session{
-		m:              m,
-		out:            os.Stdout,
-		in:             os.Stdin,
-		msgBuf:         64,
-		ctx:            cctx,
-		cancel:         cancel,
-		resizeInterval: 150 * time.Millisecond,
-		logger:         newStdLogger(os.Stderr),
+		m:               m,
+		out:             os.Stdout,
+		in:              os.Stdin,
+		msgBuf:          64,
+		done:            make(chan struct{}),
+		ctx:             cctx,
+		cancel:          cancel,
+		resizeInterval:  150 * time.Millisecond,
+		shutdownTimeout: 2 * time.Second,
+		logger:          newStdLogger(os.Stderr),
+		metrics:         noopMetrics{},
+		clock:           realClock{},
 	}
 	for _, o := range opts {
 		o(p)
 	}
+	p.applyEnv()
+	p.validation = validationFromEnv(os.Getenv(ValidateEnv), p.validation)
+	p.inspectPath = inspectPathFromEnv(os.Getenv(InspectEnv), p.inspectPath)
+	if p.noInspector {
+		p.inspectPath = ""
+	}
+	if p.inspectPath != "" {
+		p.inspector = &inspector{}
+	}
 
 	// IO-derived components
 	if p.renderer == nil {
-		p.renderer = newANSIRenderer(p.out)
+		var w io.Writer = p.out
+		if len(p.tees) > 0 {
+			w = &teeWriter{out: p.out, tees: p.tees}
+		}
+		p.written = &countingWriter{w: w}
+		if p.scriptOutput {
+			p.script = newScriptRenderer(p.written, p.clock)
+			p.renderer = p.script
+		} else {
+			ar := newANSIRenderer(p.written)
+			ar.frameDrop = p.frameSkip
+			p.renderer = ar
+		}
+	}
+	if p.terminal == nil {
+		p.terminal = newFileTerminal(p.in, p.out)
+	} else if ar, ok := p.renderer.(*ansiRenderer); ok && p.caps != nil {
+		ar.profile = p.caps.ColorProfile
+	}
+	if ar, ok := p.renderer.(*ansiRenderer); ok && p.colorProfile != ColorAuto {
+		ar.profile = p.colorProfile
 	}
 	p.input = newInput(p.in)
+	p.input.pasteChunk = p.pasteChunk
+	p.input.keys = sessionKeyTranslations(p.keyTranslations)
+	p.input.keypad = p.keypad
+	if p.escTimeout != 0 {
+		p.input.escTimeout = max(p.escTimeout, 0)
+	}
 
 	// channel
 	p.msgCh = make(chan Msg, p.msgBuf)
@@ -127,53 +297,88 @@ func NewSessionWithContext(ctx context.Context, m Model, opts ...Option) *Sessio
 // Run starts the session and blocks until completion or error.
 func (p *Session) Run() (runErr error) {
 	p.startOnce.Do(func() {
+		defer close(p.done)
 		defer func() {
 			if r := recover(); r != nil {
-				p.logger.Errorf("panic: %v", r)
+				perr := newPanicError(r)
+				p.logger.Errorf("%v\n%s", perr, perr.Stack)
 				p.stopOnce.Do(func() {
 					p.cancel()
 					p.wg.Wait()
 					p.renderer.Close()
 					p.input.restore()
 				})
-				runErr = fmt.Errorf("panic: %v", r)
+				runErr = perr
 			}
 		}()
-		
 
-		// Determine interactive/tty
-		isTTY := func(w io.Writer) bool {
-			if f, ok := w.(*os.File); ok {
-				return term.IsTerminal(int(f.Fd()))
-			}
-			return false
+		if p.stateErr != nil {
+			p.logger.Warnf("%v", p.stateErr)
 		}
-		autoNonInteractive := !isTTY(p.out)
-		effectiveNonInteractive := p.nonInteractive || autoNonInteractive
+		if err := p.preflight(); err != nil {
+			runErr = err
+			return
+		}
+
+		// Determine the mode from which of input and output are terminals
+		detected := DetectedModeMsg{Mode: p.detectMode()}
+		renderOnce := detected.Mode == ModeNonInteractive || detected.Mode == ModePipedOutput
 
-		if effectiveNonInteractive {
-			// no raw, no loops; render once, strip ANSI
+		if renderOnce && p.script == nil {
+			// no raw, no loops; render once, strip ANSI unless forced
 			cmd := p.m.Init()
 			_ = cmd
+			_ = p.update(detected)
 			view := p.m.View()
-			fmt.Fprintln(p.out, StripANSI(view))
+			if !p.keepColor() {
+				view = StripANSI(view)
+			}
+			fmt.Fprintln(p.out, view)
 			return
 		}
 
-		// Interactive path
-		if err := p.input.raw(); err != nil {
+		if detected.Mode == ModePipedInput && p.script == nil {
+			if tty, ok := p.ttyInput(); ok {
+				defer tty.Close()
+				detected.TTYInput = true
+			}
+		}
+		p.detected = detected
+
+		// Interactive path; script output leaves the terminal alone
+		if p.script != nil {
+			p.altScreen, p.enableMouse, p.enableBracketedPaste, p.colorQuery = false, false, false, false
+			p.keypad = false
+			p.cursorShape, p.noCrashScreen = CursorDefault, true
+		} else if err := p.input.raw(p.terminal); err != nil {
 			runErr = fmt.Errorf("raw mode: %w", err)
 			return
 		}
 		defer p.input.restore()
 
+		if p.caps == nil {
+			c := capabilitiesOf(p.terminal, p.out)
+			p.caps = &c
+		}
+
 		// Alt screen
 		if p.altScreen {
-			fmt.Fprint(p.out, "\x1b[?1049h")
-			defer fmt.Fprint(p.out, "\x1b[?1049l")
+			p.setAltScreen(true)
 		}
+		defer p.setAltScreen(false)
 
-		// Feature toggles
+		// Feature toggles, gated on what the terminal supports
+		if p.enableMouse && p.caps.TTY && !p.caps.Mouse {
+			p.logger.Debugf("mouse disabled: not supported by TERM=%q", p.caps.Term)
+			p.enableMouse = false
+		}
+		if p.enableBracketedPaste && p.caps.TTY && !p.caps.BracketedPaste {
+			p.logger.Debugf("bracketed paste disabled: not supported by TERM=%q", p.caps.Term)
+			p.enableBracketedPaste = false
+		}
+		if ar, ok := p.renderer.(*ansiRenderer); ok && p.caps.SyncOutput && DetectMultiplexer() == MuxNone {
+			ar.setSync(true)
+		}
 		if p.enableMouse {
 			// 1000: report clicks, 1002: button-motion, 1006: SGR mode
 			fmt.Fprint(p.out, "\x1b[?1000h\x1b[?1002h\x1b[?1006h")
@@ -183,6 +388,25 @@ func (p *Session) Run() (runErr error) {
 			fmt.Fprint(p.out, "\x1b[?2004h")
 			defer fmt.Fprint(p.out, "\x1b[?2004l")
 		}
+		if p.keypad {
+			// DECKPAM: the keypad sends ESC O sequences
+			fmt.Fprint(p.out, "\x1b=")
+			defer fmt.Fprint(p.out, "\x1b>")
+		}
+
+		if p.cursorShape != CursorDefault {
+			p.setCursorShape(p.cursorShape)
+		}
+		defer func() {
+			if p.cursorShapeSet {
+				p.writeRaw(CursorDefault.sequence())
+			}
+		}()
+		if p.colorQuery {
+			p.writeRaw("\x1b]10;?\x07\x1b]11;?\x07")
+		}
+
+		defer p.stopTimers()
 
 		// Input reader
 		p.wg.Add(1)
@@ -198,48 +422,41 @@ func (p *Session) Run() (runErr error) {
 			p.watchSize(p.ctx, p.msgCh)
 		}()
 
-		// OS signals
-		sigCh := make(chan os.Signal, 2)
-		signal.Notify(sigCh, os.Interrupt, syscall.SIGTERM)
-		defer signal.Stop(sigCh)
-
-		// Initial cycle
-		cmd := p.m.Init()
-		p.renderer.Clear()
-		p.renderer.Render(p.m.View())
-		if cmd != nil {
-			go func(c Cmd) { p.msgCh <- c() }(cmd)
+		// OS signals; a nil channel never fires when the host handles them
+		var sigCh chan os.Signal
+		if !p.noSignals {
+			sigCh = make(chan os.Signal, 2)
+			signal.Notify(sigCh, stopSignals...)
+			defer signal.Stop(sigCh)
 		}
 
-		// Main loop
-	loop:
-		for {
-			select {
-			case <-p.ctx.Done():
-				break loop
-
-			case s := <-sigCh:
-				p.logger.Infof("signal: %v", s)
-				p.msgCh <- QuitMsg{}
-
-			case msg := <-p.msgCh:
-				if msg == nil {
-					continue
-				}
-				newModel, cmd := p.m.Update(msg)
-				p.m = newModel
-				p.renderer.Render(p.m.View())
-				if cmd != nil {
-					go func(c Cmd) { p.msgCh <- c() }(cmd)
-				}
-				if _, ok := msg.(QuitMsg); ok {
-					break loop
-				}
+		if p.inspector != nil {
+			if err := p.startInspector(); err != nil {
+				p.logger.Errorf("inspector: %v", err)
+			}
+		}
+		if p.pprofAddr != "" {
+			if err := p.startPprof(); err != nil {
+				p.logger.Errorf("pprof: %v", err)
 			}
 		}
 
+		if perr := p.loop(sigCh); perr != nil {
+			p.logger.Errorf("%v\n%s", perr, perr.Stack)
+			if !p.noCrashScreen {
+				p.showCrash(perr, sigCh)
+			}
+			runErr = perr
+		} else if q, ok := p.m.(Quitter); ok {
+			q.OnQuit()
+		}
+		if runErr == nil {
+			p.saveState()
+			p.finalAutoSave()
+		}
+		p.runCleanup()
 
-		// 
+		//
 		// p.stopOnce.Do(func() {
 		// 	p.cancel()
 		// 	p.wg.Wait()
@@ -250,10 +467,10 @@ func (p *Session) Run() (runErr error) {
 			p.renderer.Close()
 			p.input.restore()
 
-			done := make(chan struct {})
+			done := make(chan struct{})
 			go func() { p.wg.Wait(); close(done) }()
 			select {
-			case <- done:
+			case <-done:
 			case <-time.After(200 * time.Millisecond):
 			}
 		})
@@ -261,44 +478,437 @@ func (p *Session) Run() (runErr error) {
 	return runErr
 }
 
-// Send injects a message from outside (tests or background jobs).
-func (p *Session) Send(msg Msg) {
-	select {
-	case p.msgCh <- msg:
+// loop runs the initial cycle and the main message loop until the session
+// quits. A panic in Init, Update, View or a command is returned as a
+// *PanicError.
+func (p *Session) loop(sigCh <-chan os.Signal) (perr *PanicError) {
+	defer func() {
+		if r := recover(); r != nil {
+			perr = newPanicError(r)
+		}
+	}()
+
+	// Initial cycle
+	restored := p.restoreState()
+	p.inModel.Store(true)
+	cmd := p.m.Init()
+	p.inModel.Store(false)
+	p.renderer.Clear()
+	p.render()
+	// Delivered here rather than through msgCh: the input and resize
+	// goroutines may already have filled it, and only this loop drains it.
+	p.exec(p.update(p.detected))
+	p.render()
+	if len(p.deps.values) > 0 {
+		p.exec(p.update(DepsMsg{Deps: p.deps}))
+		p.render()
+	}
+	if restored != nil {
+		p.exec(p.update(restored))
+		p.render()
+	}
+	p.exec(cmd)
+	p.startIdle()
+	if st, ok := p.m.(Starter); ok {
+		p.inModel.Store(true)
+		m, startCmd := st.OnStart()
+		p.inModel.Store(false)
+		p.setModel(m)
+		p.render()
+		p.exec(startCmd)
+	}
+
+	// Main loop
+	for {
+		if p.frameOwed && p.fpsWait() <= 0 && (!p.frameSkip || len(p.msgCh) == 0) {
+			p.render()
+		}
+		select {
+		case <-p.ctx.Done():
+			return nil
+
+		case s := <-sigCh:
+			p.logger.Infof("signal: %v", s)
+			var msg Msg = QuitMsg{}
+			if _, ok := p.m.(Interrupter); ok && s == os.Interrupt {
+				msg = InterruptMsg{Signal: true}
+			}
+			go func() { // only this loop drains msgCh
+				select {
+				case p.msgCh <- msg:
+				case <-p.ctx.Done():
+				}
+			}()
+
+		case msg := <-p.msgCh:
+			if pm, ok := msg.(cmdPanicMsg); ok {
+				return pm.err
+			}
+			if _, ok := msg.(resizeReadyMsg); ok {
+				msg = p.takeSize()
+			}
+			if msg == nil || p.handleInternal(msg) || p.holdIdle(msg) {
+				continue
+			}
+			if isInput(msg) {
+				p.active()
+			}
+			if p.inspector != nil {
+				h, ok := p.m.(InputHider)
+				p.inspector.record(p.clock.Now(), msg, ok && h.HidingInput())
+			}
+			if p.debugOverlay {
+				if k, ok := msg.(KeyMsg); ok && k.String == debugToggleKey {
+					p.debugVisible = !p.debugVisible
+					p.render()
+					continue
+				}
+			}
+			if p.help {
+				if k, ok := msg.(KeyMsg); ok && p.helpKeyPress(k) {
+					p.render()
+					continue
+				}
+			}
+			if k, ok := msg.(KeyMsg); ok && k.Type == KeyCtrlC {
+				if _, ok := p.m.(Interrupter); ok {
+					msg = InterruptMsg{}
+				}
+			}
+			view := p.idleView
+			cmd := p.update(msg)
+			if !p.deferFrame(msg) {
+				p.render()
+			}
+			if p.idleView != view && !isInput(msg) && !isTimed(msg) {
+				p.active()
+			}
+			p.exec(cmd)
+			if _, ok := msg.(QuitMsg); ok {
+				return nil
+			}
+		}
+	}
+}
+
+// handleInternal processes messages addressed to the session itself and
+// reports whether msg was consumed.
+func (p *Session) handleInternal(msg Msg) bool {
+	switch msg := msg.(type) {
+	case batchMsg:
+		for _, c := range msg.cmds {
+			p.exec(c)
+		}
+	case sequenceMsg:
+		go p.run(msg.cmds...)
+	case clipboardMsg:
+		p.writeRaw(osc52(msg.text))
+	case queryMsg:
+		p.writeRaw(msg.seq)
+	case rawWriteMsg:
+		p.rawWrite(msg)
+	case cursorShapeMsg:
+		p.setCursorShape(msg.shape)
+	case modelMsg:
+		msg.reply <- p.m
+	case screenMsg:
+		msg.reply <- p.m.View()
+	case inspectMsg:
+		msg.reply <- debugState(p.m)
+	case reportMsg:
+		if p.script != nil {
+			p.script.report(msg.name, msg.data)
+		}
+	case finallyMsg:
+		p.cleanup = append(p.cleanup, msg.cmds...)
+	case tickMsg:
+		p.deliverAfter(msg.d, nil, func() Msg { return TickMsg{At: p.clock.Now()} })
+	case timerMsg:
+		p.handleTimer(msg)
+	case busSubMsg:
+		p.handleSub(msg)
+	case busPublishMsg:
+		p.publish(msg)
+	case autosaveMsg:
+		p.setAutoSave(msg)
+	case autosaveDueMsg:
+		p.autosaveDue(msg)
+	case autosaveDoneMsg:
+		p.autosaveDone(msg)
+	case timerFiredMsg:
+		if p.timerFired(msg) && !p.holdIdle(TimerMsg{ID: msg.id}) {
+			cmd := p.update(TimerMsg{ID: msg.id})
+			p.render()
+			p.exec(cmd)
+		}
+	case fpsMsg:
+		p.fpsArmed = false // the loop draws any owed frame
+	case pauseReqMsg:
+		p.applyPauseReq()
+	case renderPauseMsg:
+		p.pauseRendering(msg)
+	case idleCheckMsg:
+		p.idleCheck()
+	case catchUpMsg:
+		p.catchUpArmed = false
+		p.render()
+	case altScreenMsg:
+		if p.setAltScreen(msg.on) {
+			p.render()
+		}
 	default:
+		return false
 	}
+	return true
 }
 
-// Quit requests a graceful shutdown (helper).
-func (p *Session) Quit() { p.Send(QuitMsg{}) }
+// writeRaw writes control sequences outside of a frame.
+func (p *Session) writeRaw(s string) {
+	if p.script != nil {
+		return
+	}
+	if p.written != nil {
+		_, _ = io.WriteString(p.written, s)
+		return
+	}
+	_, _ = io.WriteString(p.out, s)
+}
 
-// watchSize polls terminal size and emits ResizeMsg on change.
-func (p *Session) watchSize(ctx context.Context, out chan<- Msg) {
-	fd := func(w io.Writer) int {
-		if f, ok := w.(*os.File); ok {
-			return int(f.Fd())
+// update applies msg to the model. ResizeMsg goes to OnResize when the model
+// implements Resizer, and InterruptMsg to OnInterrupt for an Interrupter.
+func (p *Session) update(msg Msg) Cmd {
+	start := time.Now()
+	defer func() {
+		p.stats.updateTime = time.Since(start)
+		p.metrics.MsgProcessed()
+	}()
+	region := trace.StartRegion(p.ctx, "frog.Update")
+	defer region.End()
+	if trace.IsEnabled() {
+		trace.Logf(p.ctx, "frog", "%T", msg)
+	}
+
+	if rs, ok := msg.(ResizeMsg); ok {
+		p.width, p.height = rs.Width, rs.Height
+		if sz, ok := p.renderer.(interface{ SetSize(w, h int) }); ok {
+			sz.SetSize(rs.Width, rs.Height)
 		}
-		return int(os.Stdout.Fd())
-	}(p.out)
+	}
+	p.inModel.Store(true)
+	m, cmd := p.updateModel(msg)
+	p.inModel.Store(false)
+	p.setModel(m)
+	p.applyPauseReq()
+	return cmd
+}
 
-	lastW, lastH := 0, 0
-	if w, h, err := term.GetSize(fd); err == nil {
-		lastW, lastH = w, h
-		out <- ResizeMsg{Width: w, Height: h}
+// updateModel passes msg to the model's Update or to the hook it has for
+// msg, returning the new model.
+func (p *Session) updateModel(msg Msg) (Model, Cmd) {
+	if rs, ok := msg.(ResizeMsg); ok {
+		if rz, ok := p.m.(Resizer); ok {
+			return rz.OnResize(rs.Width, rs.Height)
+		}
 	}
-	ticker := time.NewTicker(p.resizeInterval)
-	defer ticker.Stop()
-	for {
-		select {
-		case <-ctx.Done():
+	if im, ok := msg.(InterruptMsg); ok {
+		if in, ok := p.m.(Interrupter); ok {
+			return in.OnInterrupt(im)
+		}
+	}
+	return p.m.Update(msg)
+}
+
+// setModel replaces the model. Only the loop writes it.
+func (p *Session) setModel(m Model) {
+	p.mmu.Lock()
+	p.m = m
+	p.mmu.Unlock()
+}
+
+// render draws the current model, collecting frame statistics.
+func (p *Session) render() {
+	p.frameOwed = false
+	if p.renderPaused {
+		return
+	}
+	p.lastFrame = p.clock.Now()
+	ar, _ := p.renderer.(*ansiRenderer)
+	var dirty map[int]bool
+	if ar != nil {
+		// Asked before View, so unchanged frames cost nothing.
+		if dirty = p.dirtyHint(); dirty != nil && len(dirty) == 0 && ar.current() {
 			return
-		case <-ticker.C:
-			if w, h, err := term.GetSize(fd); err == nil {
-				if w != lastW || h != lastH {
-					lastW, lastH = w, h
-					out <- ResizeMsg{Width: w, Height: h}
-				}
+		}
+	}
+	var view string
+	if p.tooSmall() {
+		view = p.tooSmallView()
+	} else {
+		start := time.Now()
+		region := trace.StartRegion(p.ctx, "frog.View")
+		p.inModel.Store(true)
+		view = p.m.View()
+		p.inModel.Store(false)
+		for _, h := range p.renderHooks {
+			if h.before != nil {
+				view = h.before(view)
 			}
 		}
+		region.End()
+		p.stats.viewTime = time.Since(start)
+		if p.helpVisible {
+			view = p.withHelp(view)
+		}
+		if p.debugVisible {
+			view = p.withHUD(view)
+		}
 	}
+
+	var before, n int64
+	if p.written != nil {
+		before = p.written.n
+	}
+	renderStart := time.Now()
+	if ar != nil {
+		ar.hintDirty(dirty)
+	}
+	region := trace.StartRegion(p.ctx, "frog.Render")
+	p.renderer.Render(view)
+	region.End()
+	if ar != nil {
+		p.scheduleCatchUp(ar)
+	}
+	if p.idleAfter > 0 {
+		p.idleView = view
+	}
+	now := time.Now()
+	if p.written != nil {
+		n = p.written.n - before
+	}
+	p.stats.frame(now, n)
+	p.metrics.Rendered(n, now.Sub(renderStart))
+	if p.inspector != nil {
+		p.inspector.frame(InspectedStats{
+			FPS: p.stats.fps, LastBytes: n, UpdateTime: p.stats.updateTime, ViewTime: p.stats.viewTime,
+			Width: p.width, Height: p.height, LastFrame: now,
+		})
+	}
+	if len(p.renderHooks) == 0 {
+		return
+	}
+	rs := RenderStats{
+		Bytes:  n,
+		Update: p.stats.updateTime,
+		View:   p.stats.viewTime,
+		Render: now.Sub(renderStart),
+		FPS:    p.stats.fps,
+	}
+	for _, h := range p.renderHooks {
+		if h.after != nil {
+			h.after(rs)
+		}
+	}
+}
+
+// exec runs cmd in its own goroutine and feeds its result to the loop.
+func (p *Session) exec(cmd Cmd) {
+	if cmd == nil {
+		return
+	}
+	go p.run(cmd)
+}
+
+// run runs cmds in order, feeding each result to the loop before starting
+// the next.
+func (p *Session) run(cmds ...Cmd) {
+	defer func() {
+		if r := recover(); r != nil {
+			select {
+			case p.msgCh <- cmdPanicMsg{err: newPanicError(r)}:
+			case <-p.ctx.Done():
+			}
+		}
+	}()
+	for _, cmd := range cmds {
+		start := time.Now()
+		msg := cmd()
+		p.metrics.CmdFinished(time.Since(start))
+		select {
+		case p.msgCh <- msg:
+		case <-p.ctx.Done():
+			return
+		}
+	}
+}
+
+// Capabilities returns the terminal capabilities the session runs with,
+// detected on its own terminal and output unless WithCapabilities set them.
+func (p *Session) Capabilities() Caps {
+	if p.caps == nil {
+		return capabilitiesOf(p.terminal, p.out)
+	}
+	return *p.caps
 }
+
+// Send injects a message from outside (tests or background jobs).
+func (p *Session) Send(msg Msg) {
+	select {
+	case p.msgCh <- msg:
+	default:
+		p.metrics.MsgDropped()
+	}
+}
+
+// modelMsg asks the loop for the current model.
+type modelMsg struct{ reply chan<- Model }
+
+// Model returns the current model, read by the session loop between
+// messages so it is safe to call from any goroutine, including the loop
+// itself (from Update, say). Models with pointer receivers share state with
+// the loop; copy what you need. Before Run the call blocks until the loop
+// starts; after Run it returns the final model.
+func (p *Session) Model() Model {
+	if p.inModel.Load() {
+		p.mmu.Lock()
+		defer p.mmu.Unlock()
+		return p.m
+	}
+	reply := make(chan Model, 1)
+	select {
+	case p.msgCh <- modelMsg{reply: reply}:
+	case <-p.done:
+		return p.m
+	}
+	select {
+	case m := <-reply:
+		return m
+	case <-p.done:
+		return p.m
+	}
+}
+
+// screenMsg asks the loop for the current view.
+type screenMsg struct{ reply chan<- string }
+
+// Screen returns the model's current view, rendered by the session loop.
+// Like Model it is safe to call from any goroutine.
+func (p *Session) Screen() string {
+	if p.inModel.Load() {
+		return p.Model().View()
+	}
+	reply := make(chan string, 1)
+	select {
+	case p.msgCh <- screenMsg{reply: reply}:
+	case <-p.done:
+		return p.m.View()
+	}
+	select {
+	case v := <-reply:
+		return v
+	case <-p.done:
+		return p.m.View()
+	}
+}
+
+// Quit requests a graceful shutdown (helper).
+func (p *Session) Quit() { p.Send(QuitMsg{}) }
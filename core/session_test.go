@@ -0,0 +1,148 @@
+package core
+
+import (
+	"io"
+	"strings"
+	"testing"
+	"time"
+)
+
+// fakeTerminal is an interactive terminal of a fixed size.
+type fakeTerminal struct{ w, h int }
+
+func (t fakeTerminal) IsTerminal() bool                   { return true }
+func (t fakeTerminal) MakeRaw() (func() error, error)     { return func() error { return nil }, nil }
+func (t fakeTerminal) Size() (width, height int, _ error) { return t.w, t.h, nil }
+
+// funcModel is a Model built from an update function.
+type funcModel struct {
+	init   func() Cmd
+	update func(Msg) Cmd
+	view   string
+}
+
+func (m funcModel) Init() Cmd {
+	if m.init == nil {
+		return nil
+	}
+	return m.init()
+}
+
+func (m funcModel) Update(msg Msg) (Model, Cmd) {
+	if m.update == nil {
+		return m, nil
+	}
+	return m, m.update(msg)
+}
+
+func (m funcModel) View() string { return m.view }
+
+// runSession runs m on a fake 80x24 terminal with opts, typing input, and
+// fails the test if it does not quit within a few seconds.
+func runSession(t *testing.T, m Model, input string, opts ...Option) *Session {
+	t.Helper()
+	pr, pw := io.Pipe()
+	t.Cleanup(func() { pw.Close() })
+	in := io.MultiReader(strings.NewReader(input), pr)
+	opts = append([]Option{
+		WithTerminal(fakeTerminal{80, 24}), WithIn(in), WithOut(io.Discard), WithoutSignalHandler(),
+	}, opts...)
+	p := NewSession(m, opts...)
+	done := make(chan error, 1)
+	go func() { done <- p.Run() }()
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Fatalf("Run: %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("session did not quit")
+	}
+	return p
+}
+
+// The startup messages are delivered by the loop itself, so a full message
+// buffer cannot block it.
+func TestStartupMessagesWithFullBuffer(t *testing.T) {
+	for range 3 {
+		var got []string
+		m := funcModel{
+			// Give the input reader time to fill the buffer.
+			init: func() Cmd { time.Sleep(20 * time.Millisecond); return nil },
+			update: func(msg Msg) Cmd {
+				switch msg.(type) {
+				case DetectedModeMsg:
+					got = append(got, "mode")
+				case DepsMsg:
+					got = append(got, "deps")
+					return Quit()
+				}
+				return nil
+			},
+		}
+		runSession(t, m, "abcdef", WithMsgBuffer(1), WithValue("k", 1))
+		if strings.Join(got, " ") != "mode deps" {
+			t.Fatalf("got %v, want [mode deps]", got)
+		}
+	}
+}
+
+// Model and Screen answer directly when called from the loop instead of
+// waiting for it.
+func TestModelFromUpdate(t *testing.T) {
+	var p *Session
+	var view string
+	m := funcModel{
+		view: "hello",
+		update: func(msg Msg) Cmd {
+			if _, ok := msg.(DetectedModeMsg); ok {
+				if _, ok := p.Model().(funcModel); !ok {
+					t.Errorf("Model() = %#v", p.Model())
+				}
+				view = p.Screen()
+				return Quit()
+			}
+			return nil
+		},
+	}
+	runSession(t, m, "", func(s *Session) { p = s })
+	if view != "hello" {
+		t.Fatalf("Screen() = %q, want %q", view, "hello")
+	}
+}
+
+// PauseRendering and ResumeRendering called from Update take effect when
+// it returns, without deadlocking.
+func TestPauseRenderingFromUpdate(t *testing.T) {
+	var p *Session
+	var paused []bool
+	m := funcModel{
+		update: func(msg Msg) Cmd {
+			switch msg := msg.(type) {
+			case DetectedModeMsg:
+				p.PauseRendering()
+				return func() Msg { return numMsg(1) }
+			case numMsg:
+				paused = append(paused, p.renderPaused)
+				if msg == 1 {
+					p.ResumeRendering()
+					return func() Msg { return numMsg(2) }
+				}
+				return Quit()
+			}
+			return nil
+		},
+	}
+	runSession(t, m, "", func(s *Session) { p = s })
+	if len(paused) != 2 || !paused[0] || paused[1] {
+		t.Fatalf("paused = %v, want [true false]", paused)
+	}
+}
+
+// Capabilities are detected on the session's terminal, not on stdio.
+func TestCapabilitiesUseSessionTerminal(t *testing.T) {
+	p := runSession(t, funcModel{init: Quit}, "", WithTerminal(fakeTerminal{100, 30}))
+	if c := p.Capabilities(); !c.TTY || c.Width != 100 || c.Height != 30 {
+		t.Errorf("Capabilities() = %+v, want a 100x30 terminal", c)
+	}
+}
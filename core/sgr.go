@@ -0,0 +1,27 @@
+package core
+
+import (
+	"strings"
+
+	"github.com/pondworks-lib/frog/internal/sgr"
+)
+
+// sgrState is the graphic rendition in effect at a point of a frame, so a
+// line repainted on its own can start with the styles earlier lines left
+// open.
+type sgrState struct{ sgr.State }
+
+// scan applies the SGR sequences in s.
+func (st *sgrState) scan(s string) {
+	for i := escapeIndex(s); i >= 0 && i < len(s); {
+		n := ansiLen(s, i)
+		if n == 0 {
+			i++
+			continue
+		}
+		if seq := s[i : i+n]; strings.HasPrefix(seq, "\x1b[") && seq[n-1] == 'm' {
+			st.Apply(seq[2 : n-1])
+		}
+		i += n
+	}
+}
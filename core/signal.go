@@ -0,0 +1,12 @@
+//go:build !plan9
+
+package core
+
+import (
+	"os"
+	"syscall"
+)
+
+// stopSignals are the signals the session handles: interrupt and
+// termination requests. Windows reports closing the console as SIGTERM.
+var stopSignals = []os.Signal{os.Interrupt, syscall.SIGTERM}
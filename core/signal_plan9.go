@@ -0,0 +1,7 @@
+package core
+
+import "os"
+
+// stopSignals are the signals the session handles. Plan 9 has no separate
+// termination note; SIGTERM is the interrupt note.
+var stopSignals = []os.Signal{os.Interrupt}
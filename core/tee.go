@@ -0,0 +1,33 @@
+package core
+
+import "io"
+
+// WithTee copies everything the default renderer writes (frames after
+// diffing, plus control sequences such as alt-screen switches) to w, e.g.
+// a file or network connection mirroring a demo. Errors writing to w are
+// ignored so a broken mirror never affects the terminal. It has no effect
+// with a custom renderer.
+func WithTee(w io.Writer) Option {
+	return func(p *Session) {
+		if w != nil {
+			p.tees = append(p.tees, w)
+		}
+	}
+}
+
+// teeWriter writes to out and mirrors the bytes written to tees.
+type teeWriter struct {
+	out  io.Writer
+	tees []io.Writer
+}
+
+func (t *teeWriter) Write(b []byte) (int, error) {
+	n, err := t.out.Write(b)
+	for _, w := range t.tees {
+		_, _ = w.Write(b[:n])
+	}
+	return n, err
+}
+
+// Unwrap returns the terminal output t copies.
+func (t *teeWriter) Unwrap() io.Writer { return t.out }
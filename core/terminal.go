@@ -0,0 +1,120 @@
+package core
+
+import (
+	"errors"
+	"io"
+	"os"
+	"strconv"
+)
+
+// Terminal is the terminal a session runs on: whether output goes to an
+// interactive terminal, raw mode, and the size. By default it is derived
+// from WithIn and WithOut when they are terminal files.
+type Terminal interface {
+	// IsTerminal reports whether the session is interactive. When false,
+	// the session renders its view once and returns (see WithNonInteractive).
+	IsTerminal() bool
+	// MakeRaw puts the input into raw mode and returns a function that
+	// restores the previous mode.
+	MakeRaw() (restore func() error, err error)
+	// Size returns the width and height in cells; the session polls it to
+	// detect resizes.
+	Size() (width, height int, err error)
+}
+
+// WithTerminalFd makes the terminal device open on fd the session's
+// terminal, for raw mode, size and interactivity, when WithIn and WithOut
+// are not *os.File: a serial port opened by a library, say, whose reader
+// and writer wrap the descriptor. Serial consoles rarely know their size;
+// set $COLUMNS and $LINES to the far end's, otherwise 80x24 is assumed.
+func WithTerminalFd(fd uintptr) Option {
+	return func(p *Session) { p.terminal = fdTerminal(fd) }
+}
+
+// fdTerminal is the terminal on a bare file descriptor.
+type fdTerminal int
+
+func (t fdTerminal) IsTerminal() bool { return isTerminalFd(int(t)) }
+
+func (t fdTerminal) MakeRaw() (func() error, error) { return makeRawFd(int(t)) }
+
+func (t fdTerminal) Size() (int, int, error) {
+	if w, h, err := sizeOrEnv(int(t)); err == nil {
+		return w, h, nil
+	}
+	return 80, 24, nil
+}
+
+// WithTerminal replaces the terminal derived from WithIn and WithOut, for
+// terminals frog cannot reach through a file descriptor, such as xterm.js
+// in a browser. Frames use the color profile of WithCapabilities, which
+// should be given too: without it capabilities are guessed from the
+// process environment.
+func WithTerminal(t Terminal) Option {
+	return func(p *Session) { p.terminal = t }
+}
+
+// IsTerminal reports whether f is a terminal, on every system frog runs
+// on, Plan 9's console included.
+func IsTerminal(f *os.File) bool { return isTerminalFd(int(f.Fd())) }
+
+// fileTerminal is the default Terminal, on the files behind the session's
+// input and output. Raw mode needs a terminal file as input; other readers
+// are read as they are.
+type fileTerminal struct {
+	in, out *os.File
+}
+
+func newFileTerminal(in io.Reader, out io.Writer) fileTerminal {
+	var t fileTerminal
+	t.in, _ = in.(*os.File)
+	t.out, _ = out.(*os.File)
+	return t
+}
+
+func (t fileTerminal) IsTerminal() bool {
+	return t.out != nil && IsTerminal(t.out)
+}
+
+func (t fileTerminal) MakeRaw() (func() error, error) {
+	if t.in == nil {
+		return func() error { return nil }, nil
+	}
+	return makeRawFd(int(t.in.Fd()))
+}
+
+// Size measures the output, or stdout when the output is not a file. When
+// the system cannot tell, as on Plan 9 or some serial consoles, it falls
+// back to $COLUMNS and $LINES.
+func (t fileTerminal) Size() (int, int, error) {
+	f := t.out
+	if f == nil {
+		f = os.Stdout
+	}
+	return sizeOrEnv(int(f.Fd()))
+}
+
+// sizeOrEnv measures the terminal on fd, falling back to $COLUMNS and
+// $LINES.
+func sizeOrEnv(fd int) (int, int, error) {
+	w, h, err := fdSize(fd)
+	if err != nil || w <= 0 || h <= 0 {
+		if ew, eh, ok := envSize(); ok {
+			return ew, eh, nil
+		}
+		if err == nil {
+			err = errors.New("terminal reports no size")
+		}
+	}
+	return w, h, err
+}
+
+// envSize reads the size shells export in $COLUMNS and $LINES.
+func envSize() (width, height int, ok bool) {
+	w, err1 := strconv.Atoi(os.Getenv("COLUMNS"))
+	h, err2 := strconv.Atoi(os.Getenv("LINES"))
+	if err1 != nil || err2 != nil || w <= 0 || h <= 0 {
+		return 0, 0, false
+	}
+	return w, h, true
+}
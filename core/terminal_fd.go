@@ -0,0 +1,40 @@
+//go:build !plan9
+
+package core
+
+import (
+	"errors"
+	"os"
+	"runtime"
+	"syscall"
+
+	"golang.org/x/term"
+)
+
+func isTerminalFd(fd int) bool { return term.IsTerminal(fd) }
+
+// makeRawFd puts the terminal open on fd into raw mode.
+func makeRawFd(fd int) (restore func() error, err error) {
+	state, err := term.MakeRaw(fd)
+	if err != nil {
+		return nil, err
+	}
+	enableVirtualTerminal()
+	return func() error { return term.Restore(fd, state) }, nil
+}
+
+func fdSize(fd int) (width, height int, err error) { return term.GetSize(fd) }
+
+// wouldBlock reports whether a write failed because a non-blocking output
+// is full.
+func wouldBlock(err error) bool {
+	return errors.Is(err, syscall.EAGAIN) || errors.Is(err, syscall.EWOULDBLOCK)
+}
+
+// openTTY opens the controlling terminal.
+func openTTY() (*os.File, error) {
+	if runtime.GOOS == "windows" {
+		return os.OpenFile("CONIN$", os.O_RDWR, 0)
+	}
+	return os.OpenFile("/dev/tty", os.O_RDWR, 0)
+}
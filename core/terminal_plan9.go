@@ -0,0 +1,45 @@
+package core
+
+import (
+	"errors"
+	"os"
+	"path"
+	"syscall"
+)
+
+// On Plan 9 the terminal is the console device, /dev/cons, in rio or
+// vt(1). It is raw while "rawon" has been written to /dev/consctl and the
+// file stays open.
+
+func isTerminalFd(fd int) bool {
+	p, err := syscall.Fd2path(fd)
+	return err == nil && path.Base(p) == "cons"
+}
+
+// makeRawFd ignores fd: raw mode belongs to the console of the namespace.
+func makeRawFd(fd int) (restore func() error, err error) {
+	ctl, err := os.OpenFile("/dev/consctl", os.O_WRONLY, 0)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := ctl.WriteString("rawon"); err != nil {
+		ctl.Close()
+		return nil, err
+	}
+	return func() error {
+		_, err := ctl.WriteString("rawoff")
+		return errors.Join(err, ctl.Close())
+	}, nil
+}
+
+// fdSize has no way to ask the console for its size in cells; the
+// caller falls back to $COLUMNS and $LINES.
+func fdSize(fd int) (width, height int, err error) {
+	return 0, 0, errors.New("terminal size not available on plan9")
+}
+
+// wouldBlock reports false: Plan 9 has no non-blocking files.
+func wouldBlock(err error) bool { return false }
+
+// openTTY opens the console.
+func openTTY() (*os.File, error) { return os.OpenFile("/dev/cons", os.O_RDWR, 0) }
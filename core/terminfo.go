@@ -0,0 +1,226 @@
+package core
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// termCaps holds the non-parameterized control strings Frog needs. An empty
+// string means the terminal lacks the capability and the feature is skipped.
+// Cursor addressing always uses the ANSI form (ESC [ row ; col H).
+type termCaps struct {
+	clear      string // clear screen and home the cursor
+	home       string
+	clearEOL   string
+	clearEOS   string
+	hideCursor string
+	showCursor string
+	enterAlt   string
+	exitAlt    string
+}
+
+// ansiCaps is the xterm-compatible default.
+var ansiCaps = termCaps{
+	clear:      "\x1b[2J\x1b[H",
+	home:       "\x1b[H",
+	clearEOL:   "\x1b[0K",
+	clearEOS:   "\x1b[0J",
+	hideCursor: "\x1b[?25l",
+	showCursor: "\x1b[?25h",
+	enterAlt:   "\x1b[?1049h",
+	exitAlt:    "\x1b[?1049l",
+}
+
+// builtinCaps is the embedded fallback database, matched by TERM prefix.
+var builtinCaps = []struct {
+	prefix string
+	caps   termCaps
+}{
+	{"xterm", ansiCaps},
+	{"tmux", ansiCaps},
+	{"screen", termCaps{
+		clear: "\x1b[H\x1b[J", home: "\x1b[H", clearEOL: "\x1b[K", clearEOS: "\x1b[J",
+		hideCursor: "\x1b[?25l", showCursor: "\x1b[34h\x1b[?25h",
+		enterAlt: "\x1b[?1049h", exitAlt: "\x1b[?1049l",
+	}},
+	{"rxvt", termCaps{
+		clear: "\x1b[H\x1b[2J", home: "\x1b[H", clearEOL: "\x1b[K", clearEOS: "\x1b[J",
+		hideCursor: "\x1b[?25l", showCursor: "\x1b[?25h",
+		enterAlt: "\x1b7\x1b[?47h", exitAlt: "\x1b[2J\x1b[?47l\x1b8",
+	}},
+	{"linux", termCaps{
+		clear: "\x1b[H\x1b[J", home: "\x1b[H", clearEOL: "\x1b[K", clearEOS: "\x1b[J",
+		hideCursor: "\x1b[?25l\x1b[?1c", showCursor: "\x1b[?25h\x1b[?0c",
+	}},
+	{"vt1", termCaps{
+		clear: "\x1b[H\x1b[J", home: "\x1b[H", clearEOL: "\x1b[K", clearEOS: "\x1b[J",
+	}},
+	{"vt2", termCaps{
+		clear: "\x1b[H\x1b[J", home: "\x1b[H", clearEOL: "\x1b[K", clearEOS: "\x1b[J",
+		hideCursor: "\x1b[?25l", showCursor: "\x1b[?25h",
+	}},
+	{"dumb", termCaps{}},
+}
+
+// lookupTermCaps resolves capabilities for TERM: the system terminfo
+// database first, then the embedded table, then xterm-compatible defaults.
+func lookupTermCaps(termName string) termCaps {
+	if termName == "" {
+		return ansiCaps
+	}
+	if c, err := loadTerminfo(termName); err == nil {
+		return c.withDrawingDefaults()
+	}
+	for _, b := range builtinCaps {
+		if strings.HasPrefix(termName, b.prefix) {
+			return b.caps
+		}
+	}
+	return ansiCaps
+}
+
+// withDrawingDefaults fills the strings the renderer cannot work without.
+func (c termCaps) withDrawingDefaults() termCaps {
+	if c.clear == "" {
+		c.clear = ansiCaps.clear
+	}
+	if c.home == "" {
+		c.home = ansiCaps.home
+	}
+	if c.clearEOL == "" {
+		c.clearEOL = ansiCaps.clearEOL
+	}
+	if c.clearEOS == "" {
+		c.clearEOS = ansiCaps.clearEOS
+	}
+	return c
+}
+
+// Indices of the string capabilities we read, per term(5) ordering.
+const (
+	tiClearScreen     = 5
+	tiClrEOL          = 6
+	tiClrEOS          = 7
+	tiCursorHome      = 12
+	tiCursorInvisible = 13
+	tiCursorNormal    = 16
+	tiEnterCAMode     = 28
+	tiExitCAMode      = 40
+)
+
+func terminfoDirs() []string {
+	var dirs []string
+	if d := os.Getenv("TERMINFO"); d != "" {
+		dirs = append(dirs, d)
+	}
+	if home, err := os.UserHomeDir(); err == nil {
+		dirs = append(dirs, filepath.Join(home, ".terminfo"))
+	}
+	if d := os.Getenv("TERMINFO_DIRS"); d != "" {
+		for _, p := range filepath.SplitList(d) {
+			if p == "" {
+				p = "/usr/share/terminfo"
+			}
+			dirs = append(dirs, p)
+		}
+	}
+	return append(dirs, "/etc/terminfo", "/lib/terminfo", "/usr/share/terminfo", "/usr/lib/terminfo")
+}
+
+// loadTerminfo reads a compiled terminfo entry (legacy or 32-bit format).
+func loadTerminfo(termName string) (termCaps, error) {
+	if strings.ContainsAny(termName, `/\`) {
+		return termCaps{}, errors.New("invalid TERM")
+	}
+	var data []byte
+	for _, dir := range terminfoDirs() {
+		for _, sub := range []string{termName[:1], fmt.Sprintf("%x", termName[0])} {
+			b, err := os.ReadFile(filepath.Join(dir, sub, termName))
+			if err == nil {
+				data = b
+				break
+			}
+		}
+		if data != nil {
+			break
+		}
+	}
+	if data == nil {
+		return termCaps{}, os.ErrNotExist
+	}
+	return parseTerminfo(data)
+}
+
+func parseTerminfo(data []byte) (termCaps, error) {
+	errBad := errors.New("malformed terminfo")
+	if len(data) < 12 {
+		return termCaps{}, errBad
+	}
+	h := func(i int) int { return int(int16(binary.LittleEndian.Uint16(data[i*2:]))) }
+	numSize := 2
+	switch h(0) {
+	case 0o432:
+	case 0o1036:
+		numSize = 4
+	default:
+		return termCaps{}, errBad
+	}
+	namesSize, boolCount, numCount, strCount, tableSize := h(1), h(2), h(3), h(4), h(5)
+	off := 12 + namesSize + boolCount
+	if off%2 == 1 {
+		off++
+	}
+	off += numCount * numSize
+	offsets := off
+	table := offsets + strCount*2
+	if namesSize < 0 || boolCount < 0 || numCount < 0 || strCount < 0 || tableSize < 0 ||
+		table+tableSize > len(data) {
+		return termCaps{}, errBad
+	}
+
+	str := func(idx int) string {
+		if idx >= strCount {
+			return ""
+		}
+		o := int(int16(binary.LittleEndian.Uint16(data[offsets+idx*2:])))
+		if o < 0 || o >= tableSize {
+			return ""
+		}
+		s := data[table+o : table+tableSize]
+		if end := bytes.IndexByte(s, 0); end >= 0 {
+			s = s[:end]
+		}
+		return stripDelays(string(s))
+	}
+
+	return termCaps{
+		clear:      str(tiClearScreen),
+		home:       str(tiCursorHome),
+		clearEOL:   str(tiClrEOL),
+		clearEOS:   str(tiClrEOS),
+		hideCursor: str(tiCursorInvisible),
+		showCursor: str(tiCursorNormal),
+		enterAlt:   str(tiEnterCAMode),
+		exitAlt:    str(tiExitCAMode),
+	}, nil
+}
+
+// stripDelays removes $<n> padding specifications.
+func stripDelays(s string) string {
+	for {
+		i := strings.Index(s, "$<")
+		if i < 0 {
+			return s
+		}
+		j := strings.IndexByte(s[i:], '>')
+		if j < 0 {
+			return s
+		}
+		s = s[:i] + s[i+j+1:]
+	}
+}
@@ -0,0 +1,268 @@
+package text
+
+import "unicode"
+
+// Direction is a base text direction.
+type Direction int
+
+const (
+	DirAuto Direction = iota // from the first strong character, LTR if none
+	DirLTR
+	DirRTL
+)
+
+// bidiClass is a reduced set of UAX #9 bidirectional character types.
+type bidiClass uint8
+
+const (
+	classON  bidiClass = iota // neutral (includes separators and whitespace)
+	classWS                   // whitespace, reset at line end (rule L1)
+	classL                    // strong left-to-right
+	classR                    // strong right-to-left (Hebrew and others)
+	classAL                   // Arabic letter
+	classEN                   // European number
+	classAN                   // Arabic number
+	classNSM                  // non-spacing mark
+)
+
+func classOf(r rune) bidiClass {
+	switch {
+	case r >= '0' && r <= '9', r >= 0x06f0 && r <= 0x06f9:
+		return classEN
+	case r >= 0x0660 && r <= 0x0669, r == 0x066b, r == 0x066c:
+		return classAN
+	case unicode.In(r, unicode.Mn, unicode.Me):
+		return classNSM
+	case unicode.IsSpace(r):
+		return classWS
+	case r >= 0x0600 && r <= 0x07bf, r >= 0x0860 && r <= 0x08ff,
+		r >= 0xfb50 && r <= 0xfdff, r >= 0xfe70 && r <= 0xfeff:
+		return classAL
+	case r >= 0x0590 && r <= 0x05ff, r >= 0x07c0 && r <= 0x085f,
+		r >= 0xfb1d && r <= 0xfb4f, r >= 0x10800 && r <= 0x10fff, r >= 0x1e800 && r <= 0x1efff:
+		return classR
+	case unicode.IsLetter(r), unicode.Is(unicode.Mc, r):
+		return classL
+	}
+	return classON
+}
+
+func clusterClass(c string) bidiClass {
+	for _, r := range c {
+		return classOf(r)
+	}
+	return classON
+}
+
+// HasRTL reports whether s contains right-to-left characters and so needs
+// reordering for display.
+func HasRTL(s string) bool {
+	for _, r := range s {
+		if r >= 0x0590 {
+			if c := classOf(r); c == classR || c == classAL || c == classAN {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// ParagraphDirection returns the direction of the first strong character
+// in s (UAX #9 rules P2-P3), or DirLTR if there is none.
+func ParagraphDirection(s string) Direction {
+	for _, r := range s {
+		switch classOf(r) {
+		case classL:
+			return DirLTR
+		case classR, classAL:
+			return DirRTL
+		}
+	}
+	return DirLTR
+}
+
+// ResolveLevels returns the embedding level of each cluster of a single
+// line: even levels run left to right, odd levels right to left. It
+// implements the implicit part of UAX #9 (no explicit embeddings or
+// isolates).
+func ResolveLevels(clusters []string, dir Direction) []int {
+	n := len(clusters)
+	if dir == DirAuto {
+		dir = DirLTR
+		for _, c := range clusters {
+			if k := clusterClass(c); k == classL {
+				break
+			} else if k == classR || k == classAL {
+				dir = DirRTL
+				break
+			}
+		}
+	}
+	base := 0
+	sos := classL
+	if dir == DirRTL {
+		base, sos = 1, classR
+	}
+
+	types := make([]bidiClass, n)
+	for i, c := range clusters {
+		types[i] = clusterClass(c)
+	}
+
+	// W1-W3, W7: marks take the preceding type, numbers follow the last
+	// strong type, AL becomes R.
+	lastStrong := sos
+	for i, t := range types {
+		if t == classNSM {
+			if i == 0 {
+				t = sos
+			} else {
+				t = types[i-1]
+			}
+		}
+		switch t {
+		case classL, classR:
+			lastStrong = t
+		case classAL:
+			lastStrong, t = classAL, classR
+		case classEN:
+			if lastStrong == classAL {
+				t = classAN
+			} else if lastStrong == classL {
+				t = classL
+			}
+		}
+		types[i] = t
+	}
+
+	// N1-N2: neutrals between strong types of the same direction take it
+	// (numbers count as R); others take the base direction.
+	strongDir := func(t bidiClass) (bidiClass, bool) {
+		switch t {
+		case classL:
+			return classL, true
+		case classR, classAN, classEN:
+			return classR, true
+		}
+		return 0, false
+	}
+	for i := 0; i < n; {
+		if _, ok := strongDir(types[i]); ok {
+			i++
+			continue
+		}
+		j := i
+		for j < n {
+			if _, ok := strongDir(types[j]); ok {
+				break
+			}
+			j++
+		}
+		before, after := sos, sos
+		if i > 0 {
+			before, _ = strongDir(types[i-1])
+		}
+		if j < n {
+			after, _ = strongDir(types[j])
+		}
+		fill := sos
+		if before == after {
+			fill = before
+		}
+		for k := i; k < j; k++ {
+			if types[k] == classWS && j == n {
+				continue // left for L1
+			}
+			types[k] = fill
+		}
+		i = j
+	}
+
+	// I1-I2, then L1 for trailing whitespace.
+	levels := make([]int, n)
+	for i, t := range types {
+		lv := base
+		switch {
+		case base%2 == 0 && t == classR:
+			lv++
+		case base%2 == 0 && (t == classAN || t == classEN):
+			lv += 2
+		case base%2 == 1 && (t == classL || t == classEN || t == classAN):
+			lv++
+		}
+		levels[i] = lv
+	}
+	for i := n - 1; i >= 0 && types[i] == classWS; i-- {
+		levels[i] = base
+	}
+	return levels
+}
+
+// VisualOrder returns, for each visual position, the logical index to show
+// there (rule L2).
+func VisualOrder(levels []int) []int {
+	order := make([]int, len(levels))
+	hi, lowOdd := 0, -1
+	for i, lv := range levels {
+		order[i] = i
+		if lv > hi {
+			hi = lv
+		}
+		if lv%2 == 1 && (lowOdd < 0 || lv < lowOdd) {
+			lowOdd = lv
+		}
+	}
+	if lowOdd < 0 {
+		return order
+	}
+	for lv := hi; lv >= lowOdd; lv-- {
+		for i := 0; i < len(order); {
+			if levels[order[i]] < lv {
+				i++
+				continue
+			}
+			j := i
+			for j < len(order) && levels[order[j]] >= lv {
+				j++
+			}
+			for a, b := i, j-1; a < b; a, b = a+1, b-1 {
+				order[a], order[b] = order[b], order[a]
+			}
+			i = j
+		}
+	}
+	return order
+}
+
+var mirrors = map[string]string{
+	"(": ")", ")": "(", "[": "]", "]": "[", "{": "}", "}": "{",
+	"<": ">", ">": "<", "«": "»", "»": "«",
+}
+
+// Mirror returns the mirrored form of a bracket-like cluster shown in a
+// right-to-left run (rule L4), or c unchanged.
+func Mirror(c string) string {
+	if m, ok := mirrors[c]; ok {
+		return m
+	}
+	return c
+}
+
+// Reorder converts a single logical line without escape sequences into
+// display order. Lines without right-to-left text are returned unchanged.
+func Reorder(s string, dir Direction) string {
+	if !HasRTL(s) && dir != DirRTL {
+		return s
+	}
+	clusters := Graphemes(s)
+	levels := ResolveLevels(clusters, dir)
+	out := make([]byte, 0, len(s))
+	for _, i := range VisualOrder(levels) {
+		c := clusters[i]
+		if levels[i]%2 == 1 {
+			c = Mirror(c)
+		}
+		out = append(out, c...)
+	}
+	return string(out)
+}
@@ -0,0 +1,232 @@
+// Package text provides Unicode helpers for terminal text: grapheme cluster
+// segmentation, word movement and display width. Cursor positions are byte
+// offsets into a string and always fall on grapheme boundaries, so editing
+// never splits an emoji, ZWJ sequence or base character from its combining
+// marks.
+//
+// Segmentation follows the main rules of UAX #29 extended grapheme clusters
+// (CR LF, controls, extending and spacing marks, ZWJ emoji sequences,
+// regional indicator pairs and Hangul jamo); it does not use the full
+// property tables.
+package text
+
+import (
+	"unicode"
+	"unicode/utf8"
+)
+
+// NextGrapheme returns the byte offset of the grapheme boundary after i.
+// It returns len(s) at the end of the string.
+func NextGrapheme(s string, i int) int {
+	if i >= len(s) {
+		return len(s)
+	}
+	if i < 0 {
+		i = 0
+	}
+	prev, size := utf8.DecodeRuneInString(s[i:])
+	j := i + size
+	if prev == '\r' {
+		if j < len(s) && s[j] == '\n' {
+			return j + 1
+		}
+		return j
+	}
+	if isControl(prev) {
+		return j
+	}
+	riCount := 0
+	if isRegional(prev) {
+		riCount = 1
+	}
+	for j < len(s) {
+		r, size := utf8.DecodeRuneInString(s[j:])
+		if !joins(prev, r, &riCount) {
+			break
+		}
+		prev = r
+		j += size
+	}
+	return j
+}
+
+// joins reports whether r continues the cluster whose last rune is prev.
+func joins(prev, r rune, riCount *int) bool {
+	switch {
+	case isControl(r):
+		return false
+	case isExtend(r) || r == zwj:
+		return true
+	case prev == zwj && isPictographic(r):
+		return true
+	case isRegional(r):
+		if isRegional(prev) && *riCount%2 == 1 {
+			*riCount++
+			return true
+		}
+		return false
+	case isHangulL(prev) && (isHangulL(r) || isHangulV(r) || isHangulSyllable(r)):
+		return true
+	case (isHangulV(prev) || isHangulSyllable(prev)) && (isHangulV(r) || isHangulT(r)):
+		return true
+	case isHangulT(prev) && isHangulT(r):
+		return true
+	}
+	return false
+}
+
+// PrevGrapheme returns the byte offset of the grapheme boundary before i.
+// It returns 0 at the start of the string.
+func PrevGrapheme(s string, i int) int {
+	if i > len(s) {
+		i = len(s)
+	}
+	if i <= 0 {
+		return 0
+	}
+	// Clusters only extend forwards, so walk from a safe start: the nearest
+	// preceding rune that cannot continue a cluster.
+	start := safeStart(s, i)
+	last := start
+	for j := start; j < i; {
+		last = j
+		j = NextGrapheme(s, j)
+	}
+	return last
+}
+
+// safeStart finds an offset before i from which forward segmentation gives
+// the same boundaries as segmenting the whole string.
+func safeStart(s string, i int) int {
+	j := i
+	for j > 0 {
+		r, size := utf8.DecodeLastRuneInString(s[:j])
+		j -= size
+		if j == 0 {
+			return 0
+		}
+		prev, _ := utf8.DecodeLastRuneInString(s[:j])
+		// A boundary is certain before a control or after one, and between two
+		// ordinary characters where none of the joining rules apply, but
+		// never inside CR LF.
+		if prev == '\r' && r == '\n' {
+			continue
+		}
+		if isControl(r) || isControl(prev) {
+			return j
+		}
+		if !isExtend(r) && r != zwj && !isRegional(r) && !isHangul(r) && prev != zwj {
+			return j
+		}
+	}
+	return 0
+}
+
+// Graphemes splits s into grapheme clusters.
+func Graphemes(s string) []string {
+	var out []string
+	for i := 0; i < len(s); {
+		j := NextGrapheme(s, i)
+		out = append(out, s[i:j])
+		i = j
+	}
+	return out
+}
+
+// GraphemeCount returns the number of grapheme clusters in s.
+func GraphemeCount(s string) int {
+	n := 0
+	for i := 0; i < len(s); i = NextGrapheme(s, i) {
+		n++
+	}
+	return n
+}
+
+// NextWord returns the offset just past the end of the word at or after i,
+// skipping any separators first (the usual Ctrl+Right / Alt+F motion).
+func NextWord(s string, i int) int {
+	for i < len(s) && !isWordAt(s, i) {
+		i = NextGrapheme(s, i)
+	}
+	for i < len(s) && isWordAt(s, i) {
+		i = NextGrapheme(s, i)
+	}
+	return i
+}
+
+// PrevWord returns the offset of the start of the word before i, skipping
+// any separators first (Ctrl+Left / Alt+B).
+func PrevWord(s string, i int) int {
+	for i > 0 {
+		j := PrevGrapheme(s, i)
+		if isWordAt(s, j) {
+			break
+		}
+		i = j
+	}
+	for i > 0 {
+		j := PrevGrapheme(s, i)
+		if !isWordAt(s, j) {
+			break
+		}
+		i = j
+	}
+	return i
+}
+
+// isWordAt reports whether the cluster starting at i is part of a word.
+func isWordAt(s string, i int) bool {
+	r, _ := utf8.DecodeRuneInString(s[i:])
+	return r == '_' || unicode.IsLetter(r) || unicode.IsDigit(r) || unicode.IsMark(r)
+}
+
+const zwj = '\u200d'
+
+func isControl(r rune) bool {
+	return (r < 0x20 || (r >= 0x7f && r < 0xa0) || r == '\u2028' || r == '\u2029') && r != zwj
+}
+
+// isExtend covers Grapheme_Extend and SpacingMark: combining marks,
+// variation selectors, emoji skin-tone modifiers and tag characters.
+func isExtend(r rune) bool {
+	switch {
+	case unicode.In(r, unicode.Mn, unicode.Me, unicode.Mc):
+		return true
+	case r >= 0xfe00 && r <= 0xfe0f, r >= 0xe0100 && r <= 0xe01ef: // variation selectors
+		return true
+	case r >= 0x1f3fb && r <= 0x1f3ff: // skin tones
+		return true
+	case r >= 0xe0020 && r <= 0xe007f: // tags
+		return true
+	case r == '\u200c': // ZWNJ
+		return true
+	}
+	return false
+}
+
+func isRegional(r rune) bool { return r >= 0x1f1e6 && r <= 0x1f1ff }
+
+// isPictographic approximates Extended_Pictographic.
+func isPictographic(r rune) bool {
+	switch {
+	case r >= 0x1f000 && r <= 0x1faff:
+		return true
+	case r >= 0x2600 && r <= 0x27bf:
+		return true
+	case r >= 0x2300 && r <= 0x23ff, r >= 0x2b00 && r <= 0x2bff:
+		return true
+	case r == 0x00a9, r == 0x00ae, r == 0x203c, r == 0x2049, r == 0x2122, r == 0x2139:
+		return true
+	}
+	return false
+}
+
+func isHangulL(r rune) bool { return (r >= 0x1100 && r <= 0x115f) || (r >= 0xa960 && r <= 0xa97c) }
+func isHangulV(r rune) bool { return (r >= 0x1160 && r <= 0x11a7) || (r >= 0xd7b0 && r <= 0xd7c6) }
+func isHangulT(r rune) bool { return (r >= 0x11a8 && r <= 0x11ff) || (r >= 0xd7cb && r <= 0xd7fb) }
+
+func isHangulSyllable(r rune) bool { return r >= 0xac00 && r <= 0xd7a3 }
+
+func isHangul(r rune) bool {
+	return isHangulL(r) || isHangulV(r) || isHangulT(r) || isHangulSyllable(r)
+}
@@ -0,0 +1,65 @@
+package text
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestGraphemes(t *testing.T) {
+	tests := []struct {
+		name string
+		s    string
+		want []string
+	}{
+		{"ascii", "ab", []string{"a", "b"}},
+		{"crlf", "a\r\nb", []string{"a", "\r\n", "b"}},
+		{"lone cr", "a\rb", []string{"a", "\r", "b"}},
+		{"lf cr", "\n\r", []string{"\n", "\r"}},
+		{"cr cr lf", "\r\r\n", []string{"\r", "\r\n"}},
+		{"combining mark", "e\u0301x", []string{"e\u0301", "x"}},
+		{"combining after crlf", "\r\n\u0301", []string{"\r\n", "\u0301"}},
+		{"zwj sequence", "\U0001F469\u200d\U0001F4BB!", []string{"\U0001F469\u200d\U0001F4BB", "!"}},
+		{"flags", "🇫🇷🇩🇪", []string{"🇫🇷", "🇩🇪"}},
+		{"odd flags", "🇫🇷🇩", []string{"🇫🇷", "🇩"}},
+		{"hangul jamo", "\u1100\u1161\u11a8a", []string{"\u1100\u1161\u11a8", "a"}},
+		{"wide", "日本", []string{"日", "本"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Graphemes(tt.s); !slices.Equal(got, tt.want) {
+				t.Errorf("Graphemes(%q) = %q, want %q", tt.s, got, tt.want)
+			}
+			if n := GraphemeCount(tt.s); n != len(tt.want) {
+				t.Errorf("GraphemeCount(%q) = %d, want %d", tt.s, n, len(tt.want))
+			}
+			// Walking back from the end finds the same boundaries.
+			var forward, backward []int
+			for i := 0; i < len(tt.s); i = NextGrapheme(tt.s, i) {
+				forward = append(forward, i)
+			}
+			for i := len(tt.s); i > 0; {
+				i = PrevGrapheme(tt.s, i)
+				backward = append(backward, i)
+			}
+			slices.Reverse(backward)
+			if !slices.Equal(forward, backward) {
+				t.Errorf("%q: boundaries forward %v, backward %v", tt.s, forward, backward)
+			}
+		})
+	}
+}
+
+func TestGraphemeEnds(t *testing.T) {
+	if got := NextGrapheme("ab", 2); got != 2 {
+		t.Errorf("NextGrapheme at the end = %d, want 2", got)
+	}
+	if got := NextGrapheme("ab", -1); got != 1 {
+		t.Errorf("NextGrapheme(-1) = %d, want 1", got)
+	}
+	if got := PrevGrapheme("ab", 0); got != 0 {
+		t.Errorf("PrevGrapheme at the start = %d, want 0", got)
+	}
+	if got := PrevGrapheme("ab", 9); got != 1 {
+		t.Errorf("PrevGrapheme past the end = %d, want 1", got)
+	}
+}
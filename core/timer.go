@@ -0,0 +1,121 @@
+package core
+
+import "time"
+
+// TimerMsg is delivered when a named timer started with StartTimer or
+// ResetTimer fires. A timer that was stopped or reset never delivers a
+// stale TimerMsg.
+type TimerMsg struct{ ID string }
+
+type timerOp int
+
+const (
+	timerStart timerOp = iota
+	timerStop
+	timerReset
+)
+
+// timerMsg asks the session to start, stop or reset a named timer.
+type timerMsg struct {
+	op timerOp
+	id string
+	d  time.Duration
+}
+
+// timerFiredMsg is sent by a running timer; gen identifies which start it
+// belongs to so superseded timers are ignored.
+type timerFiredMsg struct {
+	id  string
+	gen uint64
+}
+
+type sessionTimer struct {
+	stop chan struct{}
+	gen  uint64
+}
+
+// StartTimer returns a command that starts the timer id, which emits
+// TimerMsg{ID: id} after d (min 1ms). It does nothing if id is already
+// running.
+func StartTimer(id string, d time.Duration) Cmd {
+	return func() Msg { return timerMsg{op: timerStart, id: id, d: d} }
+}
+
+// StopTimer returns a command that cancels the timer id. Pending fires are
+// discarded.
+func StopTimer(id string) Cmd {
+	return func() Msg { return timerMsg{op: timerStop, id: id} }
+}
+
+// ResetTimer returns a command that restarts the timer id with duration d,
+// starting it if it is not running.
+func ResetTimer(id string, d time.Duration) Cmd {
+	return func() Msg { return timerMsg{op: timerReset, id: id, d: d} }
+}
+
+func (p *Session) handleTimer(msg timerMsg) {
+	cur, running := p.timers[msg.id]
+	switch msg.op {
+	case timerStart:
+		if running {
+			return
+		}
+	case timerStop:
+		if running {
+			close(cur.stop)
+			delete(p.timers, msg.id)
+		}
+		return
+	}
+	if running {
+		close(cur.stop)
+	}
+	d := msg.d
+	if d <= 0 {
+		d = time.Millisecond
+	}
+	if p.timers == nil {
+		p.timers = make(map[string]*sessionTimer)
+	}
+	p.timerGen++
+	fired := timerFiredMsg{id: msg.id, gen: p.timerGen}
+	t := &sessionTimer{gen: fired.gen, stop: make(chan struct{})}
+	p.timers[msg.id] = t
+	p.deliverAfter(d, t.stop, func() Msg { return fired })
+}
+
+// deliverAfter sends the message made by msg to the loop once d has passed
+// on the session clock, unless stop is closed first.
+func (p *Session) deliverAfter(d time.Duration, stop <-chan struct{}, msg func() Msg) {
+	after := p.clock.After(d)
+	go func() {
+		select {
+		case <-after:
+		case <-stop:
+			return
+		case <-p.ctx.Done():
+			return
+		}
+		select {
+		case p.msgCh <- msg():
+		case <-p.ctx.Done():
+		}
+	}()
+}
+
+// timerFired reports whether msg belongs to a live timer and retires it.
+func (p *Session) timerFired(msg timerFiredMsg) bool {
+	cur, ok := p.timers[msg.id]
+	if !ok || cur.gen != msg.gen {
+		return false
+	}
+	delete(p.timers, msg.id)
+	return true
+}
+
+func (p *Session) stopTimers() {
+	for id, t := range p.timers {
+		close(t.stop)
+		delete(p.timers, id)
+	}
+}
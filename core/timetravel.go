@@ -0,0 +1,164 @@
+package core
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// TimeTravelOption configures WithTimeTravel.
+type TimeTravelOption func(*TimeTravel)
+
+// TimeTravelLimit caps the number of recorded messages (default 1000).
+func TimeTravelLimit(n int) TimeTravelOption {
+	return func(t *TimeTravel) {
+		if n > 0 {
+			t.limit = n
+		}
+	}
+}
+
+// TimeTravelDumpFile sets where the message log is written when the dump
+// key is pressed (default "frog-timetravel.log").
+func TimeTravelDumpFile(path string) TimeTravelOption {
+	return func(t *TimeTravel) {
+		if path != "" {
+			t.dumpPath = path
+		}
+	}
+}
+
+type ttEntry struct {
+	msg   Msg
+	model Model // snapshot after msg was applied
+}
+
+// TimeTravel records every (Msg, Model) pair of the wrapped model. Ctrl+T
+// pauses the display; while paused, ←/→ step through recorded states, d
+// dumps the message log to a file, and Ctrl+T resumes. Non-input messages
+// keep updating the live model while paused.
+type TimeTravel struct {
+	model    Model
+	initial  Model
+	entries  []ttEntry
+	dropped  int // entries discarded by the limit
+	limit    int
+	paused   bool
+	cursor   int // entry shown while paused; -1 is the initial state
+	dumpPath string
+	status   string
+}
+
+// ttToggleKey pauses and resumes time travel (Ctrl+T).
+const ttToggleKey = "\x14"
+
+// WithTimeTravel wraps m with a time-travel debugger.
+func WithTimeTravel(m Model, opts ...TimeTravelOption) *TimeTravel {
+	t := &TimeTravel{
+		model:    m,
+		initial:  snapshot(m),
+		limit:    1000,
+		dumpPath: "frog-timetravel.log",
+	}
+	for _, o := range opts {
+		o(t)
+	}
+	return t
+}
+
+// Model returns the live wrapped model.
+func (t *TimeTravel) Model() Model { return t.model }
+
+func (t *TimeTravel) Init() Cmd { return t.model.Init() }
+
+func (t *TimeTravel) Update(msg Msg) (Model, Cmd) {
+	if k, ok := msg.(KeyMsg); ok && k.String == ttToggleKey {
+		t.paused = !t.paused
+		t.cursor = len(t.entries) - 1
+		t.status = ""
+		return t, nil
+	}
+	if t.paused {
+		switch msg := msg.(type) {
+		case KeyMsg:
+			t.navigate(msg)
+			return t, nil
+		case MouseMsg, PasteMsg:
+			return t, nil
+		}
+	}
+
+	var cmd Cmd
+	t.model, cmd = t.model.Update(msg)
+	t.entries = append(t.entries, ttEntry{msg: msg, model: snapshot(t.model)})
+	if len(t.entries) > t.limit {
+		t.initial = t.entries[0].model
+		t.entries[0] = ttEntry{}
+		t.entries = t.entries[1:]
+		t.dropped++
+		if t.paused && t.cursor >= 0 {
+			t.cursor--
+		}
+	}
+	return t, cmd
+}
+
+func (t *TimeTravel) navigate(k KeyMsg) {
+	switch {
+	case k.Type == KeyLeft && t.cursor >= 0:
+		t.cursor--
+	case k.Type == KeyRight && t.cursor < len(t.entries)-1:
+		t.cursor++
+	case k.Type == KeyHome:
+		t.cursor = -1
+	case k.Type == KeyEnd:
+		t.cursor = len(t.entries) - 1
+	case k.Type == KeyRune && k.Rune == 'd':
+		if err := t.dumpFile(); err != nil {
+			t.status = "dump failed: " + err.Error()
+		} else {
+			t.status = "dumped to " + t.dumpPath
+		}
+	}
+}
+
+func (t *TimeTravel) View() string {
+	if !t.paused {
+		return t.model.View()
+	}
+	m, label := t.initial, "initial state"
+	if t.cursor >= 0 {
+		e := t.entries[t.cursor]
+		m, label = e.model, fmt.Sprintf("%T %+v", e.msg, e.msg)
+	}
+	bar := fmt.Sprintf(" ⏸ step %d/%d  %s  ←/→ step  d dump  ^T resume ",
+		t.cursor+1+t.dropped, len(t.entries)+t.dropped, label)
+	if t.status != "" {
+		bar += " " + t.status + " "
+	}
+	return m.View() + "\n" + hudStyle.Render(bar)
+}
+
+// Dump writes the recorded message log to w, one message per line.
+func (t *TimeTravel) Dump(w io.Writer) error {
+	for i, e := range t.entries {
+		line := strings.ReplaceAll(fmt.Sprintf("%+v", e.msg), "\n", `\n`)
+		if _, err := fmt.Fprintf(w, "%06d %T %s\n", i+t.dropped+1, e.msg, line); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (t *TimeTravel) dumpFile() error {
+	f, err := os.Create(t.dumpPath)
+	if err != nil {
+		return err
+	}
+	if err := t.Dump(f); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
@@ -12,6 +12,8 @@ import (
 	"unicode/utf8"
 
 	"golang.org/x/term"
+
+	"github.com/pondworks-lib/frog/internal/sgr"
 )
 
 type Code string
@@ -38,6 +40,37 @@ const (
 	CodeHasNoMethods    Code = "FROG106"
 	CodeUpdateNotMethod Code = "FROG107"
 	CodeViewNotMethod   Code = "FROG108"
+	CodeUpdateIdentity  Code = "FROG109"
+	CodeViewUnsafe      Code = "FROG110"
+)
+
+// Error makes each Code usable as a sentinel: errors.Is(err, CodeMissingView)
+// reports whether a validation error contains that issue.
+func (c Code) Error() string { return string(c) }
+
+// Sentinels for errors.Is, one per Code.
+var (
+	ErrNilModel           error = CodeNilModel
+	ErrMissingView        error = CodeMissingView
+	ErrEmptyView          error = CodeEmptyView
+	ErrViewNotString      error = CodeViewNotString
+	ErrViewHasBadRunes    error = CodeViewHasBadRunes
+	ErrViewPanic          error = CodeViewPanic
+	ErrMissingUpdate      error = CodeMissingUpdate
+	ErrBadUpdateSignature error = CodeBadUpdateSignature
+	ErrMissingInit        error = CodeMissingInit
+	ErrBadInitSignature   error = CodeBadInitSignature
+
+	ErrViewVeryLarge   error = CodeViewVeryLarge
+	ErrViewSuspicious  error = CodeViewSuspicious
+	ErrNonExportedType error = CodeNonExportedType
+	ErrSlowView        error = CodeSlowView
+	ErrSlowInit        error = CodeSlowInit
+	ErrHasNoMethods    error = CodeHasNoMethods
+	ErrUpdateNotMethod error = CodeUpdateNotMethod
+	ErrViewNotMethod   error = CodeViewNotMethod
+	ErrUpdateIdentity  error = CodeUpdateIdentity
+	ErrViewUnsafe      error = CodeViewUnsafe
 )
 
 type Severity int
@@ -80,10 +113,46 @@ func (i Issue) String() string {
 	return sb.String()
 }
 
+// Error implements error, so an Issue can be extracted with errors.As.
+func (i Issue) Error() string { return i.String() }
+
+// Unwrap returns the issue's Code, which makes errors.Is match sentinels.
+func (i Issue) Unwrap() error { return i.Code }
+
+// Report is the error returned by ValidateModel. Use errors.Is with a Code
+// sentinel or errors.As with an Issue to inspect it.
 type Report struct {
 	issues []Issue
 }
 
+// Issues returns all issues in the order they were found.
+func (r *Report) Issues() []Issue { return append([]Issue(nil), r.issues...) }
+
+// Errors returns the issues with SeverityError.
+func (r *Report) Errors() []Issue { return r.filter(SeverityError) }
+
+// Warnings returns the issues with SeverityWarning.
+func (r *Report) Warnings() []Issue { return r.filter(SeverityWarning) }
+
+func (r *Report) filter(s Severity) []Issue {
+	var out []Issue
+	for _, it := range r.issues {
+		if it.Severity == s {
+			out = append(out, it)
+		}
+	}
+	return out
+}
+
+// Unwrap exposes each issue to errors.Is and errors.As.
+func (r *Report) Unwrap() []error {
+	errs := make([]error, len(r.issues))
+	for i, it := range r.issues {
+		errs[i] = it
+	}
+	return errs
+}
+
 func (r *Report) Add(it Issue) { r.issues = append(r.issues, it) }
 func (r *Report) OrNil() error {
 	if len(r.issues) == 0 {
@@ -91,6 +160,15 @@ func (r *Report) OrNil() error {
 	}
 	return r
 }
+
+// ErrorsOrNil returns r if it has any errors and nil if it only has
+// warnings, for callers that should not be stopped by warnings.
+func (r *Report) ErrorsOrNil() error {
+	if !r.HasErrors() {
+		return nil
+	}
+	return r
+}
 func (r *Report) HasErrors() bool {
 	for _, it := range r.issues {
 		if it.Severity == SeverityError {
@@ -284,7 +362,15 @@ func (e timeoutErr) Error() string {
 }
 
 // ValidateModel checks the model shape and safely runs Init/View with timeout & recovery.
-func ValidateModel(m any) error {
+func ValidateModel(m any) error { return validateModel(m, true) }
+
+// ValidateShape runs the fast subset of ValidateModel: it inspects method
+// signatures and fields by reflection but never calls Init, View or Update.
+func ValidateShape(m any) error { return validateModel(m, false) }
+
+// validateModel runs the checks; call reports whether model methods may be
+// invoked.
+func validateModel(m any, call bool) error {
 	rep := &Report{}
 
 	// 1) nil
@@ -336,7 +422,7 @@ func ValidateModel(m any) error {
 				Detail:     fmt.Sprintf("expected: func() or func() <one-value>, got: %s", prettyMethodType("Init", vInit.Type)),
 				Suggestion: "Prefer: func() frog.Cmd or func() (frog.Cmd).",
 			})
-		} else {
+		} else if call {
 			elapsed, err := safeCallInit(mv, vInit.Func, mt)
 			switch e := err.(type) {
 			case nil:
@@ -393,7 +479,7 @@ func ValidateModel(m any) error {
 				Detail:     fmt.Sprintf("got: %s", prettyMethodType("View", vView.Type)),
 				Suggestion: "Make sure View has no parameters and returns a string.",
 			})
-		} else {
+		} else if call {
 			viewRes, elapsed, viewErr := safeCallView(mv, vView.Func, mt)
 			switch e := viewErr.(type) {
 			case nil:
@@ -421,6 +507,15 @@ func ValidateModel(m any) error {
 						Suggestion: "Ensure the returned string is valid UTF-8.",
 					})
 				}
+				for _, problem := range unsafeOutput(out) {
+					rep.Add(Issue{
+						Code:       CodeViewUnsafe,
+						Severity:   SeverityWarning,
+						Summary:    "View() output is not terminal-safe",
+						Detail:     problem,
+						Suggestion: "Let the renderer position the cursor and clear the screen; use Style for colors and attributes.",
+					})
+				}
 				if len(out) > 2_000_000 {
 					rep.Add(Issue{
 						Code:       CodeViewVeryLarge,
@@ -487,12 +582,118 @@ func ValidateModel(m any) error {
 				Summary:    "Update is not a valid method (maybe a field with the same name?)",
 				Suggestion: "Define Update as a method on your model type.",
 			})
+		} else if inN == 2 && outN == 2 {
+			checkUpdateIdentity(rep, mt)
 		}
 	}
 
 	return rep.OrNil()
 }
 
+// unsafeOutput describes problems in view that would fight the renderer:
+// control sequences other than SGR, NUL bytes and styles left open at the
+// end of the view.
+func unsafeOutput(view string) []string {
+	var problems []string
+	if i := strings.IndexByte(view, 0); i >= 0 {
+		problems = append(problems, fmt.Sprintf("NUL byte at offset %d", i))
+	}
+	var control string
+	var style sgr.State // in effect after the sequences so far
+	for i := 0; i < len(view); i++ {
+		if view[i] != 0x1b {
+			continue
+		}
+		if i+1 >= len(view) || view[i+1] != '[' {
+			if i+1 < len(view) && view[i+1] == ']' {
+				continue // OSC, e.g. hyperlinks
+			}
+			if control == "" && i+1 < len(view) && view[i+1] != '\\' {
+				control = fmt.Sprintf("%q at offset %d", view[i:i+2], i)
+			}
+			continue
+		}
+		j := i + 2
+		for j < len(view) && (view[j] < 0x40 || view[j] > 0x7e) {
+			j++
+		}
+		if j >= len(view) {
+			break
+		}
+		seq := view[i : j+1]
+		if view[j] != 'm' {
+			if control == "" {
+				control = fmt.Sprintf("%q at offset %d", seq, i)
+			}
+		} else {
+			style.Apply(seq[2 : len(seq)-1])
+		}
+		i = j
+	}
+	if control != "" {
+		problems = append(problems, "contains cursor/screen control sequence "+control)
+	}
+	if !style.IsDefault() {
+		problems = append(problems, "ends with an SGR style still active (missing reset)")
+	}
+	return problems
+}
+
+// checkUpdateIdentity catches receiver mix-ups: value-receiver models that
+// copy locks on every Update, and pointer models whose Update has a value
+// receiver, so it works on a copy and returning m changes the model's type.
+// It only inspects types; Update is never called, since a probe message
+// could change a pointer model and a blocked Update could not be abandoned.
+// A pointer-receiver Update that returns a different instance is therefore
+// out of its reach: only running Update would show it.
+func checkUpdateIdentity(rep *Report, mt reflect.Type) {
+	if mt.Kind() != reflect.Ptr {
+		if field, what := findCopiedSyncField(mt, ""); field != "" {
+			rep.Add(Issue{
+				Code:       CodeUpdateIdentity,
+				Severity:   SeverityWarning,
+				Summary:    fmt.Sprintf("model is passed by value but field %s is a %s", field, what),
+				Detail:     "every Update works on a copy of the model, so the copy's " + what + " is not the one other code uses",
+				Suggestion: fmt.Sprintf("Use a pointer model (Run(&%s{})) with pointer receivers, or hold the %s by pointer.", receiverName(mt), what),
+			})
+		}
+		return
+	}
+	if _, ok := mt.Elem().MethodByName("Update"); ok {
+		rep.Add(Issue{
+			Code:       CodeUpdateIdentity,
+			Severity:   SeverityWarning,
+			Summary:    "pointer model has a value-receiver Update",
+			Detail:     fmt.Sprintf("Update works on a copy of the model, and returning m hands the session a %s instead of a %s", typeName(mt.Elem()), typeName(mt)),
+			Suggestion: fmt.Sprintf("Declare Update on *%s, mutate m and return m itself.", receiverName(mt.Elem())),
+		})
+	}
+}
+
+// findCopiedSyncField returns the first field (searching embedded and
+// nested structs) whose sync type must not be copied. Channels are fine to
+// copy and are not reported.
+func findCopiedSyncField(t reflect.Type, prefix string) (field, what string) {
+	if t.Kind() != reflect.Struct {
+		return "", ""
+	}
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		name := prefix + f.Name
+		ft := f.Type
+		if ft.PkgPath() == "sync" {
+			switch ft.Name() {
+			case "Mutex", "RWMutex", "WaitGroup", "Once", "Cond":
+				return name, "sync." + ft.Name()
+			}
+		}
+		if field, what := findCopiedSyncField(ft, name+"."); field != "" {
+			return field, what
+		}
+	}
+	return "", ""
+}
+
 // ----------------------------------------------------
 // helpers (reflection / formatting)
 // ----------------------------------------------------
@@ -0,0 +1,85 @@
+package validate
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestUnsafeOutput(t *testing.T) {
+	tests := []struct {
+		name, view, want string // want is a substring of the only problem, or "" for none
+	}{
+		{"plain", "hello", ""},
+		{"reset", "\x1b[1mhi\x1b[0m", ""},
+		{"short reset", "\x1b[31mhi\x1b[m", ""},
+		{"combined reset", "\x1b[1mhi\x1b[1;0m", ""},
+		{"256 black left open", "\x1b[38;5;0mhi", "SGR style still active"},
+		{"256 background black left open", "\x1b[48;5;0mhi", "SGR style still active"},
+		{"left open", "\x1b[1mhi", "SGR style still active"},
+		{"cursor movement", "\x1b[2Jhi", "control sequence"},
+		{"NUL", "a\x00b", "NUL byte"},
+		{"hyperlink", "\x1b]8;;https://example.com\x1b\\x\x1b]8;;\x1b\\", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := unsafeOutput(tt.view)
+			switch {
+			case tt.want == "" && len(got) != 0:
+				t.Errorf("unsafeOutput(%q) = %q, want none", tt.view, got)
+			case tt.want != "" && (len(got) != 1 || !strings.Contains(got[0], tt.want)):
+				t.Errorf("unsafeOutput(%q) = %q, want one containing %q", tt.view, got, tt.want)
+			}
+		})
+	}
+}
+
+// ValueUpdate is a pointer model whose Update has a value receiver.
+type ValueUpdate struct{ n int }
+
+func (m ValueUpdate) Init() any                 { return nil }
+func (m ValueUpdate) Update(msg any) (any, any) { return m, nil }
+func (m *ValueUpdate) View() string             { return "ok" }
+
+// NoView lacks View.
+type NoView struct{}
+
+func (NoView) Init() any                 { return nil }
+func (NoView) Update(msg any) (any, any) { return NoView{}, nil }
+
+func TestErrorsOrNil(t *testing.T) {
+	err := ValidateModel(&ValueUpdate{})
+	var rep *Report
+	if !errors.As(err, &rep) || !errors.Is(err, CodeUpdateIdentity) {
+		t.Fatalf("ValidateModel = %v, want a %s warning", err, CodeUpdateIdentity)
+	}
+	if err := rep.ErrorsOrNil(); err != nil {
+		t.Errorf("warnings only: ErrorsOrNil = %v", err)
+	}
+	err = ValidateModel(NoView{})
+	if !errors.As(err, &rep) {
+		t.Fatalf("ValidateModel = %v", err)
+	}
+	if err := rep.ErrorsOrNil(); !errors.Is(err, CodeMissingView) {
+		t.Errorf("ErrorsOrNil = %v, want %s", err, CodeMissingView)
+	}
+}
+
+// OpenStyle ends its view with a style still active.
+type OpenStyle struct{}
+
+func (OpenStyle) Init() any                 { return nil }
+func (OpenStyle) Update(msg any) (any, any) { return OpenStyle{}, nil }
+func (OpenStyle) View() string              { return "\x1b[1mbold" }
+
+// An unsafe view is a warning, which must not keep Run from starting.
+func TestUnsafeViewIsOnlyAWarning(t *testing.T) {
+	err := ValidateModel(OpenStyle{})
+	var rep *Report
+	if !errors.As(err, &rep) || !errors.Is(err, CodeViewUnsafe) {
+		t.Fatalf("ValidateModel = %v, want a %s warning", err, CodeViewUnsafe)
+	}
+	if err := rep.ErrorsOrNil(); err != nil {
+		t.Errorf("ErrorsOrNil = %v", err)
+	}
+}
@@ -0,0 +1,64 @@
+package core
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/pondworks-lib/frog/core/validate"
+)
+
+// ValidationLevel controls the pre-flight model check a Session runs at
+// startup. The check is the fast, reflection-only subset of
+// validate.ValidateModel: it never calls Init, View or Update.
+type ValidationLevel int
+
+const (
+	ValidationOff    ValidationLevel = iota // no check (default)
+	ValidationWarn                          // log issues, always start
+	ValidationStrict                        // log warnings, fail Run on errors
+)
+
+// ValidateEnv overrides the validation level: "off", "warn" or "strict".
+// The dev harness (frog dev) sets it to "warn" unless already set.
+const ValidateEnv = "FROG_VALIDATE"
+
+// WithValidation enables the pre-flight model check at the given level.
+func WithValidation(level ValidationLevel) Option {
+	return func(p *Session) { p.validation = level }
+}
+
+func validationFromEnv(v string, def ValidationLevel) ValidationLevel {
+	switch strings.ToLower(strings.TrimSpace(v)) {
+	case "off", "0", "false":
+		return ValidationOff
+	case "warn", "1", "true":
+		return ValidationWarn
+	case "strict":
+		return ValidationStrict
+	}
+	return def
+}
+
+// preflight validates the model and returns an error only in strict mode
+// when the model has errors.
+func (p *Session) preflight() error {
+	if p.validation == ValidationOff {
+		return nil
+	}
+	err := validate.ValidateShape(p.m)
+	var rep *validate.Report
+	if !errors.As(err, &rep) {
+		return nil
+	}
+	if p.validation == ValidationStrict && rep.HasErrors() {
+		return err
+	}
+	for _, it := range rep.Issues() {
+		if it.Severity == validate.SeverityError {
+			p.logger.Errorf("%s", it)
+		} else {
+			p.logger.Warnf("%s", it)
+		}
+	}
+	return nil
+}
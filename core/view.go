@@ -0,0 +1,94 @@
+package core
+
+import "sync"
+
+// ViewBuilder assembles a View string without repeated concatenation. Its
+// buffer is pooled: obtain one with NewViewBuilder and Release it once the
+// string has been produced.
+//
+//	b := frog.NewViewBuilder()
+//	defer b.Release()
+//	b.Line("title")
+//	b.Line(style.Render("status"), " ok")
+//	return b.String()
+type ViewBuilder struct {
+	buf   []byte
+	lines int
+}
+
+var viewBuilderPool = sync.Pool{
+	New: func() any { return &ViewBuilder{buf: make([]byte, 0, 4096)} },
+}
+
+// maxPooledView caps the buffer size kept in the pool (1 MiB).
+const maxPooledView = 1 << 20
+
+// NewViewBuilder returns an empty builder from the pool.
+func NewViewBuilder() *ViewBuilder {
+	b := viewBuilderPool.Get().(*ViewBuilder)
+	b.Reset()
+	return b
+}
+
+// Release returns the builder to the pool. It must not be used afterwards.
+func (b *ViewBuilder) Release() {
+	if cap(b.buf) > maxPooledView {
+		return
+	}
+	viewBuilderPool.Put(b)
+}
+
+// Reset empties the builder, keeping its buffer.
+func (b *ViewBuilder) Reset() {
+	b.buf = b.buf[:0]
+	b.lines = 0
+}
+
+// Write implements io.Writer.
+func (b *ViewBuilder) Write(p []byte) (int, error) {
+	b.buf = append(b.buf, p...)
+	return len(p), nil
+}
+
+// WriteString appends s to the current line.
+func (b *ViewBuilder) WriteString(s string) (int, error) {
+	b.buf = append(b.buf, s...)
+	return len(s), nil
+}
+
+// WriteStyled appends text rendered with st to the current line.
+func (b *ViewBuilder) WriteStyled(st Style, text string) {
+	p := st.Prefix()
+	if p == "" {
+		b.buf = append(b.buf, text...)
+		return
+	}
+	b.buf = append(b.buf, p...)
+	b.buf = append(b.buf, text...)
+	b.buf = append(b.buf, sgrReset...)
+}
+
+// Pad appends n spaces.
+func (b *ViewBuilder) Pad(n int) {
+	for ; n > 0; n-- {
+		b.buf = append(b.buf, ' ')
+	}
+}
+
+// Line starts a new line made of parts. The first call does not emit a
+// leading newline, so the result never ends with an empty trailing line.
+func (b *ViewBuilder) Line(parts ...string) {
+	if b.lines > 0 || len(b.buf) > 0 {
+		b.buf = append(b.buf, '\n')
+	}
+	for _, p := range parts {
+		b.buf = append(b.buf, p...)
+	}
+	b.lines++
+}
+
+// Len returns the number of bytes written so far.
+func (b *ViewBuilder) Len() int { return len(b.buf) }
+
+// String returns a copy of the accumulated view.
+func (b *ViewBuilder) String() string { return string(b.buf) }
@@ -0,0 +1,107 @@
+package core
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sync"
+)
+
+// Envelope is the wire form of a message: a registered type name and the
+// message encoded as JSON.
+type Envelope struct {
+	Type string          `json:"type"`
+	Data json.RawMessage `json:"data,omitempty"`
+}
+
+var wireTypes = struct {
+	sync.RWMutex
+	byName map[string]reflect.Type
+	byType map[reflect.Type]string
+}{
+	byName: map[string]reflect.Type{},
+	byType: map[reflect.Type]string{},
+}
+
+func init() {
+	RegisterMsg("key", KeyMsg{})
+	RegisterMsg("mouse", MouseMsg{})
+	RegisterMsg("paste", PasteMsg{})
+	RegisterMsg("resize", ResizeMsg{})
+	RegisterMsg("tick", TickMsg{})
+	RegisterMsg("timer", TimerMsg{})
+	RegisterMsg("quit", QuitMsg{})
+	RegisterMsg("interrupt", InterruptMsg{})
+}
+
+// RegisterMsg makes messages of sample's type encodable under name, so
+// they can be sent over the control endpoint. Register custom messages
+// once, e.g. from init. It panics if name or the type is already taken.
+func RegisterMsg(name string, sample Msg) {
+	t := reflect.TypeOf(sample)
+	if t == nil {
+		panic("frog: RegisterMsg with nil message")
+	}
+	wireTypes.Lock()
+	defer wireTypes.Unlock()
+	if _, dup := wireTypes.byName[name]; dup {
+		panic(fmt.Sprintf("frog: message name %q registered twice", name))
+	}
+	if _, dup := wireTypes.byType[t]; dup {
+		panic(fmt.Sprintf("frog: message type %v registered twice", t))
+	}
+	wireTypes.byName[name] = t
+	wireTypes.byType[t] = name
+}
+
+// EncodeMsg encodes msg as a JSON Envelope. The message type must be
+// registered with RegisterMsg.
+func EncodeMsg(msg Msg) ([]byte, error) {
+	wireTypes.RLock()
+	name, ok := wireTypes.byType[reflect.TypeOf(msg)]
+	wireTypes.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("frog: unregistered message type %T", msg)
+	}
+	data, err := json.Marshal(msg)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(Envelope{Type: name, Data: data})
+}
+
+// DecodeMsg decodes a JSON Envelope produced by EncodeMsg. A KeyMsg with
+// only String set is completed by parsing String as terminal input, so
+// {"type":"key","data":{"String":"\r"}} is an Enter press.
+func DecodeMsg(b []byte) (Msg, error) {
+	var env Envelope
+	if err := json.Unmarshal(b, &env); err != nil {
+		return nil, err
+	}
+	return env.Msg()
+}
+
+// Msg decodes the enveloped message.
+func (e Envelope) Msg() (Msg, error) {
+	wireTypes.RLock()
+	t, ok := wireTypes.byName[e.Type]
+	wireTypes.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("frog: unknown message type %q", e.Type)
+	}
+	v := reflect.New(t)
+	if len(e.Data) > 0 {
+		if err := json.Unmarshal(e.Data, v.Interface()); err != nil {
+			return nil, fmt.Errorf("frog: decode %s: %w", e.Type, err)
+		}
+	}
+	msg := v.Elem().Interface()
+	if k, ok := msg.(KeyMsg); ok && k.Type == KeyUnknown && k.String != "" {
+		if parsed, n := parseInput([]byte(k.String), true); n == len(k.String) {
+			if pk, ok := parsed.(KeyMsg); ok {
+				return pk, nil
+			}
+		}
+	}
+	return msg, nil
+}
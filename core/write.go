@@ -0,0 +1,85 @@
+package core
+
+import (
+	"io"
+	"time"
+)
+
+// Frame writes wait this long in total for an output that reports it would
+// block, such as a non-blocking pipe, before the frame is given up.
+const (
+	writeRetryLimit = time.Second
+	writeBackoffMax = 50 * time.Millisecond
+)
+
+// writeFull writes all of p to w. It continues after short writes and
+// retries, with backoff, writes refused because the output is full, so a
+// slow pipe or SSH channel gets whole frames. It returns how long it
+// waited.
+func writeFull(w io.Writer, p []byte) (waited time.Duration, err error) {
+	backoff := time.Millisecond
+	for len(p) > 0 {
+		n, err := w.Write(p)
+		p = p[n:]
+		switch {
+		case err != nil && !wouldBlock(err):
+			return waited, err
+		case err == nil && n > 0:
+			backoff = time.Millisecond
+			continue
+		case waited >= writeRetryLimit:
+			if err == nil {
+				err = io.ErrShortWrite
+			}
+			return waited, err
+		}
+		// Refused, or nothing written without an error: wait for room.
+		time.Sleep(backoff)
+		waited += backoff
+		backoff = min(backoff*2, writeBackoffMax)
+	}
+	return waited, nil
+}
+
+// WithFrameDrop skips frames while the output is saturated instead of
+// writing each in turn: after a frame had to wait for the output, frames
+// rendered within as long again are dropped. A session draws the latest
+// state once that time has passed, so the screen skips intermediate states
+// but never falls behind the model; other users of the renderer catch up
+// with their next Render. Off by default.
+func WithFrameDrop(enabled bool) RendererOption {
+	return func(r *ansiRenderer) { r.frameDrop = enabled }
+}
+
+// dropFrameLocked reports whether the next frame is dropped because the
+// output is saturated.
+func (r *ansiRenderer) dropFrameLocked() bool {
+	if !r.frameDrop || !time.Now().Before(r.saturatedUntil) {
+		return false
+	}
+	r.dropped = true
+	return true
+}
+
+// catchUp reports whether frames have been dropped, and how long until the
+// output is expected to take the next one.
+func (r *ansiRenderer) catchUp() (time.Duration, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return max(time.Until(r.saturatedUntil), 0), r.dropped
+}
+
+// catchUpMsg asks the loop to redraw after frames were dropped.
+type catchUpMsg struct{}
+
+// scheduleCatchUp arranges a redraw for when the output can take frames
+// again, if the renderer dropped the last one.
+func (p *Session) scheduleCatchUp(r *ansiRenderer) {
+	if p.catchUpArmed {
+		return
+	}
+	if d, dropped := r.catchUp(); dropped {
+		p.catchUpArmed = true
+		p.deliverAfter(d, nil, func() Msg { return catchUpMsg{} })
+	}
+}
@@ -0,0 +1,170 @@
+// Package dev is a hot-reload harness for developing frog programs. Run
+// watches a package's sources, rebuilds on change and restarts the program,
+// carrying model state across restarts through the session's persistence
+// (models implementing frog.Saver and frog.Loader keep their state).
+//
+//	err := dev.Run(ctx, dev.Config{Dir: "./cmd/myapp"})
+package dev
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/pondworks-lib/frog/core"
+)
+
+// Config configures Run.
+type Config struct {
+	Dir       string        // package to build and watch (default ".")
+	Watch     []string      // extra directories to watch (e.g. the module root)
+	Args      []string      // arguments passed to the program
+	Interval  time.Duration // source polling interval (default 500ms)
+	StateFile string        // state carried across restarts (default: temp file)
+	Log       io.Writer     // build output and harness messages (default os.Stderr)
+}
+
+// Run builds and runs the program until ctx is done, restarting it whenever
+// a watched .go file changes. A failed build keeps the previous program running.
+func Run(ctx context.Context, cfg Config) error {
+	if cfg.Dir == "" {
+		cfg.Dir = "."
+	}
+	if cfg.Interval <= 0 {
+		cfg.Interval = 500 * time.Millisecond
+	}
+	if cfg.Log == nil {
+		cfg.Log = os.Stderr
+	}
+
+	work, err := os.MkdirTemp("", "frogdev-")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(work)
+	if cfg.StateFile == "" {
+		cfg.StateFile = filepath.Join(work, "state")
+	}
+	bin := filepath.Join(work, "app")
+
+	dirs := append([]string{cfg.Dir}, cfg.Watch...)
+	stamp := sourceStamp(dirs)
+
+	var child *exec.Cmd
+	var exited chan error
+	start := func() {
+		if err := build(ctx, cfg, bin); err != nil {
+			fmt.Fprintf(cfg.Log, "frog dev: build failed: %v\n", err)
+			return
+		}
+		if child != nil {
+			stop(child, exited)
+		}
+		child, exited = launch(cfg, bin)
+	}
+	start()
+
+	ticker := time.NewTicker(cfg.Interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			if child != nil {
+				stop(child, exited)
+			}
+			return nil
+		case err := <-exited:
+			// The program quit on its own: stop the harness too.
+			child = nil
+			var ee *exec.ExitError
+			if err != nil && !errors.As(err, &ee) {
+				return err
+			}
+			return nil
+		case <-ticker.C:
+			if s := sourceStamp(dirs); s != stamp {
+				stamp = s
+				start()
+			}
+		}
+	}
+}
+
+func build(ctx context.Context, cfg Config, bin string) error {
+	cmd := exec.CommandContext(ctx, "go", "build", "-o", bin, ".")
+	cmd.Dir = cfg.Dir
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("%w\n%s", err, out)
+	}
+	return nil
+}
+
+func launch(cfg Config, bin string) (*exec.Cmd, chan error) {
+	cmd := exec.Command(bin, cfg.Args...)
+	cmd.Stdin, cmd.Stdout, cmd.Stderr = os.Stdin, os.Stdout, os.Stderr
+	cmd.Env = append(os.Environ(), core.StateFileEnv+"="+cfg.StateFile)
+	if os.Getenv(core.ValidateEnv) == "" {
+		cmd.Env = append(cmd.Env, core.ValidateEnv+"=warn")
+	}
+	exited := make(chan error, 1)
+	if err := cmd.Start(); err != nil {
+		exited <- err
+		return cmd, exited
+	}
+	go func() { exited <- cmd.Wait() }()
+	return cmd, exited
+}
+
+// stop asks the program to quit (so it saves its state) and kills it if it
+// doesn't exit within two seconds.
+func stop(cmd *exec.Cmd, exited chan error) {
+	if cmd.Process == nil {
+		return
+	}
+	_ = cmd.Process.Signal(stopSignal)
+	select {
+	case <-exited:
+	case <-time.After(2 * time.Second):
+		_ = cmd.Process.Kill()
+		<-exited
+	}
+}
+
+// sourceStamp summarizes the .go files under dirs (count and newest mtime).
+func sourceStamp(dirs []string) string {
+	var n int
+	var newest time.Time
+	for _, d := range dirs {
+		_ = filepath.WalkDir(d, func(path string, e fs.DirEntry, err error) error {
+			if err != nil {
+				return nil
+			}
+			if e.IsDir() {
+				name := e.Name()
+				if path != d && (strings.HasPrefix(name, ".") || name == "vendor" || name == "testdata") {
+					return filepath.SkipDir
+				}
+				return nil
+			}
+			if !strings.HasSuffix(path, ".go") {
+				return nil
+			}
+			if info, err := e.Info(); err == nil {
+				n++
+				if info.ModTime().After(newest) {
+					newest = info.ModTime()
+				}
+			}
+			return nil
+		})
+	}
+	return fmt.Sprintf("%d@%d", n, newest.UnixNano())
+}
@@ -0,0 +1,7 @@
+//go:build windows || plan9
+
+package dev
+
+import "os"
+
+var stopSignal = os.Interrupt
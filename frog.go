@@ -4,6 +4,7 @@ package frog
 
 import (
 	"context"
+	"errors"
 	"io"
 
 	"github.com/pondworks-lib/frog/core"
@@ -15,14 +16,32 @@ type (
 	Option = core.Option
 
 	// MUV types
-	Model     = core.Model
-	Msg       = core.Msg
-	KeyMsg    = core.KeyMsg
-	KeyType   = core.KeyType
-	TickMsg   = core.TickMsg
-	QuitMsg   = core.QuitMsg
-	Cmd       = core.Cmd
-	ResizeMsg = core.ResizeMsg
+	Model        = core.Model
+	Starter      = core.Starter
+	Resizer      = core.Resizer
+	Quitter      = core.Quitter
+	Interrupter  = core.Interrupter
+	Msg          = core.Msg
+	KeyMsg       = core.KeyMsg
+	KeyType      = core.KeyType
+	TickMsg      = core.TickMsg
+	TimerMsg     = core.TimerMsg
+	IdleMsg      = core.IdleMsg
+	ActiveMsg    = core.ActiveMsg
+	EventMsg     = core.EventMsg
+	RequestID    = core.RequestID
+	RequestMsg   = core.RequestMsg
+	ErrMsg       = core.ErrMsg
+	QuitMsg      = core.QuitMsg
+	InterruptMsg = core.InterruptMsg
+	PanicError   = core.PanicError
+	Cmd          = core.Cmd
+	Case         = core.Case
+	ResizeMsg    = core.ResizeMsg
+
+	// Session mode
+	Mode            = core.Mode
+	DetectedModeMsg = core.DetectedModeMsg
 
 	// Mouse & Paste
 	MouseMsg    = core.MouseMsg
@@ -30,8 +49,20 @@ type (
 	MouseAction = core.MouseAction
 	PasteMsg    = core.PasteMsg
 
+	PasteStartMsg = core.PasteStartMsg
+	PasteChunkMsg = core.PasteChunkMsg
+	PasteEndMsg   = core.PasteEndMsg
+
+	// Input method composition
+	CompositionMsg = core.CompositionMsg
+
+	// Terminal replies
+	ForegroundColorMsg = core.ForegroundColorMsg
+	BackgroundColorMsg = core.BackgroundColorMsg
+
 	// Styling
 	Style        = core.Style
+	ViewBuilder  = core.ViewBuilder
 	Color        = core.Color
 	ColorProfile = core.ColorProfile
 
@@ -39,33 +70,120 @@ type (
 	RendererOption = core.RendererOption
 
 	// Layout
-	AlignH = core.AlignH
-	AlignV = core.AlignV
+	AlignH      = core.AlignH
+	AlignV      = core.AlignV
+	Overflow    = core.Overflow
+	PlaceOption = core.PlaceOption
 
 	// Logger
 	Logger = core.Logger
+
+	// Time
+	Clock     = core.Clock
+	Ticker    = core.Ticker
+	FakeClock = core.FakeClock
+
+	// Cursor
+	CursorShape = core.CursorShape
+
+	// Key help
+	Binding   = core.Binding
+	KeyHelper = core.KeyHelper
+
+	// Configuration
+	Config = core.Config
+
+	// Dirty regions
+	Rect        = core.Rect
+	DirtyViewer = core.DirtyViewer
+
+	// Validation
+	ValidationLevel = core.ValidationLevel
+
+	// Terminal capabilities
+	Caps        = core.Caps
+	Multiplexer = core.Multiplexer
+	Terminal    = core.Terminal
+
+	// Metrics
+	Metrics     = core.Metrics
+	RenderStats = core.RenderStats
+
+	// Persistence
+	Saver            = core.Saver
+	Loader           = core.Loader
+	StateRestoredMsg = core.StateRestoredMsg
+
+	// History
+	History       = core.History
+	HistoryOption = core.HistoryOption
+	Snapshotter   = core.Snapshotter
+	UndoMsg       = core.UndoMsg
+	RedoMsg       = core.RedoMsg
+
+	// Time travel
+	TimeTravel       = core.TimeTravel
+	TimeTravelOption = core.TimeTravelOption
+
+	// Dependencies
+	Deps    = core.Deps
+	DepsMsg = core.DepsMsg
+
+	// Terminal key quirks
+	KeyTranslations = core.KeyTranslations
+	KeyProfile      = core.KeyProfile
 )
 
 // Key constants
 const (
-	KeyUnknown   = core.KeyUnknown
-	KeyRune      = core.KeyRune
-	KeyEnter     = core.KeyEnter
-	KeyBackspace = core.KeyBackspace
-	KeyEsc       = core.KeyEsc
-	KeyCtrlC     = core.KeyCtrlC
-	KeyUp        = core.KeyUp
-	KeyDown      = core.KeyDown
-	KeyLeft      = core.KeyLeft
-	KeyRight     = core.KeyRight
-	KeyTab       = core.KeyTab
-	KeySpace     = core.KeySpace
-	KeyDelete    = core.KeyDelete
-	KeyHome      = core.KeyHome
-	KeyEnd       = core.KeyEnd
-	KeyPgUp      = core.KeyPgUp
-	KeyPgDn      = core.KeyPgDn
-	KeyQ         = core.KeyQ
+	KeyUnknown    = core.KeyUnknown
+	KeyRune       = core.KeyRune
+	KeyEnter      = core.KeyEnter
+	KeyBackspace  = core.KeyBackspace
+	KeyEsc        = core.KeyEsc
+	KeyCtrlC      = core.KeyCtrlC
+	KeyUp         = core.KeyUp
+	KeyDown       = core.KeyDown
+	KeyLeft       = core.KeyLeft
+	KeyRight      = core.KeyRight
+	KeyTab        = core.KeyTab
+	KeySpace      = core.KeySpace
+	KeyDelete     = core.KeyDelete
+	KeyHome       = core.KeyHome
+	KeyEnd        = core.KeyEnd
+	KeyPgUp       = core.KeyPgUp
+	KeyPgDn       = core.KeyPgDn
+	KeyQ          = core.KeyQ
+	KeyF1         = core.KeyF1
+	KeyF2         = core.KeyF2
+	KeyF3         = core.KeyF3
+	KeyF4         = core.KeyF4
+	KeyF5         = core.KeyF5
+	KeyF6         = core.KeyF6
+	KeyF7         = core.KeyF7
+	KeyF8         = core.KeyF8
+	KeyF9         = core.KeyF9
+	KeyF10        = core.KeyF10
+	KeyF11        = core.KeyF11
+	KeyF12        = core.KeyF12
+	KeyKP0        = core.KeyKP0
+	KeyKP1        = core.KeyKP1
+	KeyKP2        = core.KeyKP2
+	KeyKP3        = core.KeyKP3
+	KeyKP4        = core.KeyKP4
+	KeyKP5        = core.KeyKP5
+	KeyKP6        = core.KeyKP6
+	KeyKP7        = core.KeyKP7
+	KeyKP8        = core.KeyKP8
+	KeyKP9        = core.KeyKP9
+	KeyKPEnter    = core.KeyKPEnter
+	KeyKPPlus     = core.KeyKPPlus
+	KeyKPMinus    = core.KeyKPMinus
+	KeyKPMultiply = core.KeyKPMultiply
+	KeyKPDivide   = core.KeyKPDivide
+	KeyKPDecimal  = core.KeyKPDecimal
+	KeyKPEqual    = core.KeyKPEqual
+	KeyKPComma    = core.KeyKPComma
 )
 
 // Mouse constants
@@ -85,6 +203,35 @@ const (
 	MouseWheel = core.MouseWheel
 )
 
+// MinReadableContrast is the WCAG AA contrast ratio used by Style.EnsureReadable.
+const MinReadableContrast = core.MinReadableContrast
+
+// Pre-flight validation levels
+const (
+	ValidationOff    = core.ValidationOff
+	ValidationWarn   = core.ValidationWarn
+	ValidationStrict = core.ValidationStrict
+)
+
+// Session modes, reported by DetectedModeMsg
+const (
+	ModeInteractive    = core.ModeInteractive
+	ModePipedInput     = core.ModePipedInput
+	ModePipedOutput    = core.ModePipedOutput
+	ModeNonInteractive = core.ModeNonInteractive
+)
+
+// Cursor shapes
+const (
+	CursorDefault           = core.CursorDefault
+	CursorBlinkingBlock     = core.CursorBlinkingBlock
+	CursorSteadyBlock       = core.CursorSteadyBlock
+	CursorBlinkingUnderline = core.CursorBlinkingUnderline
+	CursorSteadyUnderline   = core.CursorSteadyUnderline
+	CursorBlinkingBar       = core.CursorBlinkingBar
+	CursorSteadyBar         = core.CursorSteadyBar
+)
+
 // Color profile constants
 const (
 	ColorAuto      = core.ColorAuto
@@ -120,24 +267,41 @@ var (
 	ANSI256   = core.ANSI256
 	RGB       = core.RGB
 	Colorize  = core.Colorize
+	Styled    = core.Styled
 	StripANSI = core.StripANSI
+
+	ContrastRatio = core.ContrastRatio
+
+	// View building
+	NewViewBuilder = core.NewViewBuilder
 )
 
 // App helpers
 func NewApp(m Model, opts ...Option) *App { return core.NewSession(m, opts...) }
 func Run(m Model, opts ...Option) error {
-	if err := validate.ValidateModel(m); err != nil {
+	if err := checkModel(m); err != nil {
 		return err
 	}
 	return core.NewSession(m, opts...).Run()
 }
 
+// checkModel validates m before Run. Only errors stop the program; a report
+// of warnings alone is for frog validate and WithValidation to show.
+func checkModel(m Model) error {
+	err := validate.ValidateModel(m)
+	var rep *validate.Report
+	if errors.As(err, &rep) {
+		return rep.ErrorsOrNil()
+	}
+	return err
+}
+
 // Context-aware entrypoints
 func NewAppWithContext(ctx context.Context, m Model, opts ...Option) *App {
 	return core.NewSessionWithContext(ctx, m, opts...)
 }
 func RunContext(ctx context.Context, m Model, opts ...Option) error {
-		if err := validate.ValidateModel(m); err != nil {
+	if err := checkModel(m); err != nil {
 		return err
 	}
 	return core.NewSessionWithContext(ctx, m, opts...).Run()
@@ -145,19 +309,192 @@ func RunContext(ctx context.Context, m Model, opts ...Option) error {
 
 // Session options
 var (
-	Tick               = core.Tick
-	Quit               = core.Quit
-	Nil                = core.Nil
-	WithRenderer       = core.WithRenderer
-	WithAltScreen      = core.WithAltScreen
-	WithMsgBuffer      = core.WithMsgBuffer
-	WithOut            = core.WithOut
-	WithIn             = core.WithIn
-	WithResizeInterval = core.WithResizeInterval
-	WithNonInteractive = core.WithNonInteractive
-	WithLogger         = core.WithLogger
-	WithMouse          = core.WithMouse
-	WithBracketedPaste = core.WithBracketedPaste
+	Tick                 = core.Tick
+	RunCmd               = core.RunCmd
+	Batch                = core.Batch
+	BatchAll             = core.BatchAll
+	Sequence             = core.Sequence
+	StartTimer           = core.StartTimer
+	StopTimer            = core.StopTimer
+	ResetTimer           = core.ResetTimer
+	AutoSave             = core.AutoSave
+	Quit                 = core.Quit
+	Nil                  = core.Nil
+	WithRenderer         = core.WithRenderer
+	WithAltScreen        = core.WithAltScreen
+	WithMsgBuffer        = core.WithMsgBuffer
+	WithOut              = core.WithOut
+	WithIn               = core.WithIn
+	WithResizeInterval   = core.WithResizeInterval
+	WithResizeDebounce   = core.WithResizeDebounce
+	WithMinSize          = core.WithMinSize
+	WithFrameSkipping    = core.WithFrameSkipping
+	WithIdle             = core.WithIdle
+	WithFPS              = core.WithFPS
+	OptionsFromConfig    = core.OptionsFromConfig
+	WithEscapeTimeout    = core.WithEscapeTimeout
+	WithNonInteractive   = core.WithNonInteractive
+	WithForceColor       = core.WithForceColor
+	WithPprof            = core.WithPprof
+	WithKeyTranslations  = core.WithKeyTranslations
+	RegisterKeyProfile   = core.RegisterKeyProfile
+	WithoutSignalHandler = core.WithoutSignalHandler
+	WithoutCrashScreen   = core.WithoutCrashScreen
+	WithLogger           = core.WithLogger
+	WithClock            = core.WithClock
+	NewFakeClock         = core.NewFakeClock
+	WithMouse            = core.WithMouse
+	WithBracketedPaste   = core.WithBracketedPaste
+	WithPasteChunks      = core.WithPasteChunks
+	WithKeypad           = core.WithKeypad
+	WithDebugOverlay     = core.WithDebugOverlay
+	WithHelp             = core.WithHelp
+	WithMetrics          = core.WithMetrics
+	WithRenderHook       = core.WithRenderHook
+	WithValue            = core.WithValue
+	WithPersistence      = core.WithPersistence
+	StatePath            = core.StatePath
+	WithCapabilities     = core.WithCapabilities
+	WithTerminal         = core.WithTerminal
+	WithTerminalFd       = core.WithTerminalFd
+	WithCursorShape      = core.WithCursorShape
+	SetCursorShape       = core.SetCursorShape
+	WithValidation       = core.WithValidation
+	EnterAltScreen       = core.EnterAltScreen
+	ExitAltScreen        = core.ExitAltScreen
+	PauseRendering       = core.PauseRendering
+	ResumeRendering      = core.ResumeRendering
+	RawWrite             = core.RawWrite
+)
+
+// Terminal capability detection
+var (
+	Capabilities      = core.Capabilities
+	ProbeCapabilities = core.ProbeCapabilities
+	DetectMultiplexer = core.DetectMultiplexer
+	IsTerminal        = core.IsTerminal
+)
+
+const (
+	MuxNone   = core.MuxNone
+	MuxTmux   = core.MuxTmux
+	MuxScreen = core.MuxScreen
+)
+
+// Escape-sequence features (multiplexer-aware)
+var (
+	Passthrough  = core.Passthrough
+	Hyperlink    = core.Hyperlink
+	SetClipboard = core.SetClipboard
+
+	QueryForegroundColor = core.QueryForegroundColor
+	QueryBackgroundColor = core.QueryBackgroundColor
+	WithColorQuery       = core.WithColorQuery
+)
+
+// Provide registers v as the session's dependency of type T.
+func Provide[T any](v T) Option { return core.Provide(v) }
+
+// Lookup returns the dependency of type T registered with Provide.
+func Lookup[T any](d Deps) (T, bool) { return core.Lookup[T](d) }
+
+// History (undo/redo) and time travel
+var (
+	WithHistory   = core.WithHistory
+	HistoryLimit  = core.HistoryLimit
+	HistoryKeys   = core.HistoryKeys
+	HistoryFilter = core.HistoryFilter
+	Undo          = core.Undo
+	Redo          = core.Redo
+
+	WithTimeTravel     = core.WithTimeTravel
+	TimeTravelLimit    = core.TimeTravelLimit
+	TimeTravelDumpFile = core.TimeTravelDumpFile
+)
+
+// Typed message helpers
+var Match = core.Match
+
+// On calls fn when msg is a T and reports whether it did.
+func On[T Msg](msg Msg, fn func(T) (Model, Cmd)) (Model, Cmd, bool) { return core.On(msg, fn) }
+
+// Handle builds a Match case that runs fn for messages of type T.
+func Handle[T Msg](fn func(T) (Model, Cmd)) Case { return core.Handle(fn) }
+
+// Event bus: Publish delivers an event to every subscriber of its type as
+// an EventMsg.
+var Publish = core.Publish
+
+// Subscribe subscribes id to events of type T.
+func Subscribe[T any](id string) Cmd { return core.Subscribe[T](id) }
+
+// Unsubscribe cancels id's subscription to T.
+func Unsubscribe[T any](id string) Cmd { return core.Unsubscribe[T](id) }
+
+// EventFor returns msg's event when msg is an EventMsg for id carrying a T.
+func EventFor[T any](msg Msg, id string) (T, bool) { return core.EventFor[T](msg, id) }
+
+// Command result correlation: WithRequestID tags a command's result with a
+// RequestID, RequestIDFromMsg reads it back.
+var (
+	WithRequestID    = core.WithRequestID
+	RequestIDFromMsg = core.RequestIDFromMsg
+)
+
+// ParseSequence decodes one input event from a byte slice, for tooling and
+// replays.
+var ParseSequence = core.ParseSequence
+
+// ParseKey parses a key name such as "ctrl+x" or "alt+enter", the inverse
+// of KeyMsg.Canonical, for keybindings read from configuration.
+var ParseKey = core.ParseKey
+
+// Remote control: the wire encoding used by Session.ServeControl
+type (
+	Envelope     = core.Envelope
+	ControlReply = core.ControlReply
+)
+
+const ControlScreenshot = core.ControlScreenshot
+
+var (
+	RegisterMsg = core.RegisterMsg
+	EncodeMsg   = core.EncodeMsg
+	DecodeMsg   = core.DecodeMsg
+)
+
+// Inspector: model state, recent messages and stats over a unix socket
+type (
+	Debuggable     = core.Debuggable
+	InputHider     = core.InputHider
+	InspectReply   = core.InspectReply
+	InspectedMsg   = core.InspectedMsg
+	InspectedStats = core.InspectedStats
+)
+
+const (
+	InspectModel    = core.InspectModel
+	InspectMessages = core.InspectMessages
+	InspectStats    = core.InspectStats
+)
+
+var (
+	WithInspector      = core.WithInspector
+	WithoutInspector   = core.WithoutInspector
+	DefaultInspectPath = core.DefaultInspectPath
+)
+
+// Script output: line-delimited events instead of frames
+type (
+	ScriptFormat = core.ScriptFormat
+	ScriptEvent  = core.ScriptEvent
+)
+
+const ScriptJSON = core.ScriptJSON
+
+var (
+	WithScriptOutput = core.WithScriptOutput
+	Report           = core.Report
 )
 
 // Renderer power-user API
@@ -168,6 +505,14 @@ func NewRenderer(out io.Writer, opts ...RendererOption) core.Renderer {
 var (
 	WithDiff         = core.WithDiff
 	WithColorProfile = core.WithColorProfile
+	WithSyncOutput   = core.WithSyncOutput
+
+	WithClipping            = core.WithClipping
+	WithSoftWrap            = core.WithSoftWrap
+	WithBidi                = core.WithBidi
+	WithProgressive         = core.WithProgressive
+	WithTruncationIndicator = core.WithTruncationIndicator
+	WithFrameDrop           = core.WithFrameDrop
 )
 
 // Layout helpers
@@ -178,9 +523,23 @@ const (
 	AlignTop    = core.AlignTop
 	AlignMiddle = core.AlignMiddle
 	AlignBottom = core.AlignBottom
+
+	OverflowNone     = core.OverflowNone
+	OverflowClip     = core.OverflowClip
+	OverflowEllipsis = core.OverflowEllipsis
+	OverflowWrap     = core.OverflowWrap
 )
 
 var (
-	Center     = core.Center
-	PlaceBlock = core.PlaceBlock
+	Center          = core.Center
+	PlaceBlock      = core.PlaceBlock
+	WithOverflow    = core.WithOverflow
+	WithBoxFill     = core.WithBoxFill
+	WithExactHeight = core.WithExactHeight
+	WithFillStyle   = core.WithFillStyle
+	Fill            = core.Fill
+	Overlay         = core.Overlay
+	Truncate        = core.Truncate
+	DisplayWidth    = core.DisplayWidth
+	Reorder         = core.Reorder
 )
@@ -0,0 +1,237 @@
+// Package frogtest runs frog programs on real pseudo-terminals for
+// end-to-end tests: raw mode, resizes and escape sequences go through the
+// operating system's terminal layer just as they would in a terminal
+// emulator.
+//
+//	func TestQuit(t *testing.T) {
+//		term := frogtest.RunInPTY(t, newModel())
+//		term.WaitFor("ready")
+//		term.Type("q")
+//		if err := term.Wait(); err != nil {
+//			t.Fatal(err)
+//		}
+//	}
+//
+// Pseudo-terminals are available on Linux and macOS; elsewhere the tests
+// are skipped.
+package frogtest
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"os"
+	"os/exec"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/pondworks-lib/frog"
+	"github.com/pondworks-lib/frog/internal/pty"
+)
+
+// Timeout bounds how long WaitFor and Wait wait.
+var Timeout = 5 * time.Second
+
+// Terminal is a program running on a pseudo-terminal.
+type Terminal struct {
+	t   testing.TB
+	ptm *os.File
+
+	mu      sync.Mutex
+	out     bytes.Buffer
+	changed chan struct{} // closed and replaced when output arrives
+
+	done chan struct{} // closed when the program exits
+	err  error
+
+	clock *frog.FakeClock // from RunInPTYWithClock
+}
+
+// RunInPTY runs m in this process on a new 80x24 pseudo-terminal. The
+// session stops when the test ends, if m has not quit by then. Signal
+// handling is left to the test process.
+func RunInPTY(t testing.TB, m frog.Model, opts ...frog.Option) *Terminal {
+	t.Helper()
+	return runInPTY(t, m, nil, opts)
+}
+
+// RunInPTYWithClock is RunInPTY with the session on clock, so Tick, timers
+// and size polling only move when the test calls Advance:
+//
+//	clock := frog.NewFakeClock(time.Now())
+//	term := frogtest.RunInPTYWithClock(t, newModel(), clock)
+//	term.WaitFor("0s")
+//	term.Advance(time.Second)
+//	term.WaitFor("1s")
+func RunInPTYWithClock(t testing.TB, m frog.Model, clock *frog.FakeClock, opts ...frog.Option) *Terminal {
+	t.Helper()
+	return runInPTY(t, m, clock, append([]frog.Option{frog.WithClock(clock)}, opts...))
+}
+
+func runInPTY(t testing.TB, m frog.Model, clock *frog.FakeClock, opts []frog.Option) *Terminal {
+	t.Helper()
+	ptm, pts, err := pty.Open()
+	if errors.Is(err, errors.ErrUnsupported) {
+		t.Skip("frogtest: pseudo-terminals are not supported on this system")
+	}
+	if err != nil {
+		t.Fatalf("frogtest: open pty: %v", err)
+	}
+	if err := pty.SetSize(pts, 80, 24); err != nil {
+		t.Fatalf("frogtest: %v", err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	opts = append([]frog.Option{frog.WithIn(pts), frog.WithOut(pts), frog.WithoutSignalHandler()}, opts...)
+	app := frog.NewAppWithContext(ctx, m, opts...)
+
+	term := newTerminal(t, ptm)
+	term.clock = clock
+	go func() {
+		err := app.Run()
+		pts.Close()
+		term.exit(err)
+	}()
+	t.Cleanup(func() {
+		cancel()
+		term.waitExit()
+		ptm.Close()
+	})
+	return term
+}
+
+// StartInPTY starts cmd on a new 80x24 pseudo-terminal; pass
+// os.Args[0] to run the test binary itself, say with an environment
+// variable selecting what it should do. The program is killed when the
+// test ends, if it has not exited by then.
+func StartInPTY(t testing.TB, cmd *exec.Cmd) *Terminal {
+	t.Helper()
+	ptm, err := pty.Start(cmd, 80, 24)
+	if errors.Is(err, errors.ErrUnsupported) {
+		t.Skip("frogtest: pseudo-terminals are not supported on this system")
+	}
+	if err != nil {
+		t.Fatalf("frogtest: start %s: %v", cmd.Path, err)
+	}
+	term := newTerminal(t, ptm)
+	go func() { term.exit(cmd.Wait()) }()
+	t.Cleanup(func() {
+		select {
+		case <-term.done:
+		default:
+			cmd.Process.Kill()
+		}
+		term.waitExit()
+		ptm.Close()
+	})
+	return term
+}
+
+func newTerminal(t testing.TB, ptm *os.File) *Terminal {
+	term := &Terminal{t: t, ptm: ptm, changed: make(chan struct{}), done: make(chan struct{})}
+	go term.read()
+	return term
+}
+
+// read collects the program's output until the terminal closes.
+func (term *Terminal) read() {
+	buf := make([]byte, 4096)
+	for {
+		n, err := term.ptm.Read(buf)
+		if n > 0 {
+			term.mu.Lock()
+			term.out.Write(buf[:n])
+			close(term.changed)
+			term.changed = make(chan struct{})
+			term.mu.Unlock()
+		}
+		if err != nil {
+			return // io.EOF, or EIO once the program side is closed
+		}
+	}
+}
+
+func (term *Terminal) exit(err error) {
+	term.err = err
+	close(term.done)
+}
+
+func (term *Terminal) waitExit() {
+	select {
+	case <-term.done:
+	case <-time.After(Timeout):
+		term.t.Errorf("frogtest: program did not exit within %v", Timeout)
+	}
+}
+
+// Type writes s to the terminal as if typed, escape sequences included:
+// "\x1b[A" is the up arrow.
+func (term *Terminal) Type(s string) {
+	term.t.Helper()
+	if _, err := io.WriteString(term.ptm, s); err != nil {
+		term.t.Fatalf("frogtest: type: %v", err)
+	}
+}
+
+// Resize changes the terminal size; the program sees a resize as it would
+// from a terminal emulator.
+func (term *Terminal) Resize(cols, rows int) {
+	term.t.Helper()
+	if err := pty.SetSize(term.ptm, cols, rows); err != nil {
+		term.t.Fatalf("frogtest: resize: %v", err)
+	}
+}
+
+// Advance moves the clock of a terminal from RunInPTYWithClock forward by
+// d, delivering the ticks and timers that fall due. Size polling is on the
+// same clock, so a Resize is only noticed as the clock moves too.
+func (term *Terminal) Advance(d time.Duration) {
+	term.t.Helper()
+	if term.clock == nil {
+		term.t.Fatal("frogtest: Advance needs a terminal from RunInPTYWithClock")
+	}
+	term.clock.Advance(d)
+}
+
+// Output returns everything the program has written, escape sequences
+// included.
+func (term *Terminal) Output() string {
+	term.mu.Lock()
+	defer term.mu.Unlock()
+	return term.out.String()
+}
+
+// WaitFor waits until the output written so far contains s, failing the
+// test after Timeout.
+func (term *Terminal) WaitFor(s string) {
+	term.t.Helper()
+	deadline := time.After(Timeout)
+	for {
+		term.mu.Lock()
+		found, changed := strings.Contains(term.out.String(), s), term.changed
+		term.mu.Unlock()
+		if found {
+			return
+		}
+		select {
+		case <-changed:
+		case <-deadline:
+			term.t.Fatalf("frogtest: %q not shown within %v; output:\n%q", s, Timeout, term.Output())
+		}
+	}
+}
+
+// Wait waits for the program to exit and returns its error, failing the
+// test after Timeout.
+func (term *Terminal) Wait() error {
+	term.t.Helper()
+	select {
+	case <-term.done:
+		return term.err
+	case <-time.After(Timeout):
+		term.t.Fatalf("frogtest: program did not exit within %v", Timeout)
+		return nil
+	}
+}
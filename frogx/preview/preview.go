@@ -0,0 +1,286 @@
+// Package preview mounts component models standalone for development, like
+// a storybook: each Story builds a model that is drawn in a resizable frame,
+// with theme switching and a log of the messages it receives.
+//
+//	preview.Run(
+//		preview.Story{Name: "viewport", New: func(t preview.Theme) frog.Model {
+//			return viewport.New(40, 10).SetContent(sample)
+//		}},
+//	)
+package preview
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/pondworks-lib/frog"
+)
+
+// Theme is a set of colors a story can build its model with. Switching
+// theme rebuilds the current story.
+type Theme struct {
+	Name   string
+	Fg, Bg frog.Color
+	Accent frog.Color
+}
+
+// DefaultThemes are the themes used unless WithThemes is given.
+var DefaultThemes = []Theme{
+	{Name: "dark", Fg: frog.ColorWhite, Bg: frog.ColorBlack, Accent: frog.ColorCyan},
+	{Name: "light", Fg: frog.ColorBlack, Bg: frog.ColorBrightWhite, Accent: frog.ColorBlue},
+}
+
+// Story is one previewable component.
+type Story struct {
+	Name string
+	New  func(t Theme) frog.Model
+}
+
+// KeyMap names the keys the preview handles itself; everything else goes to
+// the component. Keys are written as KeyMsg.String, prefixed with "alt+" or
+// "ctrl+" for modified runes.
+type KeyMap struct {
+	Next     string // next story
+	Theme    string // next theme
+	Log      string // show or hide the message log
+	Narrower string
+	Wider    string
+	Shorter  string
+	Taller   string
+}
+
+// DefaultKeyMap is the key map used by New.
+var DefaultKeyMap = KeyMap{
+	Next:     "ctrl+n",
+	Theme:    "ctrl+t",
+	Log:      "ctrl+l",
+	Narrower: "alt+h",
+	Wider:    "alt+l",
+	Shorter:  "alt+k",
+	Taller:   "alt+j",
+}
+
+// Bindings describes the preview keys for a help overlay.
+func (k KeyMap) Bindings() []frog.Binding {
+	return []frog.Binding{
+		{Keys: k.Next, Help: "next story"},
+		{Keys: k.Theme, Help: "next theme"},
+		{Keys: k.Log, Help: "toggle message log"},
+		{Keys: k.Narrower + "/" + k.Wider, Help: "narrower/wider"},
+		{Keys: k.Shorter + "/" + k.Taller, Help: "shorter/taller"},
+	}
+}
+
+// Model is the preview host.
+type Model struct {
+	stories []Story
+	themes  []Theme
+	keys    KeyMap
+	story   int
+	theme   int
+	child   frog.Model
+	mounted frog.Cmd // from sizing the first story; run by Init
+
+	width, height int // component frame size
+	logSize       int
+	log           []string
+	showLog       bool
+}
+
+// Option configures a Model.
+type Option func(*Model)
+
+// WithThemes replaces DefaultThemes.
+func WithThemes(themes ...Theme) Option {
+	return func(m *Model) {
+		if len(themes) > 0 {
+			m.themes = themes
+		}
+	}
+}
+
+// WithSize sets the initial component size (default 40x10).
+func WithSize(width, height int) Option {
+	return func(m *Model) { m.width, m.height = max(width, 1), max(height, 1) }
+}
+
+// WithKeyMap replaces the default key map.
+func WithKeyMap(k KeyMap) Option { return func(m *Model) { m.keys = k } }
+
+// WithLogSize sets how many messages the log keeps (default 8).
+func WithLogSize(n int) Option {
+	return func(m *Model) {
+		if n > 0 {
+			m.logSize = n
+		}
+	}
+}
+
+// WithStory starts on the story called name.
+func WithStory(name string) Option {
+	return func(m *Model) {
+		for i, s := range m.stories {
+			if s.Name == name {
+				m.story = i
+			}
+		}
+	}
+}
+
+// New creates a preview of stories. It panics if stories is empty.
+func New(stories []Story, opts ...Option) Model {
+	if len(stories) == 0 {
+		panic("preview: no stories")
+	}
+	m := Model{
+		stories: stories,
+		themes:  DefaultThemes,
+		keys:    DefaultKeyMap,
+		width:   40,
+		height:  10,
+		logSize: 8,
+	}
+	for _, o := range opts {
+		o(&m)
+	}
+	m.mounted = m.mount()
+	return m
+}
+
+// Run previews stories in the alternate screen with mouse reporting and the
+// key help overlay.
+func Run(stories ...Story) error {
+	return frog.Run(New(stories), frog.WithAltScreen(), frog.WithMouse(), frog.WithHelp())
+}
+
+// Init initializes the first story.
+func (m Model) Init() frog.Cmd { return frog.BatchAll(m.child.Init(), m.mounted) }
+
+// KeyBindings lists the component's keys, if it describes them, and the
+// preview's own.
+func (m Model) KeyBindings() []frog.Binding {
+	var b []frog.Binding
+	if kh, ok := m.child.(frog.KeyHelper); ok {
+		b = kh.KeyBindings()
+	}
+	return append(b, m.keys.Bindings()...)
+}
+
+// mount builds the current story with the current theme and sizes it.
+func (m *Model) mount() frog.Cmd {
+	m.child = m.stories[m.story].New(m.themes[m.theme])
+	var cmd frog.Cmd
+	m.child, cmd = m.child.Update(frog.ResizeMsg{Width: m.width, Height: m.height})
+	return cmd
+}
+
+// remount rebuilds the story after switching story or theme.
+func (m *Model) remount() frog.Cmd {
+	resize := m.mount()
+	return frog.BatchAll(m.child.Init(), resize)
+}
+
+// Update handles the preview keys and forwards everything else to the
+// component, logging what it receives.
+func (m Model) Update(msg frog.Msg) (frog.Model, frog.Cmd) {
+	switch msg := msg.(type) {
+	case frog.ResizeMsg:
+		return m, nil // the terminal size; the component gets the frame size
+	case frog.KeyMsg:
+		switch keyName(msg) {
+		case m.keys.Next:
+			m.story = (m.story + 1) % len(m.stories)
+			m.log = nil
+			return m, m.remount()
+		case m.keys.Theme:
+			m.theme = (m.theme + 1) % len(m.themes)
+			return m, m.remount()
+		case m.keys.Log:
+			m.showLog = !m.showLog
+			return m, nil
+		case m.keys.Narrower:
+			return m.resize(-1, 0)
+		case m.keys.Wider:
+			return m.resize(1, 0)
+		case m.keys.Shorter:
+			return m.resize(0, -1)
+		case m.keys.Taller:
+			return m.resize(0, 1)
+		}
+	case frog.MouseMsg:
+		// The component's frame starts below the header, inside the border.
+		msg.X -= 1
+		msg.Y -= 2
+		if msg.X < 1 || msg.Y < 1 || msg.X > m.width || msg.Y > m.height {
+			return m, nil
+		}
+		return m.forward(msg)
+	}
+	return m.forward(msg)
+}
+
+func (m Model) forward(msg frog.Msg) (frog.Model, frog.Cmd) {
+	m.record(msg)
+	var cmd frog.Cmd
+	m.child, cmd = m.child.Update(msg)
+	return m, cmd
+}
+
+func (m *Model) record(msg frog.Msg) {
+	line := strings.TrimPrefix(fmt.Sprintf("%T %+v", msg, msg), "core.")
+	line = strconv.Quote(line) // keep escape sequences in messages from reaching the terminal
+	m.log = append(m.log, line[1:len(line)-1])
+	if len(m.log) > m.logSize {
+		m.log = m.log[len(m.log)-m.logSize:]
+	}
+}
+
+func (m Model) resize(dw, dh int) (frog.Model, frog.Cmd) {
+	m.width, m.height = max(m.width+dw, 1), max(m.height+dh, 1)
+	return m.forward(frog.ResizeMsg{Width: m.width, Height: m.height})
+}
+
+// View draws a header, the framed component and the log.
+func (m Model) View() string {
+	t := m.themes[m.theme]
+	accent := frog.NewStyle().Fg(t.Accent)
+	canvas := frog.NewStyle().Fg(t.Fg).Bg(t.Bg)
+
+	rows := []string{accent.Render(fmt.Sprintf(
+		" %s · %s · %dx%d   %s story  %s theme  %s log  %s/%s/%s/%s size",
+		m.stories[m.story].Name, t.Name, m.width, m.height,
+		m.keys.Next, m.keys.Theme, m.keys.Log,
+		m.keys.Narrower, m.keys.Taller, m.keys.Shorter, m.keys.Wider))}
+
+	border := strings.Repeat("─", m.width)
+	rows = append(rows, accent.Render("┌"+border+"┐"))
+	body := strings.Split(m.child.View(), "\n")
+	for i := 0; i < m.height; i++ {
+		var line string
+		if i < len(body) {
+			line = frog.Truncate(body[i], m.width)
+		}
+		line += strings.Repeat(" ", m.width-frog.DisplayWidth(line))
+		rows = append(rows, accent.Render("│")+canvas.Render(line)+accent.Render("│"))
+	}
+	rows = append(rows, accent.Render("└"+border+"┘"))
+
+	if m.showLog {
+		rows = append(rows, "", accent.Render(" messages"))
+		for _, l := range m.log {
+			rows = append(rows, " "+l)
+		}
+	}
+	return strings.Join(rows, "\n")
+}
+
+func keyName(k frog.KeyMsg) string {
+	switch {
+	case k.Ctrl && k.Type == frog.KeyRune:
+		return "ctrl+" + string(k.Rune)
+	case k.Alt:
+		return "alt+" + k.String
+	}
+	return k.String
+}
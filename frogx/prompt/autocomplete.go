@@ -0,0 +1,128 @@
+package prompt
+
+import (
+	"strings"
+
+	"github.com/pondworks-lib/frog"
+)
+
+// Completer returns suggestions for the text typed so far. It runs as a
+// command, off the session loop, so it may block (e.g. to query a server);
+// results for text the user has since changed are discarded.
+type Completer func(input string) []string
+
+// maxSuggestions is how many suggestions the dropdown shows.
+const maxSuggestions = 6
+
+// Autocomplete asks for a line of text, offering suggestions from complete
+// below the input. ↑/↓ pick a suggestion, Tab copies it into the input and
+// Enter accepts the picked suggestion or, with none picked, the input.
+func Autocomplete(prompt string, complete Completer) (string, error) {
+	m, err := run(autocompleteModel{input: inputModel{prompt: prompt}, complete: complete, pick: -1})
+	return m.answer(), err
+}
+
+// suggestionsMsg carries a completer's results for query.
+type suggestionsMsg struct {
+	query string
+	items []string
+}
+
+type autocompleteModel struct {
+	input       inputModel
+	complete    Completer
+	suggestions []string
+	pick        int // highlighted suggestion, -1 for none
+}
+
+func (m autocompleteModel) canceled() bool { return m.input.cancel }
+
+func (m autocompleteModel) Init() frog.Cmd { return m.suggest() }
+
+// suggest runs the completer for the current input.
+func (m autocompleteModel) suggest() frog.Cmd {
+	if m.complete == nil {
+		return nil
+	}
+	query, complete := m.input.value, m.complete
+	return func() frog.Msg { return suggestionsMsg{query: query, items: complete(query)} }
+}
+
+func (m autocompleteModel) answer() string {
+	if m.pick >= 0 && m.pick < len(m.suggestions) {
+		return m.suggestions[m.pick]
+	}
+	return m.input.value
+}
+
+// OnInterrupt cancels the prompt on Ctrl+C and SIGINT.
+func (m autocompleteModel) OnInterrupt(msg frog.InterruptMsg) (frog.Model, frog.Cmd) {
+	return m.Update(msg)
+}
+
+func (m autocompleteModel) Update(msg frog.Msg) (frog.Model, frog.Cmd) {
+	switch msg := msg.(type) {
+	case suggestionsMsg:
+		if msg.query == m.input.value {
+			m.suggestions = msg.items
+			m.pick = -1
+		}
+		return m, nil
+	case frog.InterruptMsg:
+		m.input.cancel = true
+		return m, frog.Quit()
+	case frog.PasteMsg:
+		m.input = m.input.insert(msg.Text)
+		return m, m.suggest()
+	case frog.KeyMsg:
+		if cancelKey(msg) {
+			m.input.cancel = true
+			return m, frog.Quit()
+		}
+		n := min(len(m.suggestions), maxSuggestions)
+		switch msg.Type {
+		case frog.KeyEnter:
+			m.input.value = m.answer()
+			m.input.done = true
+			return m, frog.Quit()
+		case frog.KeyUp:
+			if n > 0 {
+				m.pick = max(m.pick-1, -1)
+			}
+			return m, nil
+		case frog.KeyDown:
+			if n > 0 {
+				m.pick = min(m.pick+1, n-1)
+			}
+			return m, nil
+		case frog.KeyTab:
+			if n == 0 {
+				return m, nil
+			}
+			m.input.value = m.suggestions[max(m.pick, 0)]
+			m.input.cursor = len(m.input.value)
+			return m, m.suggest()
+		}
+		before := m.input.value
+		var edited bool
+		if m.input, edited = m.input.edit(msg); edited && m.input.value != before {
+			return m, m.suggest()
+		}
+	}
+	return m, nil
+}
+
+func (m autocompleteModel) View() string {
+	if m.input.done || m.input.cancel {
+		return m.input.View()
+	}
+	rows := []string{m.input.View()}
+	for i, s := range m.suggestions[:min(len(m.suggestions), maxSuggestions)] {
+		if i == m.pick {
+			rows = append(rows, cursorStyle.Render("> "+s))
+		} else {
+			rows = append(rows, hintStyle.Render("  "+s))
+		}
+	}
+	return strings.Join(rows, "\n")
+}
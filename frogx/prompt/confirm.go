@@ -0,0 +1,57 @@
+package prompt
+
+import "github.com/pondworks-lib/frog"
+
+// Confirm asks a yes/no question. Enter alone answers no.
+func Confirm(prompt string) (bool, error) {
+	m, err := run(confirmModel{prompt: prompt})
+	return m.yes, err
+}
+
+type confirmModel struct {
+	prompt string
+	yes    bool
+	done   bool
+	cancel bool
+}
+
+func (m confirmModel) canceled() bool { return m.cancel }
+
+func (m confirmModel) Init() frog.Cmd { return nil }
+
+// OnInterrupt cancels the prompt on Ctrl+C and SIGINT.
+func (m confirmModel) OnInterrupt(msg frog.InterruptMsg) (frog.Model, frog.Cmd) { return m.Update(msg) }
+
+func (m confirmModel) Update(msg frog.Msg) (frog.Model, frog.Cmd) {
+	switch msg := msg.(type) {
+	case frog.InterruptMsg:
+		m.cancel = true
+		return m, frog.Quit()
+	case frog.KeyMsg:
+		if cancelKey(msg) {
+			m.cancel = true
+			return m, frog.Quit()
+		}
+		switch msg.String {
+		case "y", "Y":
+			m.yes, m.done = true, true
+		case "n", "N", "\r":
+			m.done = true
+		}
+		if m.done {
+			return m, frog.Quit()
+		}
+	}
+	return m, nil
+}
+
+func (m confirmModel) View() string {
+	if m.done {
+		answer := "No"
+		if m.yes {
+			answer = "Yes"
+		}
+		return question(m.prompt) + " " + answerStyle.Render(answer)
+	}
+	return question(m.prompt) + " " + hintStyle.Render("(y/N)") + " "
+}
@@ -0,0 +1,65 @@
+package prompt
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/pondworks-lib/frog"
+)
+
+// inlineRenderer draws frames below the cursor instead of taking over the
+// screen: each frame moves back to the first row of the previous one and
+// redraws from there, so the final frame stays in the scrollback.
+type inlineRenderer struct {
+	mu    sync.Mutex
+	out   io.Writer
+	last  string
+	lines int  // rows drawn by the previous frame
+	plain bool // NO_COLOR is set
+}
+
+func newInlineRenderer(out io.Writer) *inlineRenderer {
+	return &inlineRenderer{out: out, plain: os.Getenv("NO_COLOR") != ""}
+}
+
+func (r *inlineRenderer) Clear() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	io.WriteString(r.out, "\x1b[?25l")
+}
+
+func (r *inlineRenderer) Render(s string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.plain {
+		s = frog.StripANSI(s)
+	}
+	if s == r.last {
+		return
+	}
+	var b strings.Builder
+	if r.lines > 1 {
+		fmt.Fprintf(&b, "\x1b[%dA", r.lines-1)
+	}
+	b.WriteString("\r\x1b[0J")
+	// The terminal is in raw mode, so line feeds need explicit returns.
+	b.WriteString(strings.ReplaceAll(s, "\n", "\r\n"))
+	io.WriteString(r.out, b.String())
+	r.last = s
+	r.lines = strings.Count(s, "\n") + 1
+}
+
+func (r *inlineRenderer) Invalidate() {
+	r.mu.Lock()
+	r.last = ""
+	r.mu.Unlock()
+}
+
+func (r *inlineRenderer) Close() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	io.WriteString(r.out, "\r\n\x1b[?25h")
+}
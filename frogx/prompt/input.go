@@ -0,0 +1,117 @@
+package prompt
+
+import (
+	"github.com/pondworks-lib/frog"
+	"github.com/pondworks-lib/frog/core/text"
+)
+
+// Input asks for a line of text.
+func Input(prompt string) (string, error) {
+	m, err := run(inputModel{prompt: prompt})
+	return m.value, err
+}
+
+type inputModel struct {
+	prompt string
+	value  string
+	cursor int // byte offset of a grapheme boundary in value
+	done   bool
+	cancel bool
+}
+
+func (m inputModel) canceled() bool { return m.cancel }
+
+func (m inputModel) Init() frog.Cmd { return nil }
+
+// OnInterrupt cancels the prompt on Ctrl+C and SIGINT.
+func (m inputModel) OnInterrupt(msg frog.InterruptMsg) (frog.Model, frog.Cmd) { return m.Update(msg) }
+
+func (m inputModel) Update(msg frog.Msg) (frog.Model, frog.Cmd) {
+	switch msg := msg.(type) {
+	case frog.InterruptMsg:
+		m.cancel = true
+		return m, frog.Quit()
+	case frog.PasteMsg:
+		m = m.insert(msg.Text)
+	case frog.KeyMsg:
+		if cancelKey(msg) {
+			m.cancel = true
+			return m, frog.Quit()
+		}
+		if msg.Type == frog.KeyEnter {
+			m.done = true
+			return m, frog.Quit()
+		}
+		m, _ = m.edit(msg)
+	}
+	return m, nil
+}
+
+// edit applies a line-editing key and reports whether k was one. The
+// cursor moves and deletes whole grapheme clusters, so an accented letter
+// or an emoji is one step; Ctrl or Alt with the arrows, Alt+B and Alt+F
+// move by word, and Ctrl+W deletes the word before the cursor.
+func (m inputModel) edit(k frog.KeyMsg) (inputModel, bool) {
+	switch k.Canonical() {
+	case "ctrl+left", "alt+left", "alt+b":
+		m.cursor = text.PrevWord(m.value, m.cursor)
+		return m, true
+	case "ctrl+right", "alt+right", "alt+f":
+		m.cursor = text.NextWord(m.value, m.cursor)
+		return m, true
+	case "ctrl+w":
+		return m.delete(text.PrevWord(m.value, m.cursor), m.cursor), true
+	}
+	switch k.Type {
+	case frog.KeyBackspace:
+		m = m.delete(text.PrevGrapheme(m.value, m.cursor), m.cursor)
+	case frog.KeyDelete:
+		m = m.delete(m.cursor, text.NextGrapheme(m.value, m.cursor))
+	case frog.KeyLeft:
+		m.cursor = text.PrevGrapheme(m.value, m.cursor)
+	case frog.KeyRight:
+		m.cursor = text.NextGrapheme(m.value, m.cursor)
+	case frog.KeyHome:
+		m.cursor = 0
+	case frog.KeyEnd:
+		m.cursor = len(m.value)
+	default:
+		s, ok := typed(k)
+		if !ok {
+			return m, false
+		}
+		m = m.insert(s)
+	}
+	return m, true
+}
+
+func (m inputModel) insert(s string) inputModel {
+	m.value = m.value[:m.cursor] + s + m.value[m.cursor:]
+	m.cursor += len(s)
+	return m
+}
+
+// delete removes value[from:to] and leaves the cursor at from.
+func (m inputModel) delete(from, to int) inputModel {
+	m.value = m.value[:from] + m.value[to:]
+	m.cursor = from
+	return m
+}
+
+func (m inputModel) View() string {
+	if m.done {
+		return question(m.prompt) + " " + answerStyle.Render(m.value)
+	}
+	return question(m.prompt) + " " + editLine(m.value, m.cursor)
+}
+
+// editLine renders text with a block cursor on the grapheme cluster at
+// byte offset cursor.
+func editLine(value string, cursor int) string {
+	end := text.NextGrapheme(value, cursor)
+	under := value[cursor:end]
+	if under == "" {
+		under = " "
+	}
+	return value[:cursor] + frog.NewStyle().Reversed().Render(under) + value[end:]
+}
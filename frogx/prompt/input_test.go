@@ -0,0 +1,35 @@
+package prompt
+
+import (
+	"testing"
+
+	"github.com/pondworks-lib/frog"
+)
+
+func TestInputEditsGraphemes(t *testing.T) {
+	tests := []struct {
+		name  string
+		value string
+		keys  []frog.KeyMsg
+		want  string
+	}{
+		{"backspace combining mark", "cafe\u0301", []frog.KeyMsg{{Type: frog.KeyBackspace}}, "caf"},
+		{"backspace flag", "go🇫🇷", []frog.KeyMsg{{Type: frog.KeyBackspace}}, "go"},
+		{"delete after left", "a👍🏽b", []frog.KeyMsg{{Type: frog.KeyLeft}, {Type: frog.KeyLeft}, {Type: frog.KeyDelete}}, "ab"},
+		{"insert after left", "ñb", []frog.KeyMsg{{Type: frog.KeyLeft}, {Type: frog.KeyLeft}, {Type: frog.KeyRight}, {Type: frog.KeyRune, Rune: 'x'}}, "ñxb"},
+		{"delete word", "hello wide world", []frog.KeyMsg{{Type: frog.KeyRune, Rune: 'w', Ctrl: true}}, "hello wide "},
+		{"word left", "one two", []frog.KeyMsg{{Type: frog.KeyLeft, Ctrl: true}, {Type: frog.KeyRune, Rune: '_'}}, "one _two"},
+		{"word right", "one two", []frog.KeyMsg{{Type: frog.KeyHome}, {Type: frog.KeyRune, Rune: 'f', Alt: true}, {Type: frog.KeyRune, Rune: '!'}}, "one! two"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := inputModel{value: tt.value, cursor: len(tt.value)}
+			for _, k := range tt.keys {
+				m, _ = m.edit(k)
+			}
+			if m.value != tt.want {
+				t.Errorf("value = %q, want %q", m.value, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,105 @@
+package prompt
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/pondworks-lib/frog"
+)
+
+// MultiSelect asks the user to pick any number of options and returns
+// their indices in order. Space toggles the option under the cursor and
+// 'a' selects all (or none, when all are selected).
+func MultiSelect(prompt string, options []string) ([]int, error) {
+	if len(options) == 0 {
+		return nil, errors.New("prompt: no options")
+	}
+	m, err := run(multiSelectModel{prompt: prompt, options: options, chosen: make([]bool, len(options))})
+	if err != nil {
+		return nil, err
+	}
+	return m.selected(), nil
+}
+
+type multiSelectModel struct {
+	prompt  string
+	options []string
+	chosen  []bool
+	cursor  int
+	done    bool
+	cancel  bool
+}
+
+func (m multiSelectModel) canceled() bool { return m.cancel }
+
+func (m multiSelectModel) Init() frog.Cmd { return nil }
+
+func (m multiSelectModel) selected() []int {
+	var idx []int
+	for i, c := range m.chosen {
+		if c {
+			idx = append(idx, i)
+		}
+	}
+	return idx
+}
+
+// OnInterrupt cancels the prompt on Ctrl+C and SIGINT.
+func (m multiSelectModel) OnInterrupt(msg frog.InterruptMsg) (frog.Model, frog.Cmd) {
+	return m.Update(msg)
+}
+
+func (m multiSelectModel) Update(msg frog.Msg) (frog.Model, frog.Cmd) {
+	switch msg := msg.(type) {
+	case frog.InterruptMsg:
+		m.cancel = true
+		return m, frog.Quit()
+	case frog.KeyMsg:
+		if cancelKey(msg) {
+			m.cancel = true
+			return m, frog.Quit()
+		}
+		switch {
+		case msg.Type == frog.KeyEnter:
+			m.done = true
+			return m, frog.Quit()
+		case msg.Type == frog.KeyUp, msg.String == "k":
+			m.cursor = (m.cursor + len(m.options) - 1) % len(m.options)
+		case msg.Type == frog.KeyDown, msg.String == "j":
+			m.cursor = (m.cursor + 1) % len(m.options)
+		case msg.Type == frog.KeySpace:
+			m.chosen = append([]bool(nil), m.chosen...)
+			m.chosen[m.cursor] = !m.chosen[m.cursor]
+		case msg.String == "a":
+			all := len(m.selected()) == len(m.options)
+			m.chosen = make([]bool, len(m.options))
+			for i := range m.chosen {
+				m.chosen[i] = !all
+			}
+		}
+	}
+	return m, nil
+}
+
+func (m multiSelectModel) View() string {
+	if m.done {
+		var names []string
+		for _, i := range m.selected() {
+			names = append(names, m.options[i])
+		}
+		return question(m.prompt) + " " + answerStyle.Render(strings.Join(names, ", "))
+	}
+	rows := []string{question(m.prompt) + " " + hintStyle.Render("space to toggle, a for all, enter to confirm")}
+	for i, o := range m.options {
+		box := "[ ] "
+		if m.chosen[i] {
+			box = "[x] "
+		}
+		if i == m.cursor {
+			rows = append(rows, cursorStyle.Render("> "+box+o))
+		} else {
+			rows = append(rows, "  "+box+o)
+		}
+	}
+	return strings.Join(rows, "\n")
+}
@@ -0,0 +1,258 @@
+package prompt
+
+import (
+	"math"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/pondworks-lib/frog"
+	"github.com/pondworks-lib/frog/components/progress"
+)
+
+// PasswordOption configures Password.
+type PasswordOption func(*passwordModel)
+
+// WithMask sets the character echoed per typed character (default '*').
+// A zero mask echoes nothing.
+func WithMask(r rune) PasswordOption { return func(m *passwordModel) { m.mask = r } }
+
+// WithConfirmation asks for the secret a second time under label and
+// starts over if the two entries differ.
+func WithConfirmation(label string) PasswordOption {
+	return func(m *passwordModel) { m.confirmLabel = label }
+}
+
+// WithStrengthMeter shows an estimate of the secret's strength as it is
+// typed.
+func WithStrengthMeter() PasswordOption { return func(m *passwordModel) { m.meter = true } }
+
+// Password asks for a secret without echoing it. The returned slice is the
+// only copy left in memory; zero it when done. Buffers used while typing
+// are zeroed before Password returns.
+func Password(prompt string, opts ...PasswordOption) ([]byte, error) {
+	m := passwordModel{prompt: prompt, mask: '*', entry: &secret{}, confirm: &secret{}}
+	for _, o := range opts {
+		o(&m)
+	}
+	defer m.entry.wipe()
+	defer m.confirm.wipe()
+	final, err := run(m)
+	if err != nil {
+		return nil, err
+	}
+	return final.entry.bytes(), nil
+}
+
+// secret is an append-only rune buffer that zeroes memory it lets go of.
+// It is shared by copies of the model so nothing is left behind.
+type secret struct{ r []rune }
+
+func (s *secret) add(rs []rune) {
+	if len(s.r)+len(rs) > cap(s.r) {
+		grown := make([]rune, len(s.r), max(2*cap(s.r), len(s.r)+len(rs), 16))
+		copy(grown, s.r)
+		s.wipe()
+		s.r = grown
+	}
+	s.r = append(s.r, rs...)
+}
+
+func (s *secret) backspace() {
+	if n := len(s.r); n > 0 {
+		s.r[n-1] = 0
+		s.r = s.r[:n-1]
+	}
+}
+
+func (s *secret) wipe() {
+	clear(s.r[:cap(s.r)])
+	s.r = s.r[:0]
+}
+
+func (s *secret) equal(o *secret) bool {
+	if len(s.r) != len(o.r) {
+		return false
+	}
+	diff := rune(0)
+	for i := range s.r {
+		diff |= s.r[i] ^ o.r[i]
+	}
+	return diff == 0
+}
+
+func (s *secret) bytes() []byte {
+	n := 0
+	for _, r := range s.r {
+		n += utf8.RuneLen(r)
+	}
+	b := make([]byte, 0, n)
+	for _, r := range s.r {
+		b = utf8.AppendRune(b, r)
+	}
+	return b
+}
+
+type passwordModel struct {
+	prompt       string
+	confirmLabel string
+	mask         rune
+	meter        bool
+
+	entry, confirm *secret
+	confirming     bool
+	mismatch       bool
+	done           bool
+	cancel         bool
+}
+
+func (m passwordModel) canceled() bool { return m.cancel }
+
+func (m passwordModel) Init() frog.Cmd { return nil }
+
+// HidingInput keeps typed characters out of the inspector's messages.
+func (m passwordModel) HidingInput() bool { return true }
+
+// DebugState shows the inspector the prompt's progress but not the secret.
+func (m passwordModel) DebugState() any {
+	return map[string]any{
+		"prompt":     m.prompt,
+		"confirming": m.confirming,
+		"mismatch":   m.mismatch,
+		"done":       m.done,
+		"cancel":     m.cancel,
+	}
+}
+
+func (m passwordModel) field() *secret {
+	if m.confirming {
+		return m.confirm
+	}
+	return m.entry
+}
+
+// OnInterrupt cancels the prompt on Ctrl+C and SIGINT.
+func (m passwordModel) OnInterrupt(msg frog.InterruptMsg) (frog.Model, frog.Cmd) {
+	return m.Update(msg)
+}
+
+func (m passwordModel) Update(msg frog.Msg) (frog.Model, frog.Cmd) {
+	switch msg := msg.(type) {
+	case frog.InterruptMsg:
+		m.cancel = true
+		return m, frog.Quit()
+	case frog.PasteMsg:
+		m.field().add([]rune(msg.Text))
+	case frog.KeyMsg:
+		if cancelKey(msg) {
+			m.cancel = true
+			return m, frog.Quit()
+		}
+		switch msg.Type {
+		case frog.KeyEnter:
+			return m.submit()
+		case frog.KeyBackspace:
+			m.field().backspace()
+		default:
+			if s, ok := typed(msg); ok {
+				m.mismatch = false
+				m.field().add([]rune(s))
+			}
+		}
+	}
+	return m, nil
+}
+
+func (m passwordModel) submit() (frog.Model, frog.Cmd) {
+	if m.confirmLabel == "" {
+		m.done = true
+		return m, frog.Quit()
+	}
+	if !m.confirming {
+		m.confirming = true
+		return m, nil
+	}
+	if !m.entry.equal(m.confirm) {
+		m.entry.wipe()
+		m.confirm.wipe()
+		m.confirming = false
+		m.mismatch = true
+		return m, nil
+	}
+	m.done = true
+	return m, frog.Quit()
+}
+
+func (m passwordModel) echo(s *secret) string {
+	if m.mask == 0 {
+		return ""
+	}
+	return strings.Repeat(string(m.mask), len(s.r))
+}
+
+func (m passwordModel) View() string {
+	if m.done {
+		return question(m.prompt) + " " + hintStyle.Render("(hidden)")
+	}
+	rows := []string{question(m.prompt) + " " + m.echo(m.entry)}
+	if m.meter && !m.confirming {
+		rows = append(rows, "  "+strengthBar(m.entry.r))
+	}
+	if m.confirming {
+		rows[0] = question(m.prompt) + " " + hintStyle.Render("(entered)")
+		rows = append(rows, question(m.confirmLabel)+" "+m.echo(m.confirm))
+	}
+	if m.mismatch {
+		rows = append(rows, errorStyle.Render("  entries did not match, try again"))
+	}
+	return strings.Join(rows, "\n")
+}
+
+var strengthLevels = []struct {
+	label string
+	color frog.Color
+}{
+	{"weak", frog.ColorRed},
+	{"fair", frog.ColorYellow},
+	{"good", frog.ColorGreen},
+	{"strong", frog.ColorBrightGreen},
+}
+
+// strengthBar estimates the secret's entropy from its length and the
+// character classes it uses, reaching full strength at 80 bits.
+func strengthBar(rs []rune) string {
+	var lower, upper, digit, other, wide bool
+	for _, r := range rs {
+		switch {
+		case r > unicode.MaxASCII:
+			wide = true
+		case unicode.IsLower(r):
+			lower = true
+		case unicode.IsUpper(r):
+			upper = true
+		case unicode.IsDigit(r):
+			digit = true
+		default:
+			other = true
+		}
+	}
+	pool := 0
+	for _, c := range []struct {
+		used bool
+		size int
+	}{{lower, 26}, {upper, 26}, {digit, 10}, {other, 33}, {wide, 100}} {
+		if c.used {
+			pool += c.size
+		}
+	}
+	score := 0.0
+	if pool > 0 {
+		score = math.Min(float64(len(rs))*math.Log2(float64(pool))/80, 1)
+	}
+	level := strengthLevels[min(int(score*float64(len(strengthLevels))), len(strengthLevels)-1)]
+	bar := progress.New(
+		progress.WithWidth(20),
+		progress.WithStyles(frog.NewStyle().Fg(level.color), hintStyle),
+	).SetPercent(score)
+	return bar.View() + " " + level.label
+}
@@ -0,0 +1,77 @@
+// Package prompt asks one-off questions from command-line tools without
+// writing a Model. Each function runs a small session inline, below the
+// current cursor position on stderr, and returns the answer:
+//
+//	name, err := prompt.Input("Project name")
+//	i, err := prompt.Select("Language", []string{"Go", "Rust", "Zig"})
+//	ok, err := prompt.Confirm("Create it?")
+//	picked, err := prompt.MultiSelect("Features", []string{"CI", "Docker", "Docs"})
+//	city, err := prompt.Autocomplete("City", cities.Lookup)
+//	pw, err := prompt.Password("Password", prompt.WithConfirmation("Repeat"))
+package prompt
+
+import (
+	"errors"
+	"os"
+
+	"github.com/pondworks-lib/frog"
+)
+
+var (
+	// ErrInterrupted is returned when the user cancels with Ctrl+C or Esc.
+	ErrInterrupted = errors.New("prompt: interrupted")
+	// ErrNotTerminal is returned when stdin or stderr is not a terminal.
+	ErrNotTerminal = errors.New("prompt: not a terminal")
+)
+
+var (
+	questionStyle = frog.NewStyle().Fg(frog.ColorCyan).Bolded()
+	answerStyle   = frog.NewStyle().Fg(frog.ColorCyan)
+	cursorStyle   = frog.NewStyle().Fg(frog.ColorCyan).Bolded()
+	hintStyle     = frog.NewStyle().Fainted()
+	errorStyle    = frog.NewStyle().Fg(frog.ColorRed)
+)
+
+// result is implemented by the prompt models.
+type result interface {
+	frog.Model
+	frog.Interrupter
+	canceled() bool
+}
+
+// run drives m inline and returns the final model.
+func run[M result](m M) (M, error) {
+	if !frog.IsTerminal(os.Stdin) || !frog.IsTerminal(os.Stderr) {
+		return m, ErrNotTerminal
+	}
+	app := frog.NewApp(m,
+		frog.WithOut(os.Stderr),
+		frog.WithRenderer(newInlineRenderer(os.Stderr)),
+	)
+	if err := app.Run(); err != nil {
+		return m, err
+	}
+	final, ok := app.Model().(M)
+	if !ok || final.canceled() {
+		return m, ErrInterrupted
+	}
+	return final, nil
+}
+
+// question renders the "? prompt" header shared by all prompts.
+func question(prompt string) string { return questionStyle.Render("?") + " " + prompt }
+
+// cancelKey reports whether k aborts the prompt.
+func cancelKey(k frog.KeyMsg) bool { return k.Type == frog.KeyEsc }
+
+// typed returns the text a key press inserts, if any.
+func typed(k frog.KeyMsg) (string, bool) {
+	if k.Alt || k.Ctrl {
+		return "", false
+	}
+	switch k.Type {
+	case frog.KeyRune, frog.KeySpace, frog.KeyQ:
+		return string(k.Rune), true
+	}
+	return "", false
+}
@@ -0,0 +1,77 @@
+package prompt
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/pondworks-lib/frog"
+)
+
+// Select asks the user to pick one of options and returns its index.
+func Select(prompt string, options []string) (int, error) {
+	if len(options) == 0 {
+		return -1, errors.New("prompt: no options")
+	}
+	m, err := run(selectModel{prompt: prompt, options: options})
+	if err != nil {
+		return -1, err
+	}
+	return m.cursor, nil
+}
+
+type selectModel struct {
+	prompt  string
+	options []string
+	cursor  int
+	done    bool
+	cancel  bool
+}
+
+func (m selectModel) canceled() bool { return m.cancel }
+
+func (m selectModel) Init() frog.Cmd { return nil }
+
+// OnInterrupt cancels the prompt on Ctrl+C and SIGINT.
+func (m selectModel) OnInterrupt(msg frog.InterruptMsg) (frog.Model, frog.Cmd) { return m.Update(msg) }
+
+func (m selectModel) Update(msg frog.Msg) (frog.Model, frog.Cmd) {
+	switch msg := msg.(type) {
+	case frog.InterruptMsg:
+		m.cancel = true
+		return m, frog.Quit()
+	case frog.KeyMsg:
+		if cancelKey(msg) {
+			m.cancel = true
+			return m, frog.Quit()
+		}
+		switch {
+		case msg.Type == frog.KeyEnter:
+			m.done = true
+			return m, frog.Quit()
+		case msg.Type == frog.KeyUp, msg.String == "k":
+			m.cursor = (m.cursor + len(m.options) - 1) % len(m.options)
+		case msg.Type == frog.KeyDown, msg.String == "j":
+			m.cursor = (m.cursor + 1) % len(m.options)
+		case msg.Type == frog.KeyHome:
+			m.cursor = 0
+		case msg.Type == frog.KeyEnd:
+			m.cursor = len(m.options) - 1
+		}
+	}
+	return m, nil
+}
+
+func (m selectModel) View() string {
+	if m.done {
+		return question(m.prompt) + " " + answerStyle.Render(m.options[m.cursor])
+	}
+	rows := []string{question(m.prompt) + " " + hintStyle.Render("↑/↓ to move, enter to choose")}
+	for i, o := range m.options {
+		if i == m.cursor {
+			rows = append(rows, cursorStyle.Render("> "+o))
+		} else {
+			rows = append(rows, "  "+o)
+		}
+	}
+	return strings.Join(rows, "\n")
+}
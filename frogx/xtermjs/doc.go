@@ -0,0 +1,16 @@
+// Package xtermjs runs frog programs in the browser on an xterm.js
+// terminal, for documentation playgrounds and demos. Build the program
+// with GOOS=js GOARCH=wasm, load it with wasm_exec.js next to an xterm.js
+// Terminal, and hand that terminal to Run:
+//
+//	func main() {
+//		term := js.Global().Get("term") // new Terminal(), opened on the page
+//		if err := xtermjs.Run(model{}, term); err != nil {
+//			println(err.Error())
+//		}
+//	}
+//
+// The model runs unchanged: keys and pastes arrive from the terminal's
+// onData events, frames are written to it, and its rows and columns are
+// the session's size.
+package xtermjs
@@ -0,0 +1,128 @@
+//go:build js && wasm
+
+package xtermjs
+
+import (
+	"io"
+	"sync"
+	"syscall/js"
+
+	"github.com/pondworks-lib/frog"
+)
+
+// Terminal connects a session to an xterm.js Terminal object. It is the
+// session's input, output and frog.Terminal.
+type Terminal struct {
+	term   js.Value
+	onData js.Func
+	sub    js.Value // the onData disposable
+
+	mu     sync.Mutex
+	ready  *sync.Cond
+	buf    []byte
+	closed bool
+}
+
+// New attaches to term, an xterm.js Terminal, and starts collecting its
+// input. Close detaches it.
+func New(term js.Value) *Terminal {
+	t := &Terminal{term: term}
+	t.ready = sync.NewCond(&t.mu)
+	t.onData = js.FuncOf(func(_ js.Value, args []js.Value) any {
+		if len(args) > 0 {
+			t.mu.Lock()
+			t.buf = append(t.buf, args[0].String()...)
+			t.mu.Unlock()
+			t.ready.Signal()
+		}
+		return nil
+	})
+	t.sub = term.Call("onData", t.onData)
+	return t
+}
+
+// Read returns input typed into the terminal, blocking until there is
+// some. It returns io.EOF once the terminal is closed.
+func (t *Terminal) Read(p []byte) (int, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	for len(t.buf) == 0 && !t.closed {
+		t.ready.Wait()
+	}
+	if len(t.buf) == 0 {
+		return 0, io.EOF
+	}
+	n := copy(p, t.buf)
+	t.buf = t.buf[n:]
+	return n, nil
+}
+
+// Write sends output to the terminal.
+func (t *Terminal) Write(p []byte) (int, error) {
+	data := js.Global().Get("Uint8Array").New(len(p))
+	js.CopyBytesToJS(data, p)
+	t.term.Call("write", data)
+	return len(p), nil
+}
+
+// IsTerminal reports true: xterm.js is always interactive.
+func (t *Terminal) IsTerminal() bool { return true }
+
+// MakeRaw does nothing, as xterm.js passes every key through already.
+func (t *Terminal) MakeRaw() (func() error, error) {
+	return func() error { return nil }, nil
+}
+
+// Size returns the terminal's columns and rows.
+func (t *Terminal) Size() (int, int, error) {
+	return t.term.Get("cols").Int(), t.term.Get("rows").Int(), nil
+}
+
+// Close stops listening for input and ends pending reads.
+func (t *Terminal) Close() error {
+	t.mu.Lock()
+	if t.closed {
+		t.mu.Unlock()
+		return nil
+	}
+	t.closed = true
+	t.mu.Unlock()
+	t.ready.Broadcast()
+	t.sub.Call("dispose")
+	t.onData.Release()
+	return nil
+}
+
+// Capabilities describes what xterm.js supports, with the current size.
+func (t *Terminal) Capabilities() frog.Caps {
+	w, h, _ := t.Size()
+	return frog.Caps{
+		Term:           "xterm-256color",
+		TTY:            true,
+		Width:          w,
+		Height:         h,
+		ColorProfile:   frog.ColorTrueColor,
+		Mouse:          true,
+		BracketedPaste: true,
+		SyncOutput:     true,
+	}
+}
+
+// Options returns the session options that run a session on t. Options
+// given after them take precedence.
+func (t *Terminal) Options() []frog.Option {
+	return []frog.Option{
+		frog.WithIn(t),
+		frog.WithOut(t),
+		frog.WithTerminal(t),
+		frog.WithCapabilities(t.Capabilities()),
+	}
+}
+
+// Run runs m on term, an xterm.js Terminal, until it quits. opts are
+// applied after the terminal's own options.
+func Run(m frog.Model, term js.Value, opts ...frog.Option) error {
+	t := New(term)
+	defer t.Close()
+	return frog.Run(m, append(t.Options(), opts...)...)
+}
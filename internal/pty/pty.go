@@ -0,0 +1,34 @@
+// Package pty opens pseudo-terminals and starts commands on them, so tests
+// can exercise raw mode, resizes and escape handling end to end, the way a
+// terminal emulator would. It supports Linux and macOS; elsewhere Open
+// returns errors.ErrUnsupported.
+package pty
+
+import (
+	"os"
+	"os/exec"
+)
+
+// Start runs cmd on a new pseudo-terminal of the given size, as its
+// controlling terminal and standard input, output and error, and returns
+// the controlling side: writes to it are typed into the terminal and reads
+// return the program's output. The terminal side is closed in this process
+// once cmd has started.
+func Start(cmd *exec.Cmd, cols, rows int) (*os.File, error) {
+	ptm, pts, err := Open()
+	if err != nil {
+		return nil, err
+	}
+	defer pts.Close()
+	if err := SetSize(pts, cols, rows); err != nil {
+		ptm.Close()
+		return nil, err
+	}
+	cmd.Stdin, cmd.Stdout, cmd.Stderr = pts, pts, pts
+	setControllingTerminal(cmd)
+	if err := cmd.Start(); err != nil {
+		ptm.Close()
+		return nil, err
+	}
+	return ptm, nil
+}
@@ -0,0 +1,37 @@
+package pty
+
+import (
+	"bytes"
+	"os"
+	"unsafe"
+
+	"golang.org/x/sys/unix"
+)
+
+// Open returns a new pseudo-terminal: the controlling side and the
+// terminal a program runs on.
+func Open() (ptm, pts *os.File, err error) {
+	ptm, err = os.OpenFile("/dev/ptmx", os.O_RDWR|unix.O_NOCTTY, 0)
+	if err != nil {
+		return nil, nil, err
+	}
+	fd := ptm.Fd()
+	var name [128]byte
+	for _, req := range []uintptr{unix.TIOCPTYGRANT, unix.TIOCPTYUNLK} {
+		if _, _, errno := unix.Syscall(unix.SYS_IOCTL, fd, req, 0); errno != 0 {
+			ptm.Close()
+			return nil, nil, errno
+		}
+	}
+	if _, _, errno := unix.Syscall(unix.SYS_IOCTL, fd, unix.TIOCPTYGNAME, uintptr(unsafe.Pointer(&name[0]))); errno != 0 {
+		ptm.Close()
+		return nil, nil, errno
+	}
+	n := bytes.IndexByte(name[:], 0)
+	pts, err = os.OpenFile(string(name[:n]), os.O_RDWR|unix.O_NOCTTY, 0)
+	if err != nil {
+		ptm.Close()
+		return nil, nil, err
+	}
+	return ptm, pts, nil
+}
@@ -0,0 +1,17 @@
+//go:build !linux && !darwin
+
+package pty
+
+import (
+	"errors"
+	"os"
+	"os/exec"
+)
+
+// Open is not supported on this system.
+func Open() (ptm, pts *os.File, err error) { return nil, nil, errors.ErrUnsupported }
+
+// SetSize is not supported on this system.
+func SetSize(f *os.File, cols, rows int) error { return errors.ErrUnsupported }
+
+func setControllingTerminal(cmd *exec.Cmd) {}
@@ -0,0 +1,133 @@
+// Package sgr tracks the graphic rendition SGR escape sequences set, for
+// code that needs to know which styles a string leaves open.
+package sgr
+
+import (
+	"strconv"
+	"strings"
+)
+
+// State is the graphic rendition in effect: the zero State is the
+// terminal default.
+type State struct {
+	attrs      uint16   // bit n set for attribute n, 1 (bold) to 9 (strike)
+	fg, bg, ul string   // color parameters, such as "31" or "38;5;208"; "" is the default
+	extra      []string // other parameters, such as overline, kept as given
+}
+
+// Apply updates the state with the parameters of one SGR sequence.
+func (st *State) Apply(params string) {
+	if params != "" && (params[0] < '0' || params[0] > '9') && params[0] != ';' {
+		return // private sequences such as ESC[>4;2m are not SGR
+	}
+	p := strings.Split(params, ";")
+	for i := 0; i < len(p); i++ {
+		tok := p[i]
+		if k, sub, ok := strings.Cut(tok, ":"); ok {
+			// Colon forms: 38:2::r:g:b, 4:3 (curly underline).
+			switch k {
+			case "38":
+				st.fg = tok
+			case "48":
+				st.bg = tok
+			case "58":
+				st.ul = tok
+			case "4":
+				st.set(4, sub != "0")
+			default:
+				st.extra = append(st.extra, tok)
+			}
+			continue
+		}
+		n, err := strconv.Atoi(tok)
+		if tok == "" {
+			n, err = 0, nil
+		}
+		if err != nil {
+			continue
+		}
+		switch {
+		case n == 0:
+			*st = State{}
+		case n >= 1 && n <= 9:
+			st.set(n, true)
+		case n == 21:
+			st.set(4, true) // double underline
+		case n == 22:
+			st.set(1, false)
+			st.set(2, false)
+		case n >= 23 && n <= 29 && n != 26:
+			st.set(n-20, false)
+			if n == 25 {
+				st.set(6, false)
+			}
+		case n >= 30 && n <= 37, n >= 90 && n <= 97:
+			st.fg = tok
+		case n == 39:
+			st.fg = ""
+		case n >= 40 && n <= 47, n >= 100 && n <= 107:
+			st.bg = tok
+		case n == 49:
+			st.bg = ""
+		case n == 59:
+			st.ul = ""
+		case n == 38 || n == 48 || n == 58:
+			end := i + 1
+			if end < len(p) {
+				switch p[end] {
+				case "5":
+					end += 2
+				case "2":
+					end += 4
+				}
+			}
+			end = min(end, len(p))
+			color := strings.Join(p[i:end], ";")
+			switch n {
+			case 38:
+				st.fg = color
+			case 48:
+				st.bg = color
+			default:
+				st.ul = color
+			}
+			i = end - 1
+		default:
+			st.extra = append(st.extra, tok)
+		}
+	}
+}
+
+func (st *State) set(attr int, on bool) {
+	if on {
+		st.attrs |= 1 << attr
+	} else {
+		st.attrs &^= 1 << attr
+	}
+}
+
+// IsDefault reports whether no styles are in effect.
+func (st State) IsDefault() bool {
+	return st.attrs == 0 && st.fg == "" && st.bg == "" && st.ul == "" && len(st.extra) == 0
+}
+
+// String returns the SGR sequence that sets the state from the default, or
+// "" when the state is the default.
+func (st State) String() string {
+	var p []string
+	for n := 1; n <= 9; n++ {
+		if st.attrs&(1<<n) != 0 {
+			p = append(p, strconv.Itoa(n))
+		}
+	}
+	for _, c := range []string{st.fg, st.bg, st.ul} {
+		if c != "" {
+			p = append(p, c)
+		}
+	}
+	p = append(p, st.extra...)
+	if len(p) == 0 {
+		return ""
+	}
+	return "\x1b[" + strings.Join(p, ";") + "m"
+}
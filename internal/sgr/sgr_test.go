@@ -0,0 +1,46 @@
+package sgr
+
+import "testing"
+
+func TestApply(t *testing.T) {
+	tests := []struct {
+		name   string
+		params []string
+		want   string
+	}{
+		{"empty is reset", []string{"1", ""}, ""},
+		{"reset", []string{"1;31", "0"}, ""},
+		{"trailing reset", []string{"1;0"}, ""},
+		{"reset then style", []string{"0;1"}, "\x1b[1m"},
+		{"attributes", []string{"1", "3;4"}, "\x1b[1;3;4m"},
+		{"attribute off", []string{"1;2", "22"}, ""},
+		{"blink off", []string{"5", "25"}, ""},
+		{"basic colors", []string{"31;42"}, "\x1b[31;42m"},
+		{"default colors", []string{"31;42", "39;49"}, ""},
+		{"256 black is not a reset", []string{"38;5;0"}, "\x1b[38;5;0m"},
+		{"256 background black", []string{"48;5;0"}, "\x1b[48;5;0m"},
+		{"truecolor black", []string{"38;2;0;0;0"}, "\x1b[38;2;0;0;0m"},
+		{"color then attribute", []string{"38;5;208;1"}, "\x1b[1;38;5;208m"},
+		{"truncated color", []string{"38;5"}, "\x1b[38;5m"},
+		{"colon color", []string{"38:2::1:2:3"}, "\x1b[38:2::1:2:3m"},
+		{"curly underline", []string{"4:3"}, "\x1b[4m"},
+		{"underline off", []string{"4:3", "4:0"}, ""},
+		{"underline color", []string{"58;5;1", "59"}, ""},
+		{"other parameters kept", []string{"53"}, "\x1b[53m"},
+		{"private sequence ignored", []string{">4;2"}, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var st State
+			for _, p := range tt.params {
+				st.Apply(p)
+			}
+			if got := st.String(); got != tt.want {
+				t.Errorf("String() = %q, want %q", got, tt.want)
+			}
+			if st.IsDefault() != (tt.want == "") {
+				t.Errorf("IsDefault() = %v, want %v", st.IsDefault(), tt.want == "")
+			}
+		})
+	}
+}
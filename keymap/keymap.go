@@ -0,0 +1,254 @@
+// Package keymap lets end users rebind the keys of a frog application. The
+// program declares its actions with default keys; a configuration file in
+// TOML or YAML can bind each action to other keys, written as frog.ParseKey
+// names:
+//
+//	# ~/.config/notes/keys.toml
+//	save = "ctrl+w"
+//	quit = ["q", "ctrl+q"]
+//
+// The resulting Keymap resolves key presses to actions, reports keys bound
+// to more than one action, and describes itself for the help overlay.
+package keymap
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/pondworks-lib/frog"
+)
+
+// Action is something the user can bind keys to.
+type Action struct {
+	Name string   // as written in the configuration file, e.g. "save"
+	Help string   // shown in the help overlay
+	Keys []string // the default keys, as frog.ParseKey names
+}
+
+// Format is a configuration file syntax.
+type Format int
+
+const (
+	TOML Format = iota
+	YAML
+)
+
+// Conflict is a key bound to more than one action. The first action listed
+// gets the key: one the configuration bound it to, or else the action
+// declared first.
+type Conflict struct {
+	Key     string // canonical name
+	Actions []string
+}
+
+func (c Conflict) String() string {
+	return fmt.Sprintf("%s is bound to %s", c.Key, strings.Join(c.Actions, ", "))
+}
+
+// Keymap is the merged result of the defaults and a configuration. It is
+// not modified after creation and may be shared.
+type Keymap struct {
+	actions   []Action          // declaration order, with the effective keys
+	byKey     map[string]string // canonical key to the action that gets it
+	conflicts []Conflict
+}
+
+// New returns the keymap of the actions' default keys. It fails if an
+// action has no name, two share one, or a key does not parse.
+func New(actions ...Action) (*Keymap, error) {
+	return merge(actions, nil)
+}
+
+// Parse merges the bindings in data with the actions' defaults. Each
+// action the configuration names gets exactly the keys listed there, so an
+// empty list unbinds it; others keep their defaults. Unknown action names
+// and keys that do not parse are errors.
+func Parse(data []byte, format Format, actions ...Action) (*Keymap, error) {
+	var (
+		user map[string][]string
+		err  error
+	)
+	switch format {
+	case TOML:
+		user, err = parseTOML(string(data))
+	case YAML:
+		user, err = parseYAML(string(data))
+	default:
+		return nil, fmt.Errorf("keymap: unknown format %d", format)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return merge(actions, user)
+}
+
+// Load reads the configuration file at path, choosing the format by its
+// extension (.toml, .yaml or .yml), and merges it like Parse. A missing
+// file is not an error: the keymap has the defaults.
+func Load(path string, actions ...Action) (*Keymap, error) {
+	var format Format
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".toml":
+		format = TOML
+	case ".yaml", ".yml":
+		format = YAML
+	default:
+		return nil, fmt.Errorf("keymap: %s: unknown file type, want .toml, .yaml or .yml", path)
+	}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return New(actions...)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("keymap: %w", err)
+	}
+	k, err := Parse(data, format, actions...)
+	if err != nil {
+		return nil, fmt.Errorf("%w (in %s)", err, path)
+	}
+	return k, nil
+}
+
+// ConfigPath returns the usual place of app's key configuration:
+// keys.toml in app's directory under the user configuration directory,
+// such as ~/.config/<app>/keys.toml.
+func ConfigPath(app string) (string, error) {
+	if app == "" {
+		return "", errors.New("empty app name")
+	}
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, app, "keys.toml"), nil
+}
+
+func merge(actions []Action, user map[string][]string) (*Keymap, error) {
+	k := &Keymap{actions: make([]Action, len(actions)), byKey: map[string]string{}}
+	index := map[string]int{}
+	for i, a := range actions {
+		if a.Name == "" {
+			return nil, fmt.Errorf("keymap: action %d has no name", i)
+		}
+		if _, dup := index[a.Name]; dup {
+			return nil, fmt.Errorf("keymap: duplicate action %q", a.Name)
+		}
+		index[a.Name] = i
+		keys, err := canonical(a.Keys)
+		if err != nil {
+			return nil, fmt.Errorf("keymap: default keys of %q: %w", a.Name, err)
+		}
+		a.Keys = keys
+		k.actions[i] = a
+	}
+	for name, keys := range user {
+		i, ok := index[name]
+		if !ok {
+			return nil, fmt.Errorf("keymap: unknown action %q", name)
+		}
+		keys, err := canonical(keys)
+		if err != nil {
+			return nil, fmt.Errorf("keymap: %s: %w", name, err)
+		}
+		k.actions[i].Keys = keys
+	}
+
+	// Configured actions claim their keys first, then the rest in order.
+	claimants := map[string][]string{}
+	var order []string
+	claim := func(a Action) {
+		for _, key := range a.Keys {
+			if len(claimants[key]) == 0 {
+				order = append(order, key)
+				k.byKey[key] = a.Name
+			}
+			claimants[key] = append(claimants[key], a.Name)
+		}
+	}
+	for _, a := range k.actions {
+		if _, ok := user[a.Name]; ok {
+			claim(a)
+		}
+	}
+	for _, a := range k.actions {
+		if _, ok := user[a.Name]; !ok {
+			claim(a)
+		}
+	}
+	for _, key := range order {
+		if names := claimants[key]; len(names) > 1 {
+			k.conflicts = append(k.conflicts, Conflict{Key: key, Actions: names})
+		}
+	}
+	return k, nil
+}
+
+// canonical parses keys and returns their canonical names, dropping
+// duplicates.
+func canonical(keys []string) ([]string, error) {
+	out := make([]string, 0, len(keys))
+	for _, s := range keys {
+		msg, err := frog.ParseKey(s)
+		if err != nil {
+			return nil, err
+		}
+		name := msg.Canonical()
+		dup := false
+		for _, o := range out {
+			dup = dup || o == name
+		}
+		if !dup {
+			out = append(out, name)
+		}
+	}
+	return out, nil
+}
+
+// Action returns the name of the action msg is bound to, or "".
+func (k *Keymap) Action(msg frog.KeyMsg) string {
+	return k.byKey[msg.Canonical()]
+}
+
+// Is reports whether msg triggers action.
+func (k *Keymap) Is(msg frog.KeyMsg, action string) bool {
+	name := k.Action(msg)
+	return name != "" && name == action
+}
+
+// Keys returns the canonical names of the keys bound to action.
+func (k *Keymap) Keys(action string) []string {
+	for _, a := range k.actions {
+		if a.Name == action {
+			return append([]string(nil), a.Keys...)
+		}
+	}
+	return nil
+}
+
+// Conflicts lists the keys bound to more than one action, for the program
+// to warn about.
+func (k *Keymap) Conflicts() []Conflict {
+	return append([]Conflict(nil), k.conflicts...)
+}
+
+// Bindings describes the keymap for a help overlay, one entry per action
+// with keys, listing the keys the action actually gets.
+func (k *Keymap) Bindings() []frog.Binding {
+	var b []frog.Binding
+	for _, a := range k.actions {
+		var keys []string
+		for _, key := range a.Keys {
+			if k.byKey[key] == a.Name {
+				keys = append(keys, key)
+			}
+		}
+		if len(keys) > 0 {
+			b = append(b, frog.Binding{Keys: strings.Join(keys, "/"), Help: a.Help})
+		}
+	}
+	return b
+}
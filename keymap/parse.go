@@ -0,0 +1,228 @@
+package keymap
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// The parsers read the part of TOML and YAML a key configuration needs:
+// one binding per line, action names mapped to a key or a list of keys.
+// A bracketed list may continue over the following lines until its "]".
+// When the file has a "keys" table only that is read, so the bindings can
+// live in a larger configuration file; otherwise the top level is.
+
+// parseTOML reads lines such as `save = "ctrl+s"` and
+// `quit = ["q", "ctrl+q"]`, with # comments and [table] headers.
+func parseTOML(src string) (map[string][]string, error) {
+	out := map[string][]string{}
+	lines := strings.Split(src, "\n")
+	inKeys := true
+	for _, line := range lines {
+		if isTOMLKeysTable(strings.TrimSpace(stripComment(line))) {
+			inKeys = false
+		}
+	}
+	for n := 0; n < len(lines); n++ {
+		line := strings.TrimSpace(stripComment(lines[n]))
+		switch {
+		case line == "":
+			continue
+		case strings.HasPrefix(line, "["):
+			inKeys = isTOMLKeysTable(line)
+			continue
+		case !inKeys:
+			continue
+		}
+		name, value, ok := strings.Cut(line, "=")
+		if !ok {
+			return nil, fmt.Errorf("keymap: line %d: want action = keys", n+1)
+		}
+		start := n
+		value, n = continueList(strings.TrimSpace(value), lines, n)
+		keys, err := parseValue(value)
+		if err != nil {
+			return nil, fmt.Errorf("keymap: line %d: %w", start+1, err)
+		}
+		out[unquote(strings.TrimSpace(name))] = keys
+	}
+	return out, nil
+}
+
+func isTOMLKeysTable(line string) bool {
+	if !strings.HasPrefix(line, "[") || !strings.HasSuffix(line, "]") {
+		return false
+	}
+	return unquote(strings.TrimSpace(line[1:len(line)-1])) == "keys"
+}
+
+// parseYAML reads `save: ctrl+s`, `quit: [q, ctrl+q]` and block lists:
+//
+//	quit:
+//	  - q
+//	  - ctrl+q
+//
+// Bindings are either top-level or nested under "keys:".
+func parseYAML(src string) (map[string][]string, error) {
+	out := map[string][]string{}
+	var (
+		list       string // the action whose block list is being read
+		listIndent = -1
+		skipIndent = -1 // skip lines deeper than this: an ignored mapping
+	)
+	lines := strings.Split(src, "\n")
+	nested := false // bindings are under "keys:"
+	for _, l := range lines {
+		if strings.TrimRight(stripComment(l), " \t\r") == "keys:" {
+			nested = true
+		}
+	}
+	for n := 0; n < len(lines); n++ {
+		line := strings.TrimRight(stripComment(lines[n]), " \t\r")
+		body := strings.TrimLeft(line, " ")
+		if body == "" || body == "---" {
+			continue
+		}
+		indent := len(line) - len(body)
+		if skipIndent >= 0 {
+			if indent > skipIndent {
+				continue
+			}
+			skipIndent = -1
+		}
+		if item, ok := strings.CutPrefix(body, "-"); ok && list != "" && indent >= listIndent {
+			out[list] = append(out[list], unquote(strings.TrimSpace(item)))
+			continue
+		}
+		list = ""
+		name, value, ok := strings.Cut(body, ":")
+		if !ok {
+			return nil, fmt.Errorf("keymap: line %d: want action: keys", n+1)
+		}
+		name, value = unquote(strings.TrimSpace(name)), strings.TrimSpace(value)
+		if nested && indent == 0 {
+			if name != "keys" {
+				skipIndent = 0 // another part of the configuration
+			}
+			continue
+		}
+		if value != "" {
+			start := n
+			value, n = continueList(value, lines, n)
+			keys, err := parseValue(value)
+			if err != nil {
+				return nil, fmt.Errorf("keymap: line %d: %w", start+1, err)
+			}
+			out[name] = keys
+			continue
+		}
+		// An empty value opens a block list, or a mapping when the next
+		// line is not a list item.
+		if next := nextLine(lines[n+1:]); strings.HasPrefix(next, "-") {
+			list, listIndent = name, indent
+			out[name] = []string{}
+		} else {
+			skipIndent = indent
+		}
+	}
+	return out, nil
+}
+
+// nextLine returns the next non-blank line without its indentation.
+func nextLine(lines []string) string {
+	for _, l := range lines {
+		if l = strings.TrimSpace(stripComment(l)); l != "" {
+			return l
+		}
+	}
+	return ""
+}
+
+// continueList appends to value, a list opened on line n, the lines up to
+// the one that closes it, and returns the list and that line's index.
+func continueList(value string, lines []string, n int) (string, int) {
+	for strings.HasPrefix(value, "[") && !strings.HasSuffix(value, "]") && n+1 < len(lines) {
+		n++
+		value += " " + strings.TrimSpace(stripComment(lines[n]))
+	}
+	return value, n
+}
+
+// parseValue reads a key name or a list of them in brackets.
+func parseValue(s string) ([]string, error) {
+	if !strings.HasPrefix(s, "[") {
+		return []string{unquote(s)}, nil
+	}
+	if !strings.HasSuffix(s, "]") {
+		return nil, fmt.Errorf("unterminated list %s", s)
+	}
+	keys := []string{}
+	for _, item := range splitList(s[1 : len(s)-1]) {
+		if item = strings.TrimSpace(item); item != "" {
+			keys = append(keys, unquote(item))
+		}
+	}
+	return keys, nil
+}
+
+// splitList splits at commas outside quotes.
+func splitList(s string) []string {
+	var (
+		items []string
+		quote byte
+		start int
+	)
+	for i := 0; i < len(s); i++ {
+		switch c := s[i]; {
+		case quote != 0:
+			if c == '\\' && quote == '"' {
+				i++
+			} else if c == quote {
+				quote = 0
+			}
+		case c == '"' || c == '\'':
+			quote = c
+		case c == ',':
+			items = append(items, s[start:i])
+			start = i + 1
+		}
+	}
+	return append(items, s[start:])
+}
+
+// stripComment cuts a # comment that starts outside quotes, at the start of
+// the line or after a space, so a bare "#" can still be quoted.
+func stripComment(line string) string {
+	var quote byte
+	for i := 0; i < len(line); i++ {
+		switch c := line[i]; {
+		case quote != 0:
+			if c == '\\' && quote == '"' {
+				i++
+			} else if c == quote {
+				quote = 0
+			}
+		case c == '"' || c == '\'':
+			quote = c
+		case c == '#' && (i == 0 || line[i-1] == ' ' || line[i-1] == '\t'):
+			return line[:i]
+		}
+	}
+	return line
+}
+
+// unquote removes double or single quotes; double-quoted strings take Go
+// escapes, which match TOML's and YAML's for key names.
+func unquote(s string) string {
+	if len(s) >= 2 {
+		switch {
+		case s[0] == '"' && s[len(s)-1] == '"':
+			if u, err := strconv.Unquote(s); err == nil {
+				return u
+			}
+		case s[0] == '\'' && s[len(s)-1] == '\'':
+			return s[1 : len(s)-1]
+		}
+	}
+	return s
+}
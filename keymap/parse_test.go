@@ -0,0 +1,104 @@
+package keymap
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseTOML(t *testing.T) {
+	tests := []struct {
+		name, src string
+		want      map[string][]string
+	}{
+		{"single and list", `
+save = "ctrl+s"   # comment
+quit = ["q", 'ctrl+q']
+"go top" = g
+`, map[string][]string{"save": {"ctrl+s"}, "quit": {"q", "ctrl+q"}, "go top": {"g"}}},
+		{"multi-line list", `
+quit = [
+  "q",      # plain
+  "ctrl+q", # trailing comma
+]
+save = "ctrl+s"
+`, map[string][]string{"quit": {"q", "ctrl+q"}, "save": {"ctrl+s"}}},
+		{"list continued after first item", "quit = [\"q\",\n  \"esc\"]", map[string][]string{"quit": {"q", "esc"}}},
+		{"empty list", "quit = []", map[string][]string{"quit": {}}},
+		{"quoted hash and comma", `mark = ["#", ","]`, map[string][]string{"mark": {"#", ","}}},
+		{"keys table", `
+title = "notes"
+[keys]
+save = "ctrl+w"
+[theme]
+save = "ignored"
+`, map[string][]string{"save": {"ctrl+w"}}},
+		{"other tables only", `
+quit = "q"
+[theme]
+accent = "blue"
+`, map[string][]string{"quit": {"q"}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseTOML(tt.src)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseTOMLErrors(t *testing.T) {
+	tests := []struct{ src, want string }{
+		{"save", "line 1: want action = keys"},
+		{"save = \"s\"\nquit = [\"q\",\n  \"esc\"", "line 2: unterminated list"},
+	}
+	for _, tt := range tests {
+		if _, err := parseTOML(tt.src); err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("parseTOML(%q) = %v, want %q", tt.src, err, tt.want)
+		}
+	}
+}
+
+func TestParseYAML(t *testing.T) {
+	tests := []struct {
+		name, src string
+		want      map[string][]string
+	}{
+		{"flow", `
+save: ctrl+s
+quit: [q, "ctrl+q"]
+`, map[string][]string{"save": {"ctrl+s"}, "quit": {"q", "ctrl+q"}}},
+		{"block list", `
+quit:
+  - q   # comment
+  - 'ctrl+q'
+save: ctrl+s
+`, map[string][]string{"quit": {"q", "ctrl+q"}, "save": {"ctrl+s"}}},
+		{"multi-line flow", "quit: [q,\n  esc]\nsave: s", map[string][]string{"quit": {"q", "esc"}, "save": {"s"}}},
+		{"nested under keys", `
+---
+theme:
+  accent: blue
+keys:
+  save: ctrl+w
+  quit:
+    - q
+`, map[string][]string{"save": {"ctrl+w"}, "quit": {"q"}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseYAML(tt.src)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
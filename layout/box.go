@@ -0,0 +1,147 @@
+// Package layout builds common pieces of views on frog's layout helpers,
+// measuring text by display width so styled and wide text line up.
+package layout
+
+import (
+	"strings"
+
+	"github.com/pondworks-lib/frog"
+)
+
+// Border is the set of strings a box is drawn with; each should be one
+// column wide.
+type Border struct {
+	Top, Bottom, Left, Right                   string
+	TopLeft, TopRight, BottomLeft, BottomRight string
+}
+
+// Border styles.
+var (
+	RoundedBorder = Border{"─", "─", "│", "│", "╭", "╮", "╰", "╯"}
+	NormalBorder  = Border{"─", "─", "│", "│", "┌", "┐", "└", "┘"}
+	ThickBorder   = Border{"━", "━", "┃", "┃", "┏", "┓", "┗", "┛"}
+	DoubleBorder  = Border{"═", "═", "║", "║", "╔", "╗", "╚", "╝"}
+	ASCIIBorder   = Border{"-", "-", "|", "|", "+", "+", "+", "+"}
+)
+
+// Padding is the space between a box's border and its content, in cells.
+type Padding struct {
+	Top, Right, Bottom, Left int
+}
+
+// Pad returns the same padding on every side.
+func Pad(n int) Padding { return Padding{n, n, n, n} }
+
+// PadXY returns x columns of padding left and right and y rows above and
+// below.
+func PadXY(x, y int) Padding { return Padding{y, x, y, x} }
+
+// BoxOptions describes a box for Box. The zero value draws a rounded
+// border tightly around the content.
+type BoxOptions struct {
+	Title       string
+	TitleAlign  frog.AlignH // where the title sits in the top border
+	TitleStyle  frog.Style
+	Border      Border // RoundedBorder when zero
+	BorderStyle frog.Style
+	Padding     Padding
+	// Background is drawn over the inside of the box, padding included,
+	// such as a dark panel color; the content's own styles are kept.
+	Background frog.Style
+
+	// Width and Height are the outer size, border included; 0 fits the
+	// content (and the title). Content that does not fit is handled as
+	// Overflow says, clipped by default, and cut at the bottom.
+	Width, Height int
+	Overflow      frog.Overflow
+	Align         frog.AlignH // of the content lines within the box
+}
+
+// Box draws content in a framed panel:
+//
+//	╭─ Disk ───────╮
+//	│ /     42%    │
+//	│ /home 87%    │
+//	╰──────────────╯
+//
+// A title too long for the top border is cut with an ellipsis.
+func Box(content string, o BoxOptions) string {
+	b := o.Border
+	if b == (Border{}) {
+		b = RoundedBorder
+	}
+	p := o.Padding
+	lines := strings.Split(content, "\n")
+
+	cw := 0 // content width and height
+	for _, l := range lines {
+		cw = max(cw, frog.DisplayWidth(l))
+	}
+	ch := len(lines)
+	if o.Width > 0 {
+		cw = o.Width - 2 - p.Left - p.Right
+	} else if tw := frog.DisplayWidth(o.Title); tw > 0 {
+		cw = max(cw, tw+4-p.Left-p.Right) // a border column and a space each side
+	}
+	if o.Height > 0 {
+		ch = o.Height - 2 - p.Top - p.Bottom
+	}
+	cw, ch = max(cw, 0), max(ch, 0)
+	innerW := cw + p.Left + p.Right
+
+	overflow := o.Overflow
+	if overflow == frog.OverflowNone {
+		overflow = frog.OverflowClip // a box cannot grow
+	}
+	body := make([]string, ch)
+	if cw > 0 && ch > 0 {
+		placed := frog.PlaceBlock(content, cw, ch, o.Align, frog.AlignTop,
+			frog.WithOverflow(overflow), frog.WithBoxFill())
+		copy(body, strings.Split(frog.Fill(placed, cw, ch, o.Background), "\n"))
+	}
+
+	side := func(s string) string { return o.BorderStyle.Render(s) }
+	var out strings.Builder
+	out.WriteString(topBorder(b, o, innerW))
+	spaces := func(n int) string { return o.Background.Render(strings.Repeat(" ", n)) }
+	blank := side(b.Left) + spaces(innerW) + side(b.Right)
+	for range p.Top {
+		out.WriteString("\n" + blank)
+	}
+	left, right := spaces(p.Left), spaces(p.Right)
+	for _, l := range body {
+		if l == "" {
+			l = spaces(cw)
+		}
+		out.WriteString("\n" + side(b.Left) + left + l + right + side(b.Right))
+	}
+	for range p.Bottom {
+		out.WriteString("\n" + blank)
+	}
+	out.WriteString("\n" + side(b.BottomLeft+strings.Repeat(b.Bottom, innerW)+b.BottomRight))
+	return out.String()
+}
+
+// topBorder draws the top border innerW columns wide between the corners,
+// with the title set into it.
+func topBorder(b Border, o BoxOptions, innerW int) string {
+	title := o.Title
+	room := innerW - 4 // a border column and a space on each side
+	if title == "" || room < 1 {
+		return o.BorderStyle.Render(b.TopLeft + strings.Repeat(b.Top, innerW) + b.TopRight)
+	}
+	if frog.DisplayWidth(title) > room {
+		title = frog.Truncate(title, room-1) + "…"
+	}
+	rest := innerW - frog.DisplayWidth(title) - 2
+	before := 1
+	switch o.TitleAlign {
+	case frog.AlignCenter:
+		before = rest / 2
+	case frog.AlignRight:
+		before = rest - 1
+	}
+	return o.BorderStyle.Render(b.TopLeft+strings.Repeat(b.Top, before)+" ") +
+		o.TitleStyle.Render(title) +
+		o.BorderStyle.Render(" "+strings.Repeat(b.Top, rest-before)+b.TopRight)
+}
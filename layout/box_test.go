@@ -0,0 +1,36 @@
+package layout
+
+import (
+	"testing"
+
+	"github.com/pondworks-lib/frog"
+)
+
+func TestBox(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		o       BoxOptions
+		want    string
+	}{
+		{"tight", "hi", BoxOptions{}, "╭──╮\n│hi│\n╰──╯"},
+		{"ragged lines", "a\nbcd", BoxOptions{Border: ASCIIBorder}, "+---+\n|a  |\n|bcd|\n+---+"},
+		{"padding", "x", BoxOptions{Padding: PadXY(1, 1)}, "╭───╮\n│   │\n│ x │\n│   │\n╰───╯"},
+		{"title", "hi", BoxOptions{Title: "T"}, "╭─ T ─╮\n│hi   │\n╰─────╯"},
+		{
+			"title right", "hi", BoxOptions{Title: "T", TitleAlign: frog.AlignRight, Width: 9},
+			"╭─── T ─╮\n│hi     │\n╰───────╯",
+		},
+		{"title cut", "hi", BoxOptions{Title: "Title", Width: 8}, "╭─ T… ─╮\n│hi    │\n╰──────╯"},
+		{"fixed size clips", "abcdef\ng\nh", BoxOptions{Width: 5, Height: 4}, "╭───╮\n│abc│\n│g  │\n╰───╯"},
+		{"fixed size pads", "a", BoxOptions{Width: 4, Height: 4}, "╭──╮\n│a │\n│  │\n╰──╯"},
+		{"align center", "a\nbcd", BoxOptions{Align: frog.AlignCenter}, "╭───╮\n│ a │\n│bcd│\n╰───╯"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Box(tt.content, tt.o); got != tt.want {
+				t.Errorf("Box =\n%s\nwant\n%s", got, tt.want)
+			}
+		})
+	}
+}
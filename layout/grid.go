@@ -0,0 +1,128 @@
+package layout
+
+import (
+	"strings"
+
+	"github.com/pondworks-lib/frog"
+)
+
+// GridOptions describes a grid for Grid.
+type GridOptions struct {
+	// Gap is the number of spaces between columns, or on each side of
+	// Separator when one is set.
+	Gap int
+	// Separator is drawn between columns, such as "│".
+	Separator string
+	// HeaderRule, such as "─", is repeated across the grid under the first
+	// row.
+	HeaderRule     string
+	SeparatorStyle frog.Style // for Separator and HeaderRule
+
+	// Align sets each column's alignment; columns past its end align left.
+	Align []frog.AlignH
+	// MaxWidths caps each column's width, cutting wider cells with an
+	// ellipsis; 0 or a missing entry leaves the column as wide as its
+	// widest cell.
+	MaxWidths []int
+}
+
+// Grid lays out rows of cells in columns as wide as their widest cell, for
+// static views that do not need the table component's scrolling and
+// selection:
+//
+//	layout.Grid([][]string{
+//		{"NAME", "CPU", "MEM"},
+//		{"frogd", "3.1%", "120M"},
+//	}, layout.GridOptions{Gap: 2, HeaderRule: "─", Align: []frog.AlignH{frog.AlignLeft, frog.AlignRight, frog.AlignRight}})
+//
+// Widths ignore escape sequences, so cells can be styled. A cell holding
+// several lines makes its row that tall. Rows may have different numbers
+// of cells; missing cells are blank.
+func Grid(cells [][]string, o GridOptions) string {
+	cols := 0
+	for _, row := range cells {
+		cols = max(cols, len(row))
+	}
+	if cols == 0 {
+		return ""
+	}
+
+	// Split cells into lines, cut them to the column caps, and measure.
+	split := make([][][]string, len(cells))
+	widths := make([]int, cols)
+	for r, row := range cells {
+		split[r] = make([][]string, cols)
+		for c := range cols {
+			if c >= len(row) {
+				continue
+			}
+			lines := strings.Split(row[c], "\n")
+			for i, l := range lines {
+				if c < len(o.MaxWidths) && o.MaxWidths[c] > 0 && frog.DisplayWidth(l) > o.MaxWidths[c] {
+					l = frog.Truncate(l, o.MaxWidths[c]-1) + "…"
+					lines[i] = l
+				}
+				widths[c] = max(widths[c], frog.DisplayWidth(l))
+			}
+			split[r][c] = lines
+		}
+	}
+
+	gap := strings.Repeat(" ", max(o.Gap, 0))
+	between := gap
+	if o.Separator != "" {
+		between = gap + o.SeparatorStyle.Render(o.Separator) + gap
+	}
+	total := (cols - 1) * frog.DisplayWidth(between)
+	for _, w := range widths {
+		total += w
+	}
+
+	var out []string
+	for r, row := range split {
+		height := 1
+		for _, lines := range row {
+			height = max(height, len(lines))
+		}
+		for i := range height {
+			var b strings.Builder
+			for c, lines := range row {
+				if c > 0 {
+					b.WriteString(between)
+				}
+				cell := ""
+				if i < len(lines) {
+					cell = lines[i]
+				}
+				b.WriteString(align(cell, widths[c], alignOf(o.Align, c)))
+			}
+			out = append(out, strings.TrimRight(b.String(), " "))
+		}
+		if r == 0 && o.HeaderRule != "" {
+			out = append(out, o.SeparatorStyle.Render(strings.Repeat(o.HeaderRule, total)))
+		}
+	}
+	return strings.Join(out, "\n")
+}
+
+func alignOf(aligns []frog.AlignH, c int) frog.AlignH {
+	if c < len(aligns) {
+		return aligns[c]
+	}
+	return frog.AlignLeft
+}
+
+// align pads s to w columns.
+func align(s string, w int, a frog.AlignH) string {
+	pad := w - frog.DisplayWidth(s)
+	if pad <= 0 {
+		return s
+	}
+	switch a {
+	case frog.AlignRight:
+		return strings.Repeat(" ", pad) + s
+	case frog.AlignCenter:
+		return strings.Repeat(" ", pad/2) + s + strings.Repeat(" ", pad-pad/2)
+	}
+	return s + strings.Repeat(" ", pad)
+}
@@ -0,0 +1,37 @@
+package layout
+
+import (
+	"testing"
+
+	"github.com/pondworks-lib/frog"
+)
+
+func TestGrid(t *testing.T) {
+	tests := []struct {
+		name  string
+		cells [][]string
+		o     GridOptions
+		want  string
+	}{
+		{"empty", nil, GridOptions{}, ""},
+		{"columns", [][]string{{"a", "bb"}, {"ccc", "d"}}, GridOptions{Gap: 1}, "a   bb\nccc d"},
+		{
+			"align", [][]string{{"name", "n"}, {"x", "10"}},
+			GridOptions{Gap: 1, Align: []frog.AlignH{frog.AlignLeft, frog.AlignRight}},
+			"name  n\nx    10",
+		},
+		{"separator", [][]string{{"a", "b"}, {"cc", "d"}}, GridOptions{Gap: 1, Separator: "│"}, "a  │ b\ncc │ d"},
+		{"header rule", [][]string{{"a", "b"}, {"c", "d"}}, GridOptions{Gap: 1, HeaderRule: "─"}, "a b\n───\nc d"},
+		{"max width", [][]string{{"abcdef", "x"}}, GridOptions{Gap: 1, MaxWidths: []int{4}}, "abc… x"},
+		{"ragged rows", [][]string{{"a", "b", "c"}, {"d"}}, GridOptions{Gap: 1}, "a b c\nd"},
+		{"multi-line cell", [][]string{{"a\nb", "c"}}, GridOptions{Gap: 1}, "a c\nb"},
+		{"wide cells", [][]string{{"日本", "x"}, {"a", "y"}}, GridOptions{Gap: 1}, "日本 x\na    y"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Grid(tt.cells, tt.o); got != tt.want {
+				t.Errorf("Grid =\n%q\nwant\n%q", got, tt.want)
+			}
+		})
+	}
+}
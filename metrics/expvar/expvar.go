@@ -0,0 +1,42 @@
+// Package expvar publishes frog session metrics with the standard library's
+// expvar package, served at /debug/vars by net/http. Importing it registers
+// the /debug/vars handler on http.DefaultServeMux, which is why it is not
+// part of frog itself.
+//
+//	app := frog.NewApp(m, frog.WithMetrics(expvar.New("frog")))
+package expvar
+
+import (
+	"expvar"
+	"time"
+
+	"github.com/pondworks-lib/frog"
+)
+
+// metrics publishes counters as an expvar.Map.
+type metrics struct {
+	m *expvar.Map
+}
+
+// New returns Metrics published under name. Reusing a name shares the
+// existing map.
+func New(name string) frog.Metrics {
+	if v, ok := expvar.Get(name).(*expvar.Map); ok {
+		return metrics{m: v}
+	}
+	return metrics{m: expvar.NewMap(name)}
+}
+
+func (e metrics) MsgProcessed() { e.m.Add("msgs_processed", 1) }
+func (e metrics) MsgDropped()   { e.m.Add("msgs_dropped", 1) }
+
+func (e metrics) Rendered(bytes int64, d time.Duration) {
+	e.m.Add("renders", 1)
+	e.m.Add("bytes_written", bytes)
+	e.m.Add("render_ns_total", int64(d))
+}
+
+func (e metrics) CmdFinished(d time.Duration) {
+	e.m.Add("cmds", 1)
+	e.m.Add("cmd_ns_total", int64(d))
+}
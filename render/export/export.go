@@ -0,0 +1,230 @@
+// Package export converts rendered views, strings carrying frog's SGR
+// escape sequences, to plain text, HTML and SVG, for "save screenshot"
+// features and for embedding program output in documentation.
+package export
+
+import (
+	"fmt"
+	"html"
+	"math"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/pondworks-lib/frog"
+)
+
+type config struct {
+	fg, bg     frog.Color
+	fontFamily string
+	fontSize   int
+}
+
+// Option configures ToHTML and ToSVG.
+type Option func(*config)
+
+// WithColors sets the default foreground and background, used for text
+// without colors of its own and for reversed text. The defaults are
+// frog.ColorWhite on frog.ColorBlack.
+func WithColors(fg, bg frog.Color) Option {
+	return func(c *config) { c.fg, c.bg = fg, bg }
+}
+
+// WithFont sets the font family and size in pixels. The defaults are
+// "monospace" and 14. SVG output lays text out on a grid of cells 0.6 by
+// 1.2 times the size, which suits most monospace fonts.
+func WithFont(family string, size int) Option {
+	return func(c *config) { c.fontFamily, c.fontSize = family, size }
+}
+
+func newConfig(opts []Option) config {
+	c := config{fg: frog.ColorWhite, bg: frog.ColorBlack, fontFamily: "monospace", fontSize: 14}
+	for _, o := range opts {
+		o(&c)
+	}
+	return c
+}
+
+// ToPlainText returns view without escape sequences or trailing spaces.
+func ToPlainText(view string) string {
+	lines := parse(view)
+	out := make([]string, len(lines))
+	for i, l := range lines {
+		var b strings.Builder
+		for _, r := range l {
+			b.WriteString(r.text)
+		}
+		out[i] = strings.TrimRight(b.String(), " ")
+	}
+	return strings.Join(out, "\n")
+}
+
+// ToHTML returns view as a <pre> element whose styled text is wrapped in
+// spans with inline styles. OSC 8 hyperlinks become links when their
+// target is an http, https, mailto or file URL; others stay plain text.
+func ToHTML(view string, opts ...Option) string {
+	c := newConfig(opts)
+	var b strings.Builder
+	fmt.Fprintf(&b, `<pre style="color:%s;background:%s;font-family:%s;font-size:%dpx;line-height:1.2">`,
+		hex(c.fg), hex(c.bg), html.EscapeString(c.fontFamily), c.fontSize)
+	for i, l := range parse(view) {
+		if i > 0 {
+			b.WriteByte('\n')
+		}
+		for _, r := range l {
+			text := html.EscapeString(r.text)
+			if css := c.css(r.attrs); css != "" {
+				text = `<span style="` + css + `">` + text + `</span>`
+			}
+			if linkable(r.link) {
+				text = `<a href="` + html.EscapeString(r.link) + `">` + text + `</a>`
+			}
+			b.WriteString(text)
+		}
+	}
+	b.WriteString("</pre>")
+	return b.String()
+}
+
+// css returns the inline style for a, or "" for default text.
+func (c config) css(a attrs) string {
+	var decls []string
+	fg, bg, fgSet, bgSet := c.colors(a)
+	if fgSet {
+		decls = append(decls, "color:"+hex(fg))
+	}
+	if bgSet {
+		decls = append(decls, "background:"+hex(bg))
+	}
+	if a.bold {
+		decls = append(decls, "font-weight:bold")
+	}
+	if a.faint {
+		decls = append(decls, "opacity:0.6")
+	}
+	if a.italic {
+		decls = append(decls, "font-style:italic")
+	}
+	if d := decoration(a); d != "" {
+		decls = append(decls, "text-decoration:"+d)
+	}
+	return strings.Join(decls, ";")
+}
+
+// colors resolves the run's colors, swapping them for reversed text. The
+// flags report which differ from the defaults.
+func (c config) colors(a attrs) (fg, bg frog.Color, fgSet, bgSet bool) {
+	fg, bg = c.fg, c.bg
+	if a.fg != nil {
+		fg, fgSet = *a.fg, true
+	}
+	if a.bg != nil {
+		bg, bgSet = *a.bg, true
+	}
+	if a.reverse {
+		fg, bg, fgSet, bgSet = bg, fg, true, true
+	}
+	return fg, bg, fgSet, bgSet
+}
+
+func decoration(a attrs) string {
+	var d []string
+	if a.underline {
+		d = append(d, "underline")
+	}
+	if a.strike {
+		d = append(d, "line-through")
+	}
+	return strings.Join(d, " ")
+}
+
+// ToSVG returns view as a standalone SVG image, one cell per terminal
+// column, sized to the widest line. OSC 8 hyperlinks become links under
+// the same rules as ToHTML.
+func ToSVG(view string, opts ...Option) string {
+	c := newConfig(opts)
+	lines := parse(view)
+	cw, lh := float64(c.fontSize)*0.6, float64(c.fontSize)*1.2
+	cols := 0
+	for _, l := range lines {
+		w := 0
+		for _, r := range l {
+			w += frog.DisplayWidth(r.text)
+		}
+		cols = max(cols, w)
+	}
+	width, height := float64(cols)*cw, float64(len(lines))*lh
+
+	var b strings.Builder
+	fmt.Fprintf(&b, `<svg xmlns="http://www.w3.org/2000/svg" width="%s" height="%s" viewBox="0 0 %[1]s %[2]s">`,
+		num(width), num(height))
+	fmt.Fprintf(&b, `<rect width="100%%" height="100%%" fill="%s"/>`, hex(c.bg))
+	fmt.Fprintf(&b, `<g font-family="%s" font-size="%d" fill="%s" xml:space="preserve">`,
+		html.EscapeString(c.fontFamily), c.fontSize, hex(c.fg))
+	for i, l := range lines {
+		y := float64(i) * lh
+		col := 0
+		for _, r := range l {
+			w := frog.DisplayWidth(r.text)
+			x, rw := float64(col)*cw, float64(w)*cw
+			col += w
+			fg, bg, fgSet, bgSet := c.colors(r.attrs)
+			if bgSet {
+				fmt.Fprintf(&b, `<rect x="%s" y="%s" width="%s" height="%s" fill="%s"/>`,
+					num(x), num(y), num(rw), num(lh), hex(bg))
+			}
+			if strings.TrimSpace(r.text) == "" && decoration(r.attrs) == "" {
+				continue
+			}
+			link := linkable(r.link)
+			if link {
+				fmt.Fprintf(&b, `<a href="%s">`, html.EscapeString(r.link))
+			}
+			fmt.Fprintf(&b, `<text x="%s" y="%s" textLength="%s" lengthAdjust="spacingAndGlyphs" dominant-baseline="central"`,
+				num(x), num(y+lh/2), num(rw))
+			if fgSet {
+				fmt.Fprintf(&b, ` fill="%s"`, hex(fg))
+			}
+			if r.bold {
+				b.WriteString(` font-weight="bold"`)
+			}
+			if r.faint {
+				b.WriteString(` opacity="0.6"`)
+			}
+			if r.italic {
+				b.WriteString(` font-style="italic"`)
+			}
+			if d := decoration(r.attrs); d != "" {
+				fmt.Fprintf(&b, ` text-decoration="%s"`, d)
+			}
+			fmt.Fprintf(&b, `>%s</text>`, html.EscapeString(r.text))
+			if link {
+				b.WriteString(`</a>`)
+			}
+		}
+	}
+	b.WriteString("</g></svg>")
+	return b.String()
+}
+
+// linkable reports whether target may become an href. Targets come from
+// program output, so schemes such as javascript: are left as plain text.
+func linkable(target string) bool {
+	u, err := url.Parse(target)
+	if err != nil {
+		return false
+	}
+	switch strings.ToLower(u.Scheme) {
+	case "http", "https", "mailto", "file":
+		return true
+	}
+	return false
+}
+
+func hex(c frog.Color) string {
+	r, g, b := c.RGB()
+	return fmt.Sprintf("#%02x%02x%02x", r, g, b)
+}
+
+// num formats a coordinate without needless decimals.
+func num(v float64) string { return strconv.FormatFloat(math.Round(v*100)/100, 'f', -1, 64) }
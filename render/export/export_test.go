@@ -0,0 +1,38 @@
+package export
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestLinks(t *testing.T) {
+	link := func(target, text string) string {
+		return "\x1b]8;;" + target + "\x1b\\" + text + "\x1b]8;;\x1b\\"
+	}
+	tests := []struct {
+		target string
+		href   bool
+	}{
+		{"https://example.com/a?b=1", true},
+		{"HTTP://example.com", true},
+		{"mailto:frog@example.com", true},
+		{"file:///tmp/log.txt", true},
+		{"javascript:alert(1)", false},
+		{"JavaScript:alert(1)", false},
+		{"data:text/html,<b>x</b>", false},
+		{"vbscript:msgbox", false},
+		{"relative/path", false},
+		{"http://[::1", false},
+	}
+	for _, tt := range tests {
+		view := link(tt.target, "go")
+		for name, out := range map[string]string{"html": ToHTML(view), "svg": ToSVG(view)} {
+			if got := strings.Contains(out, "href="); got != tt.href {
+				t.Errorf("%s %q: href %v, want %v\n%s", name, tt.target, got, tt.href, out)
+			}
+			if !strings.Contains(out, ">go</") {
+				t.Errorf("%s %q: text missing\n%s", name, tt.target, out)
+			}
+		}
+	}
+}
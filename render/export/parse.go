@@ -0,0 +1,184 @@
+package export
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/pondworks-lib/frog"
+)
+
+// attrs is the styling in effect for a run of text.
+type attrs struct {
+	fg, bg    *frog.Color
+	bold      bool
+	faint     bool
+	italic    bool
+	underline bool
+	reverse   bool
+	strike    bool
+	link      string // OSC 8 target
+}
+
+// run is text sharing one set of attributes.
+type run struct {
+	text string
+	attrs
+}
+
+// parse splits a rendered view into lines of styled runs. SGR sequences
+// and OSC 8 hyperlinks set the attributes; other escape sequences and
+// carriage returns are dropped.
+func parse(view string) [][]run {
+	var (
+		lines [][]run
+		line  []run
+		cur   attrs
+		text  strings.Builder
+	)
+	flush := func() {
+		if text.Len() > 0 {
+			line = append(line, run{text: text.String(), attrs: cur})
+			text.Reset()
+		}
+	}
+	for i := 0; i < len(view); {
+		c := view[i]
+		switch {
+		case c == '\n':
+			flush()
+			lines = append(lines, line)
+			line = nil
+			i++
+		case c == '\r':
+			i++
+		case c == 0x1b && i+1 < len(view) && view[i+1] == '[':
+			j := i + 2
+			for j < len(view) && (view[j] < 0x40 || view[j] > 0x7e) {
+				j++
+			}
+			if j < len(view) && view[j] == 'm' {
+				flush()
+				cur = applySGR(cur, view[i+2:j])
+			}
+			i = j + 1
+		case c == 0x1b && i+1 < len(view) && view[i+1] == ']':
+			body, n := oscBody(view[i+2:])
+			if target, ok := strings.CutPrefix(body, "8;"); ok {
+				flush()
+				_, cur.link, _ = strings.Cut(target, ";")
+			}
+			i += 2 + n
+		case c == 0x1b:
+			i += min(2, len(view)-i)
+		default:
+			j := i + 1
+			for j < len(view) && view[j] != 0x1b && view[j] != '\n' && view[j] != '\r' {
+				j++
+			}
+			text.WriteString(view[i:j])
+			i = j
+		}
+	}
+	flush()
+	return append(lines, line)
+}
+
+// oscBody returns the body of an OSC sequence starting at s, which ends
+// with BEL or ST, and the bytes consumed including the terminator.
+func oscBody(s string) (string, int) {
+	for j := 0; j < len(s); j++ {
+		switch {
+		case s[j] == 0x07:
+			return s[:j], j + 1
+		case s[j] == 0x1b && j+1 < len(s) && s[j+1] == '\\':
+			return s[:j], j + 2
+		}
+	}
+	return s, len(s)
+}
+
+// applySGR updates a with the parameters of an SGR sequence.
+func applySGR(a attrs, params string) attrs {
+	if params == "" {
+		return attrs{link: a.link}
+	}
+	codes := strings.Split(params, ";")
+	for i := 0; i < len(codes); i++ {
+		n, _ := strconv.Atoi(codes[i])
+		switch {
+		case n == 0:
+			a = attrs{link: a.link}
+		case n == 1:
+			a.bold = true
+		case n == 2:
+			a.faint = true
+		case n == 3:
+			a.italic = true
+		case n == 4:
+			a.underline = true
+		case n == 7:
+			a.reverse = true
+		case n == 9:
+			a.strike = true
+		case n == 22:
+			a.bold, a.faint = false, false
+		case n == 23:
+			a.italic = false
+		case n == 24:
+			a.underline = false
+		case n == 27:
+			a.reverse = false
+		case n == 29:
+			a.strike = false
+		case n >= 30 && n <= 37:
+			a.fg = &ansi16[n-30]
+		case n >= 90 && n <= 97:
+			a.fg = &ansi16[n-90+8]
+		case n >= 40 && n <= 47:
+			a.bg = &ansi16[n-40]
+		case n >= 100 && n <= 107:
+			a.bg = &ansi16[n-100+8]
+		case n == 39:
+			a.fg = nil
+		case n == 49:
+			a.bg = nil
+		case n == 38 || n == 48:
+			c, used := extendedColor(codes[i+1:])
+			i += used
+			if c == nil {
+				break
+			}
+			if n == 38 {
+				a.fg = c
+			} else {
+				a.bg = c
+			}
+		}
+	}
+	return a
+}
+
+// extendedColor parses the parameters after 38 or 48: "5;n" or
+// "2;r;g;b". It returns the number of parameters used.
+func extendedColor(p []string) (*frog.Color, int) {
+	num := func(i int) uint8 {
+		n, _ := strconv.Atoi(p[i])
+		return uint8(n)
+	}
+	switch {
+	case len(p) >= 2 && p[0] == "5":
+		c := frog.ANSI256(num(1))
+		return &c, 2
+	case len(p) >= 4 && p[0] == "2":
+		c := frog.RGB(num(1), num(2), num(3))
+		return &c, 4
+	}
+	return nil, len(p)
+}
+
+var ansi16 = [16]frog.Color{
+	frog.ColorBlack, frog.ColorRed, frog.ColorGreen, frog.ColorYellow,
+	frog.ColorBlue, frog.ColorMagenta, frog.ColorCyan, frog.ColorWhite,
+	frog.ColorBrightBlack, frog.ColorBrightRed, frog.ColorBrightGreen, frog.ColorBrightYellow,
+	frog.ColorBrightBlue, frog.ColorBrightMagenta, frog.ColorBrightCyan, frog.ColorBrightWhite,
+}
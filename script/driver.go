@@ -0,0 +1,80 @@
+package script
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+
+	"github.com/pondworks-lib/frog"
+)
+
+// Attach drives an interactive session running in the same process.
+func Attach(app *frog.App) Driver { return appDriver{app} }
+
+type appDriver struct{ app *frog.App }
+
+func (d appDriver) Send(msg frog.Msg) error {
+	d.app.Send(msg)
+	d.app.Model() // wait for the loop so a long "type" can't overflow the queue
+	return nil
+}
+
+func (d appDriver) Screen() (string, error) { return d.app.Screen(), nil }
+
+// Client drives a session through its control endpoint.
+type Client struct {
+	conn net.Conn
+	r    *bufio.Scanner
+}
+
+// Dial connects to a session serving ServeControl at addr.
+func Dial(network, addr string) (*Client, error) {
+	conn, err := net.Dial(network, addr)
+	if err != nil {
+		return nil, err
+	}
+	r := bufio.NewScanner(conn)
+	r.Buffer(nil, 1<<24)
+	return &Client{conn: conn, r: r}, nil
+}
+
+// Close closes the connection.
+func (c *Client) Close() error { return c.conn.Close() }
+
+// Send delivers msg, which must be registered with frog.RegisterMsg.
+func (c *Client) Send(msg frog.Msg) error {
+	b, err := frog.EncodeMsg(msg)
+	if err != nil {
+		return err
+	}
+	_, err = c.call(b)
+	return err
+}
+
+// Screen returns the session's current view.
+func (c *Client) Screen() (string, error) {
+	rep, err := c.call([]byte(`{"type":"` + frog.ControlScreenshot + `"}`))
+	return rep.Screen, err
+}
+
+func (c *Client) call(req []byte) (frog.ControlReply, error) {
+	var rep frog.ControlReply
+	if _, err := c.conn.Write(append(req, '\n')); err != nil {
+		return rep, err
+	}
+	if !c.r.Scan() {
+		if err := c.r.Err(); err != nil {
+			return rep, err
+		}
+		return rep, errors.New("control connection closed")
+	}
+	if err := json.Unmarshal(c.r.Bytes(), &rep); err != nil {
+		return rep, err
+	}
+	if !rep.OK {
+		return rep, fmt.Errorf("control: %s", rep.Error)
+	}
+	return rep, nil
+}
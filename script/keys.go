@@ -0,0 +1,61 @@
+package script
+
+import (
+	"strings"
+	"unicode/utf8"
+
+	"github.com/pondworks-lib/frog"
+)
+
+var namedKeys = map[string]string{
+	"enter":     "\r",
+	"tab":       "\t",
+	"backspace": "\x7f",
+	"esc":       "\x1b",
+	"space":     " ",
+	"up":        "\x1b[A",
+	"down":      "\x1b[B",
+	"right":     "\x1b[C",
+	"left":      "\x1b[D",
+	"home":      "\x1b[H",
+	"end":       "\x1b[F",
+	"delete":    "\x1b[3~",
+	"pgup":      "\x1b[5~",
+	"pgdown":    "\x1b[6~",
+}
+
+// keySequence maps a key name such as "enter", "ctrl+c", "alt+x" or a
+// single character to the bytes a terminal sends for it.
+func keySequence(name string) (string, bool) {
+	lower := strings.ToLower(name)
+	if seq, ok := namedKeys[lower]; ok {
+		return seq, true
+	}
+	if rest, ok := strings.CutPrefix(lower, "ctrl+"); ok {
+		if len(rest) == 1 && rest[0] >= 'a' && rest[0] <= 'z' {
+			return string(rune(rest[0] - 'a' + 1)), true
+		}
+		return "", false
+	}
+	if strings.HasPrefix(lower, "alt+") {
+		if seq, ok := keySequence(name[len("alt+"):]); ok {
+			return "\x1b" + seq, true
+		}
+		return "", false
+	}
+	if utf8.RuneCountInString(name) == 1 {
+		return name, true
+	}
+	return "", false
+}
+
+// key decodes seq into the KeyMsg a terminal would produce.
+func key(seq string) frog.Msg {
+	if seq == "\x1b" {
+		return frog.KeyMsg{Type: frog.KeyEsc, String: seq}
+	}
+	if msg, n := frog.ParseSequence([]byte(seq)); n == len(seq) && msg != nil {
+		return msg
+	}
+	return frog.KeyMsg{Type: frog.KeyRune, String: seq}
+}
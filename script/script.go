@@ -0,0 +1,215 @@
+// Package script runs end-to-end tests written in a small line-based
+// language against a running frog program, either in-process or through
+// the session's control endpoint (Session.ServeControl).
+//
+//	# login.frog
+//	expect 'Name:'
+//	type hello
+//	press enter
+//	expect 'Done'
+//
+// Commands, one per line; blank lines and lines starting with # are ignored:
+//
+//	type <text>          send text as key presses
+//	press <key> [...]    send keys: enter, esc, tab, up, ctrl+c, alt+x, a ...
+//	paste <text>         send a bracketed paste
+//	resize <w> <h>       send a resize
+//	expect <text>        wait until the screen contains text
+//	refute <text>        fail if the screen contains text
+//	sleep <duration>     pause, e.g. sleep 200ms
+//	timeout <duration>   how long expect waits (default 2s)
+//	quit                 ask the program to quit
+//
+// Text may be bare (the rest of the line) or quoted with ' or ". Quoted
+// text understands Go escapes such as \n and \t.
+package script
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pondworks-lib/frog"
+)
+
+// Driver is what a script runs against.
+type Driver interface {
+	Send(msg frog.Msg) error
+	Screen() (string, error)
+}
+
+// Step is one parsed script line.
+type Step struct {
+	Line int
+	Cmd  string
+	Args []string
+}
+
+// Script is a parsed script.
+type Script struct {
+	Name  string
+	Steps []Step
+}
+
+// Error reports the script line that failed.
+type Error struct {
+	Name string
+	Line int
+	Err  error
+}
+
+func (e *Error) Error() string { return fmt.Sprintf("%s:%d: %v", e.Name, e.Line, e.Err) }
+
+func (e *Error) Unwrap() error { return e.Err }
+
+// DefaultTimeout is how long expect waits unless the script sets timeout.
+const DefaultTimeout = 2 * time.Second
+
+// pollInterval is how often expect re-reads the screen.
+const pollInterval = 20 * time.Millisecond
+
+// Parse reads a script; name is used in error messages.
+func Parse(name string, r io.Reader) (*Script, error) {
+	s := &Script{Name: name}
+	sc := bufio.NewScanner(r)
+	for n := 1; sc.Scan(); n++ {
+		line := strings.TrimSpace(sc.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		cmd, rest, _ := strings.Cut(line, " ")
+		rest = strings.TrimSpace(rest)
+		var args []string
+		var err error
+		switch cmd {
+		case "type", "paste", "expect", "refute":
+			var text string
+			text, err = parseText(rest)
+			args = []string{text}
+		case "press":
+			args = strings.Fields(rest)
+			if len(args) == 0 {
+				err = fmt.Errorf("press needs a key")
+			}
+			for _, k := range args {
+				if _, ok := keySequence(k); !ok && err == nil {
+					err = fmt.Errorf("unknown key %q", k)
+				}
+			}
+		case "resize":
+			args = strings.Fields(rest)
+			if len(args) != 2 {
+				err = fmt.Errorf("resize needs width and height")
+			}
+			for _, a := range args {
+				if _, e := strconv.Atoi(a); e != nil && err == nil {
+					err = fmt.Errorf("bad size %q", a)
+				}
+			}
+		case "sleep", "timeout":
+			if _, e := time.ParseDuration(rest); e != nil {
+				err = fmt.Errorf("bad duration %q", rest)
+			}
+			args = []string{rest}
+		case "quit":
+		default:
+			err = fmt.Errorf("unknown command %q", cmd)
+		}
+		if err != nil {
+			return nil, &Error{Name: name, Line: n, Err: err}
+		}
+		s.Steps = append(s.Steps, Step{Line: n, Cmd: cmd, Args: args})
+	}
+	if err := sc.Err(); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+func parseText(s string) (string, error) {
+	if len(s) >= 2 && (s[0] == '"' || s[0] == '\'') && s[len(s)-1] == s[0] {
+		body := s[1 : len(s)-1]
+		if s[0] == '\'' {
+			body = strings.ReplaceAll(body, `"`, `\"`)
+		}
+		return strconv.Unquote(`"` + body + `"`)
+	}
+	return s, nil
+}
+
+// Run executes the script against d, stopping at the first failing step.
+func (s *Script) Run(ctx context.Context, d Driver) error {
+	timeout := DefaultTimeout
+	for _, st := range s.Steps {
+		var err error
+		switch st.Cmd {
+		case "type":
+			for _, r := range st.Args[0] {
+				if err = d.Send(key(string(r))); err != nil {
+					break
+				}
+			}
+		case "press":
+			for _, k := range st.Args {
+				seq, _ := keySequence(k)
+				if err = d.Send(key(seq)); err != nil {
+					break
+				}
+			}
+		case "paste":
+			err = d.Send(frog.PasteMsg{Text: st.Args[0]})
+		case "resize":
+			w, _ := strconv.Atoi(st.Args[0])
+			h, _ := strconv.Atoi(st.Args[1])
+			err = d.Send(frog.ResizeMsg{Width: w, Height: h})
+		case "expect":
+			err = expect(ctx, d, st.Args[0], timeout)
+		case "refute":
+			var screen string
+			if screen, err = d.Screen(); err == nil && strings.Contains(frog.StripANSI(screen), st.Args[0]) {
+				err = fmt.Errorf("screen contains %q:\n%s", st.Args[0], frog.StripANSI(screen))
+			}
+		case "sleep":
+			dur, _ := time.ParseDuration(st.Args[0])
+			select {
+			case <-time.After(dur):
+			case <-ctx.Done():
+				err = ctx.Err()
+			}
+		case "timeout":
+			timeout, _ = time.ParseDuration(st.Args[0])
+		case "quit":
+			err = d.Send(frog.QuitMsg{})
+		}
+		if err != nil {
+			return &Error{Name: s.Name, Line: st.Line, Err: err}
+		}
+	}
+	return nil
+}
+
+func expect(ctx context.Context, d Driver, text string, timeout time.Duration) error {
+	deadline := time.Now().Add(timeout)
+	for {
+		screen, err := d.Screen()
+		if err != nil {
+			return err
+		}
+		plain := frog.StripANSI(screen)
+		if strings.Contains(plain, text) {
+			return nil
+		}
+		if time.Now().After(deadline) {
+			return fmt.Errorf("timed out after %v waiting for %q; screen:\n%s", timeout, text, plain)
+		}
+		select {
+		case <-time.After(pollInterval):
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}